@@ -4,7 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
 )
 
 // ApplicationCommandOptionType represents the type of an application command option.
@@ -377,6 +382,32 @@ type ApplicationCommandOptionSubCommand struct {
 	Options []ApplicationCommandOption `json:"options,omitempty"`
 }
 
+var _ json.Unmarshaler = (*ApplicationCommandOptionSubCommand)(nil)
+
+func (o *ApplicationCommandOptionSubCommand) UnmarshalJSON(buf []byte) error {
+	var temp struct {
+		OptionBase
+		Options []json.RawMessage `json:"options"`
+	}
+	if err := json.Unmarshal(buf, &temp); err != nil {
+		return err
+	}
+
+	o.OptionBase = temp.OptionBase
+	o.Options = nil
+	if temp.Options != nil {
+		o.Options = make([]ApplicationCommandOption, 0, len(temp.Options))
+		for i := range len(temp.Options) {
+			option, err := UnmarshalApplicationCommandOption(temp.Options[i])
+			if err != nil {
+				return err
+			}
+			o.Options = append(o.Options, option)
+		}
+	}
+	return nil
+}
+
 func (o *ApplicationCommandOptionSubCommand) MarshalJSON() ([]byte, error) {
 	type NoMethod ApplicationCommandOptionSubCommand
 	return json.Marshal((*NoMethod)(o))
@@ -477,7 +508,7 @@ func UnmarshalApplicationCommandOption(buf []byte) (ApplicationCommandOption, er
 		var o ApplicationCommandOptionSubCommand
 		return &o, json.Unmarshal(buf, &o)
 	case ApplicationCommandOptionTypeSubCommandGroup:
-		var o ApplicationCommandOptionSubCommand
+		var o ApplicationCommandOptionSubCommandGroup
 		return &o, json.Unmarshal(buf, &o)
 	case ApplicationCommandOptionTypeString:
 		var o ApplicationCommandOptionString
@@ -857,3 +888,639 @@ func UnmarshalApplicationCommand(buf []byte) (ApplicationCommand, error) {
 		return nil, errors.New("unknown application command type")
 	}
 }
+
+/*****************************
+ *   Command Option Builders
+ *****************************/
+
+// NewSubCommand returns a sub-command option with the given parameters.
+func NewSubCommand(name, description string, options ...ApplicationCommandOption) *ApplicationCommandOptionSubCommand {
+	return &ApplicationCommandOptionSubCommand{
+		OptionBase: OptionBase{Type: ApplicationCommandOptionTypeSubCommand, Name: name, Description: description},
+		Options:    options,
+	}
+}
+
+// NewSubCommandGroup returns a sub-command group option holding the given sub-commands.
+func NewSubCommandGroup(name, description string, subCommands ...*ApplicationCommandOptionSubCommand) *ApplicationCommandOptionSubCommandGroup {
+	group := &ApplicationCommandOptionSubCommandGroup{
+		OptionBase: OptionBase{Type: ApplicationCommandOptionTypeSubCommandGroup, Name: name, Description: description},
+		Options:    make([]ApplicationCommandOptionSubCommand, 0, len(subCommands)),
+	}
+	for _, subCommand := range subCommands {
+		group.Options = append(group.Options, *subCommand)
+	}
+	return group
+}
+
+// NewStringOption returns a string option.
+func NewStringOption(name, description string, required bool) *ApplicationCommandOptionString {
+	return &ApplicationCommandOptionString{
+		OptionBase:   OptionBase{Type: ApplicationCommandOptionTypeString, Name: name, Description: description},
+		RequiredBase: RequiredBase{Required: required},
+	}
+}
+
+// AddChoice adds a choice to the option and returns the option for chaining.
+func (o *ApplicationCommandOptionString) AddChoice(name, value string) *ApplicationCommandOptionString {
+	o.Choices = append(o.Choices, ApplicationCommandOptionChoiceString{ChoiceOptionBase{Name: name}, value})
+	return o
+}
+
+// NewIntegerOption returns an integer option.
+func NewIntegerOption(name, description string, required bool) *ApplicationCommandOptionInteger {
+	return &ApplicationCommandOptionInteger{
+		OptionBase:   OptionBase{Type: ApplicationCommandOptionTypeInteger, Name: name, Description: description},
+		RequiredBase: RequiredBase{Required: required},
+	}
+}
+
+// AddChoice adds a choice to the option and returns the option for chaining.
+func (o *ApplicationCommandOptionInteger) AddChoice(name string, value int) *ApplicationCommandOptionInteger {
+	o.Choices = append(o.Choices, ApplicationCommandOptionChoiceInteger{ChoiceOptionBase{Name: name}, value})
+	return o
+}
+
+// NewFloatOption returns a float (number) option.
+func NewFloatOption(name, description string, required bool) *ApplicationCommandOptionFloat {
+	return &ApplicationCommandOptionFloat{
+		OptionBase:   OptionBase{Type: ApplicationCommandOptionTypeFloat, Name: name, Description: description},
+		RequiredBase: RequiredBase{Required: required},
+	}
+}
+
+// AddChoice adds a choice to the option and returns the option for chaining.
+func (o *ApplicationCommandOptionFloat) AddChoice(name string, value float64) *ApplicationCommandOptionFloat {
+	o.Choices = append(o.Choices, ApplicationCommandOptionChoiceFloat{ChoiceOptionBase{Name: name}, value})
+	return o
+}
+
+// NewBoolOption returns a boolean option.
+func NewBoolOption(name, description string, required bool) *ApplicationCommandOptionBool {
+	return &ApplicationCommandOptionBool{
+		OptionBase:   OptionBase{Type: ApplicationCommandOptionTypeBool, Name: name, Description: description},
+		RequiredBase: RequiredBase{Required: required},
+	}
+}
+
+// NewUserOption returns a user option.
+func NewUserOption(name, description string, required bool) *ApplicationCommandOptionUser {
+	return &ApplicationCommandOptionUser{
+		OptionBase:   OptionBase{Type: ApplicationCommandOptionTypeUser, Name: name, Description: description},
+		RequiredBase: RequiredBase{Required: required},
+	}
+}
+
+// NewChannelOption returns a channel option, restricted to channelTypes if any are given.
+func NewChannelOption(name, description string, required bool, channelTypes ...ChannelType) *ApplicationCommandOptionChannel {
+	return &ApplicationCommandOptionChannel{
+		OptionBase:         OptionBase{Type: ApplicationCommandOptionTypeChannel, Name: name, Description: description},
+		RequiredBase:       RequiredBase{Required: required},
+		ChannelConstraints: ChannelConstraints{ChannelTypes: channelTypes},
+	}
+}
+
+// NewRoleOption returns a role option.
+func NewRoleOption(name, description string, required bool) *ApplicationCommandOptionRole {
+	return &ApplicationCommandOptionRole{
+		OptionBase:   OptionBase{Type: ApplicationCommandOptionTypeRole, Name: name, Description: description},
+		RequiredBase: RequiredBase{Required: required},
+	}
+}
+
+// NewMentionableOption returns a mentionable (users and roles) option.
+func NewMentionableOption(name, description string, required bool) *ApplicationCommandOptionMentionable {
+	return &ApplicationCommandOptionMentionable{
+		OptionBase:   OptionBase{Type: ApplicationCommandOptionTypeMentionable, Name: name, Description: description},
+		RequiredBase: RequiredBase{Required: required},
+	}
+}
+
+// NewAttachmentOption returns an attachment option.
+func NewAttachmentOption(name, description string, required bool) *ApplicationCommandOptionAttachment {
+	return &ApplicationCommandOptionAttachment{
+		OptionBase:   OptionBase{Type: ApplicationCommandOptionTypeAttachment, Name: name, Description: description},
+		RequiredBase: RequiredBase{Required: required},
+	}
+}
+
+/*****************************
+ *   Command Registration
+ *****************************/
+
+// ApplicationCommandOptions contains parameters for creating an application command.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#create-global-application-command-json-params
+type ApplicationCommandOptions struct {
+	// Name is the name of the command (1-32 characters).
+	Name string `json:"name"`
+
+	// NameLocalizations is a localization dictionary for the name field.
+	NameLocalizations map[Locale]string `json:"name_localizations,omitempty"`
+
+	// Description is the description of the command (1-100 characters).
+	//
+	// Note:
+	//   - Required for chat input commands, must be empty for user and message commands.
+	Description string `json:"description,omitempty"`
+
+	// DescriptionLocalizations is a localization dictionary for the description field.
+	DescriptionLocalizations map[Locale]string `json:"description_localizations,omitempty"`
+
+	// Options is the list of parameters of a chat input command (max 25).
+	Options []ApplicationCommandOption `json:"options,omitempty"`
+
+	// DefaultMemberPermissions is the set of permissions required to use the command.
+	//
+	// Optional:
+	//   - Leave it None to allow everyone, use Some(0) to only allow admins.
+	DefaultMemberPermissions optional.Option[Permissions] `json:"default_member_permissions,omitzero"`
+
+	// IntegrationTypes is the list of installation contexts where the command is available.
+	//
+	// Optional:
+	//   - Leave it nil to use the installation contexts of the app, an empty list is sent as is.
+	IntegrationTypes []ApplicationIntegrationType `json:"integration_types,omitzero"`
+
+	// Contexts is the list of interaction contexts where the command can be used.
+	//
+	// Optional:
+	//   - Leave it nil to allow all the interaction contexts, an empty list is sent as is.
+	Contexts []InteractionContextType `json:"contexts,omitzero"`
+
+	// Type is the type of the command, defaults to ApplicationCommandTypeChatInput.
+	Type ApplicationCommandType `json:"type,omitempty"`
+
+	// NSFW indicates whether the command is age-restricted.
+	NSFW bool `json:"nsfw,omitempty"`
+
+	// Handler determines how a primary entry point command is handled.
+	Handler ApplicationCommandHandlerType `json:"handler,omitempty"`
+}
+
+// NewChatInputCommandOptions returns the parameters to create a slash command with the given options.
+//
+// Usage example:
+//
+//	cmd := dwaz.NewChatInputCommandOptions("config", "Configure the bot",
+//		dwaz.NewSubCommand("language", "Set the language",
+//			dwaz.NewStringOption("value", "The language", true).
+//				AddChoice("English", "en").
+//				AddChoice("French", "fr"),
+//		),
+//	)
+func NewChatInputCommandOptions(name, description string, options ...ApplicationCommandOption) ApplicationCommandOptions {
+	return ApplicationCommandOptions{
+		Type:        ApplicationCommandTypeChatInput,
+		Name:        name,
+		Description: description,
+		Options:     options,
+	}
+}
+
+// SetIntegrationTypes sets the installation contexts where the command is available.
+//
+// Note:
+//   - Calling it with no integration types makes the command invalid, see ValidateIntegrationTypes.
+//
+// Returns the options for chaining.
+func (o *ApplicationCommandOptions) SetIntegrationTypes(integrationTypes ...ApplicationIntegrationType) *ApplicationCommandOptions {
+	o.IntegrationTypes = append([]ApplicationIntegrationType{}, integrationTypes...)
+	return o
+}
+
+// SetContexts sets the interaction contexts where the command can be used.
+//
+// Returns the options for chaining.
+func (o *ApplicationCommandOptions) SetContexts(contexts ...InteractionContextType) *ApplicationCommandOptions {
+	o.Contexts = append([]InteractionContextType{}, contexts...)
+	return o
+}
+
+// SetUserInstallable makes the command available to both guild and user installs of the app,
+// usable in guilds, bot DMs and private channels.
+//
+// Usage example:
+//
+//	cmd := dwaz.NewChatInputCommandOptions("ping", "Replies with pong")
+//	cmd.SetUserInstallable()
+//
+// Returns the options for chaining.
+func (o *ApplicationCommandOptions) SetUserInstallable() *ApplicationCommandOptions {
+	return o.
+		SetIntegrationTypes(ApplicationIntegrationTypeGuildInstall, ApplicationIntegrationTypeUserInstall).
+		SetContexts(InteractionContextTypeGuild, InteractionContextTypeBotDM, InteractionContextTypePrivateChannel)
+}
+
+// ValidateIntegrationTypes checks the installation settings of the command, it's called before
+// the command is registered.
+//
+// Returns an error if IntegrationTypes was set but is empty, or if IntegrationTypes or Contexts
+// contain an unknown value. A nil IntegrationTypes is valid and falls back to the app's configuration.
+func (o *ApplicationCommandOptions) ValidateIntegrationTypes() error {
+	if o.IntegrationTypes != nil && len(o.IntegrationTypes) == 0 {
+		return errors.New("application command must have at least one integration type")
+	}
+	for _, integrationType := range o.IntegrationTypes {
+		if integrationType != ApplicationIntegrationTypeGuildInstall && integrationType != ApplicationIntegrationTypeUserInstall {
+			return fmt.Errorf("unknown application integration type %d", integrationType)
+		}
+	}
+	for _, context := range o.Contexts {
+		if context < InteractionContextTypeGuild || context > InteractionContextTypePrivateChannel {
+			return fmt.Errorf("unknown interaction context type %d", context)
+		}
+	}
+	return nil
+}
+
+// ModifyApplicationCommandOptions contains parameters for editing an application command.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#edit-global-application-command-json-params
+type ModifyApplicationCommandOptions struct {
+	// Name is the name of the command (1-32 characters).
+	Name optional.Option[string] `json:"name,omitzero"`
+
+	// NameLocalizations is a localization dictionary for the name field.
+	NameLocalizations optional.Option[map[Locale]string] `json:"name_localizations,omitzero"`
+
+	// Description is the description of the command (1-100 characters).
+	Description optional.Option[string] `json:"description,omitzero"`
+
+	// DescriptionLocalizations is a localization dictionary for the description field.
+	DescriptionLocalizations optional.Option[map[Locale]string] `json:"description_localizations,omitzero"`
+
+	// Options replaces the parameters of the command.
+	Options optional.Option[[]ApplicationCommandOption] `json:"options,omitzero"`
+
+	// DefaultMemberPermissions is the set of permissions required to use the command.
+	DefaultMemberPermissions optional.Option[Permissions] `json:"default_member_permissions,omitzero"`
+
+	// IntegrationTypes is the list of installation contexts where the command is available.
+	IntegrationTypes optional.Option[[]ApplicationIntegrationType] `json:"integration_types,omitzero"`
+
+	// Contexts is the list of interaction contexts where the command can be used.
+	Contexts optional.Option[[]InteractionContextType] `json:"contexts,omitzero"`
+
+	// NSFW indicates whether the command is age-restricted.
+	NSFW optional.Option[bool] `json:"nsfw,omitzero"`
+
+	// Handler determines how a primary entry point command is handled.
+	Handler optional.Option[ApplicationCommandHandlerType] `json:"handler,omitzero"`
+}
+
+// decodeApplicationCommands decodes a list of application commands of any type.
+func decodeApplicationCommands(r io.Reader) ([]ApplicationCommand, error) {
+	var raws []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raws); err != nil {
+		return nil, err
+	}
+
+	commands := make([]ApplicationCommand, 0, len(raws))
+	for _, raw := range raws {
+		command, err := UnmarshalApplicationCommand(raw)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
+
+// globalCommandsEndpoint returns the endpoint of the global commands of an application.
+func globalCommandsEndpoint(applicationID Snowflake) string {
+	return "/applications/" + applicationID.String() + "/commands"
+}
+
+// guildCommandsEndpoint returns the endpoint of the commands of an application in a guild.
+func guildCommandsEndpoint(applicationID, guildID Snowflake) string {
+	return "/applications/" + applicationID.String() + "/guilds/" + guildID.String() + "/commands"
+}
+
+// RegisterGlobalCommands overwrites all global commands of the application with cmds.
+//
+// Commands that don't exist are created, existing ones are updated and the ones not listed are deleted.
+// Unlike creating commands one by one, this doesn't count toward the daily command creation limit
+// for commands that already exist.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-global-application-commands
+func (r *requester) RegisterGlobalCommands(applicationID Snowflake, cmds []ApplicationCommandOptions) result.Result[[]ApplicationCommand] {
+	return r.overwriteCommands(globalCommandsEndpoint(applicationID), "/applications/{id}/commands", cmds)
+}
+
+// CreateGlobalCommand creates a global command.
+//
+// Note:
+//   - Creating a command with the same name as an existing one of the same type overwrites it.
+//   - Applications can create up to 200 new commands per day.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#create-global-application-command
+func (r *requester) CreateGlobalCommand(applicationID Snowflake, opts ApplicationCommandOptions) result.Result[ApplicationCommand] {
+	return r.createCommand(globalCommandsEndpoint(applicationID), "/applications/{id}/commands", opts)
+}
+
+// FetchGlobalCommands returns all global commands of the application.
+//
+// If withLocalizations is true, the full localization dictionaries are included.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#get-global-application-commands
+func (r *requester) FetchGlobalCommands(applicationID Snowflake, withLocalizations bool) result.Result[[]ApplicationCommand] {
+	return r.fetchCommands(globalCommandsEndpoint(applicationID), "/applications/{id}/commands", withLocalizations)
+}
+
+// FetchGlobalCommand returns a global command of the application.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#get-global-application-command
+func (r *requester) FetchGlobalCommand(applicationID, commandID Snowflake) result.Result[ApplicationCommand] {
+	return r.fetchCommand(globalCommandsEndpoint(applicationID)+"/"+commandID.String(), "/applications/{id}/commands/{id}")
+}
+
+// ModifyGlobalCommand edits a global command, only the fields that are set are updated.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#edit-global-application-command
+func (r *requester) ModifyGlobalCommand(applicationID, commandID Snowflake, opts ModifyApplicationCommandOptions) result.Result[ApplicationCommand] {
+	return r.modifyCommand(globalCommandsEndpoint(applicationID)+"/"+commandID.String(), "/applications/{id}/commands/{id}", opts)
+}
+
+// DeleteGlobalCommand deletes a global command.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#delete-global-application-command
+func (r *requester) DeleteGlobalCommand(applicationID, commandID Snowflake) result.Void {
+	return r.deleteCommand(globalCommandsEndpoint(applicationID) + "/" + commandID.String())
+}
+
+// RegisterGuildCommands overwrites all commands of the application in a guild with cmds.
+//
+// Guild commands are available instantly, which makes them handy while developing commands.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-guild-application-commands
+func (r *requester) RegisterGuildCommands(applicationID, guildID Snowflake, cmds []ApplicationCommandOptions) result.Result[[]ApplicationCommand] {
+	return r.overwriteCommands(guildCommandsEndpoint(applicationID, guildID), "/applications/{id}/guilds/{id}/commands", cmds)
+}
+
+// CreateGuildCommand creates a command of the application in a guild.
+//
+// Note:
+//   - Creating a command with the same name as an existing one of the same type overwrites it.
+//   - Applications can create up to 200 new commands per day per guild.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#create-guild-application-command
+func (r *requester) CreateGuildCommand(applicationID, guildID Snowflake, opts ApplicationCommandOptions) result.Result[ApplicationCommand] {
+	return r.createCommand(guildCommandsEndpoint(applicationID, guildID), "/applications/{id}/guilds/{id}/commands", opts)
+}
+
+// FetchGuildCommands returns all commands of the application in a guild.
+//
+// If withLocalizations is true, the full localization dictionaries are included.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#get-guild-application-commands
+func (r *requester) FetchGuildCommands(applicationID, guildID Snowflake, withLocalizations bool) result.Result[[]ApplicationCommand] {
+	return r.fetchCommands(guildCommandsEndpoint(applicationID, guildID), "/applications/{id}/guilds/{id}/commands", withLocalizations)
+}
+
+// FetchGuildCommand returns a command of the application in a guild.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#get-guild-application-command
+func (r *requester) FetchGuildCommand(applicationID, guildID, commandID Snowflake) result.Result[ApplicationCommand] {
+	return r.fetchCommand(guildCommandsEndpoint(applicationID, guildID)+"/"+commandID.String(), "/applications/{id}/guilds/{id}/commands/{id}")
+}
+
+// ModifyGuildCommand edits a command of the application in a guild, only the fields that are set are updated.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#edit-guild-application-command
+func (r *requester) ModifyGuildCommand(applicationID, guildID, commandID Snowflake, opts ModifyApplicationCommandOptions) result.Result[ApplicationCommand] {
+	return r.modifyCommand(guildCommandsEndpoint(applicationID, guildID)+"/"+commandID.String(), "/applications/{id}/guilds/{id}/commands/{id}", opts)
+}
+
+// DeleteGuildCommand deletes a command of the application in a guild.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#delete-guild-application-command
+func (r *requester) DeleteGuildCommand(applicationID, guildID, commandID Snowflake) result.Void {
+	return r.deleteCommand(guildCommandsEndpoint(applicationID, guildID) + "/" + commandID.String())
+}
+
+// overwriteCommands replaces all the commands at endpoint with cmds, route is the endpoint template used in logs.
+func (r *requester) overwriteCommands(endpoint, route string, cmds []ApplicationCommandOptions) result.Result[[]ApplicationCommand] {
+	if cmds == nil {
+		// A null body is rejected, send an empty list to delete all commands.
+		cmds = []ApplicationCommandOptions{}
+	}
+	for i := range cmds {
+		if err := cmds[i].ValidateIntegrationTypes(); err != nil {
+			return result.Err[[]ApplicationCommand](fmt.Errorf("command %q: %w", cmds[i].Name, err))
+		}
+	}
+	reqBody, err := json.Marshal(cmds)
+	if err != nil {
+		return result.Err[[]ApplicationCommand](err)
+	}
+
+	res := r.DoRequest(Request{Method: "PUT", URL: endpoint, Body: reqBody})
+	if res.IsErr() {
+		return result.Err[[]ApplicationCommand](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	commands, err := decodeApplicationCommands(body)
+	if err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PUT",
+			"url":    route,
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]ApplicationCommand](err)
+	}
+	return result.Ok(commands)
+}
+
+// createCommand creates a command at endpoint, route is the endpoint template used in logs.
+func (r *requester) createCommand(endpoint, route string, opts ApplicationCommandOptions) result.Result[ApplicationCommand] {
+	if err := opts.ValidateIntegrationTypes(); err != nil {
+		return result.Err[ApplicationCommand](err)
+	}
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[ApplicationCommand](err)
+	}
+	return r.doCommandRequest(Request{Method: "POST", URL: endpoint, Body: reqBody}, route)
+}
+
+// fetchCommands returns the commands at endpoint, route is the endpoint template used in logs.
+func (r *requester) fetchCommands(endpoint, route string, withLocalizations bool) result.Result[[]ApplicationCommand] {
+	if withLocalizations {
+		endpoint += "?with_localizations=true"
+	}
+
+	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	if res.IsErr() {
+		return result.Err[[]ApplicationCommand](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	commands, err := decodeApplicationCommands(body)
+	if err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    route,
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]ApplicationCommand](err)
+	}
+	return result.Ok(commands)
+}
+
+// fetchCommand returns the command at endpoint, route is the endpoint template used in logs.
+func (r *requester) fetchCommand(endpoint, route string) result.Result[ApplicationCommand] {
+	return r.doCommandRequest(Request{Method: "GET", URL: endpoint}, route)
+}
+
+// modifyCommand edits the command at endpoint, route is the endpoint template used in logs.
+func (r *requester) modifyCommand(endpoint, route string, opts ModifyApplicationCommandOptions) result.Result[ApplicationCommand] {
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[ApplicationCommand](err)
+	}
+	return r.doCommandRequest(Request{Method: "PATCH", URL: endpoint, Body: reqBody}, route)
+}
+
+// deleteCommand deletes the command at endpoint.
+func (r *requester) deleteCommand(endpoint string) result.Void {
+	res := r.DoRequest(Request{Method: "DELETE", URL: endpoint})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// doCommandRequest sends req and decodes the application command it returns.
+func (r *requester) doCommandRequest(req Request, route string) result.Result[ApplicationCommand] {
+	res := r.DoRequest(req)
+	if res.IsErr() {
+		return result.Err[ApplicationCommand](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	buf, err := io.ReadAll(body)
+	if err != nil {
+		return result.Err[ApplicationCommand](err)
+	}
+	command, err := UnmarshalApplicationCommand(buf)
+	if err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": req.Method,
+			"url":    route,
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[ApplicationCommand](err)
+	}
+	return result.Ok(command)
+}
+
+/*****************************
+ *   Command Permissions
+ *****************************/
+
+// ApplicationCommandPermissionType is the type of the target of a command permission.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-application-command-permission-type
+type ApplicationCommandPermissionType int
+
+const (
+	ApplicationCommandPermissionTypeRole ApplicationCommandPermissionType = iota + 1
+	ApplicationCommandPermissionTypeUser
+	ApplicationCommandPermissionTypeChannel
+)
+
+// ApplicationCommandPermission allows or denies a command to a role, user or channel.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-application-command-permissions-structure
+type ApplicationCommandPermission struct {
+	// ID is the ID of the role, user or channel.
+	//
+	// Note:
+	//   - The guild ID targets the @everyone role, guild ID - 1 targets all channels.
+	ID Snowflake `json:"id"`
+
+	// Type is the type of the target.
+	Type ApplicationCommandPermissionType `json:"type"`
+
+	// Permission is true to allow the command, false to deny it.
+	Permission bool `json:"permission"`
+}
+
+// GuildApplicationCommandPermissions holds the permissions of a command in a guild.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-guild-application-command-permissions-structure
+type GuildApplicationCommandPermissions struct {
+	// ID is the ID of the command, or the application ID when the permissions apply to all its commands.
+	ID Snowflake `json:"id"`
+
+	// ApplicationID is the ID of the application the command belongs to.
+	ApplicationID Snowflake `json:"application_id"`
+
+	// GuildID is the ID of the guild.
+	GuildID Snowflake `json:"guild_id"`
+
+	// Permissions is the list of permissions of the command in the guild (max 100).
+	Permissions []ApplicationCommandPermission `json:"permissions"`
+}
+
+// AppliesToAllCommands reports whether the permissions are the defaults of all commands of the application.
+func (p *GuildApplicationCommandPermissions) AppliesToAllCommands() bool {
+	return p.ID == p.ApplicationID
+}
+
+// FetchGuildCommandPermissions returns the permissions of all commands of the application in a guild.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#get-guild-application-command-permissions
+func (r *requester) FetchGuildCommandPermissions(applicationID, guildID Snowflake) result.Result[[]GuildApplicationCommandPermissions] {
+	res := r.DoRequest(Request{Method: "GET", URL: guildCommandsEndpoint(applicationID, guildID) + "/permissions"})
+	if res.IsErr() {
+		return result.Err[[]GuildApplicationCommandPermissions](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var permissions []GuildApplicationCommandPermissions
+	if err := json.NewDecoder(body).Decode(&permissions); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/applications/{id}/guilds/{id}/commands/permissions",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]GuildApplicationCommandPermissions](err)
+	}
+	return result.Ok(permissions)
+}
+
+// FetchCommandPermissions returns the permissions of a command of the application in a guild.
+//
+// commandID can be a global command or a command of the guild.
+//
+// Reference: https://discord.com/developers/docs/interactions/application-commands#get-application-command-permissions
+func (r *requester) FetchCommandPermissions(applicationID, guildID, commandID Snowflake) result.Result[GuildApplicationCommandPermissions] {
+	res := r.DoRequest(Request{
+		Method: "GET",
+		URL:    guildCommandsEndpoint(applicationID, guildID) + "/" + commandID.String() + "/permissions",
+	})
+	if res.IsErr() {
+		return result.Err[GuildApplicationCommandPermissions](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var permissions GuildApplicationCommandPermissions
+	if err := json.NewDecoder(body).Decode(&permissions); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/applications/{id}/guilds/{id}/commands/{id}/permissions",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[GuildApplicationCommandPermissions](err)
+	}
+	return result.Ok(permissions)
+}
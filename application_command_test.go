@@ -0,0 +1,272 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+func TestMarshalUserInstallableCommand(t *testing.T) {
+	cmd := NewChatInputCommandOptions("ping", "Replies with pong")
+	cmd.SetUserInstallable()
+
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var got struct {
+		IntegrationTypes []int `json:"integration_types"`
+		Contexts         []int `json:"contexts"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(got.IntegrationTypes) != 2 || got.IntegrationTypes[0] != 0 || got.IntegrationTypes[1] != 1 {
+		t.Errorf("integration_types = %v, want [0 1]", got.IntegrationTypes)
+	}
+	if len(got.Contexts) != 3 || got.Contexts[0] != 0 || got.Contexts[1] != 1 || got.Contexts[2] != 2 {
+		t.Errorf("contexts = %v, want [0 1 2]", got.Contexts)
+	}
+}
+
+func TestMarshalCommandExplicitEmptyContexts(t *testing.T) {
+	cmd := NewChatInputCommandOptions("ping", "Replies with pong")
+	data, _ := json.Marshal(cmd)
+	if strings.Contains(string(data), "contexts") || strings.Contains(string(data), "integration_types") {
+		t.Errorf("unset lists should be omitted, got %s", data)
+	}
+
+	cmd.SetContexts()
+	data, _ = json.Marshal(cmd)
+	if !strings.Contains(string(data), `"contexts":[]`) {
+		t.Errorf("an explicit empty contexts list should be sent, got %s", data)
+	}
+}
+
+func TestValidateIntegrationTypes(t *testing.T) {
+	var cmd ApplicationCommandOptions
+	if err := cmd.ValidateIntegrationTypes(); err != nil {
+		t.Errorf("unset integration types should be valid, got %v", err)
+	}
+
+	cmd.SetIntegrationTypes()
+	if err := cmd.ValidateIntegrationTypes(); err == nil {
+		t.Error("empty integration types should be rejected")
+	}
+
+	cmd.SetIntegrationTypes(ApplicationIntegrationType(5))
+	if err := cmd.ValidateIntegrationTypes(); err == nil {
+		t.Error("unknown integration type should be rejected")
+	}
+
+	cmd.SetIntegrationTypes(ApplicationIntegrationTypeUserInstall).SetContexts(InteractionContextType(9))
+	if err := cmd.ValidateIntegrationTypes(); err == nil {
+		t.Error("unknown context should be rejected")
+	}
+}
+
+func TestRegisterCommandsValidatesIntegrationTypes(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	})
+
+	invalid := NewChatInputCommandOptions("ping", "Replies with pong")
+	invalid.SetIntegrationTypes()
+
+	if res := r.CreateGlobalCommand(1, invalid); !res.IsErr() {
+		t.Error("CreateGlobalCommand should reject a command without integration types")
+	}
+	valid := NewChatInputCommandOptions("help", "Shows the help")
+	if res := r.RegisterGuildCommands(1, 2, []ApplicationCommandOptions{valid, invalid}); !res.IsErr() {
+		t.Error("RegisterGuildCommands should reject a command without integration types")
+	}
+}
+
+func TestSetContextsCopiesSlice(t *testing.T) {
+	contexts := []InteractionContextType{InteractionContextTypeGuild, InteractionContextTypeBotDM}
+
+	var cmd ApplicationCommandOptions
+	cmd.SetContexts(contexts...)
+	contexts[0] = InteractionContextTypePrivateChannel
+
+	if cmd.Contexts[0] != InteractionContextTypeGuild {
+		t.Errorf("contexts = %v, want the caller's slice to be copied", cmd.Contexts)
+	}
+}
+
+func TestRegisterGlobalCommandsBody(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/applications/1/commands" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body []map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body) != 2 {
+			t.Fatalf("body = %v, want 2 commands", body)
+		}
+		if body[0]["name"] != "config" || body[0]["type"] != float64(ApplicationCommandTypeChatInput) || body[0]["default_member_permissions"] != "0" {
+			t.Errorf("command = %v", body[0])
+		}
+		subCommand := body[0]["options"].([]any)[0].(map[string]any)
+		if subCommand["type"] != float64(ApplicationCommandOptionTypeSubCommand) || subCommand["name"] != "language" {
+			t.Errorf("sub-command = %v", subCommand)
+		}
+		option := subCommand["options"].([]any)[0].(map[string]any)
+		choices := option["choices"].([]any)
+		if option["required"] != true || len(choices) != 2 || choices[1].(map[string]any)["value"] != "fr" {
+			t.Errorf("option = %v", option)
+		}
+		if _, ok := body[1]["description"]; ok || body[1]["type"] != float64(ApplicationCommandTypeUser) {
+			t.Errorf("user command = %v", body[1])
+		}
+		io.WriteString(w, `[
+			{"id": "10", "application_id": "1", "type": 1, "name": "config", "description": "Configure the bot", "version": "1"},
+			{"id": "11", "application_id": "1", "type": 2, "name": "Profile", "version": "1"}
+		]`)
+	})
+
+	config := NewChatInputCommandOptions("config", "Configure the bot",
+		NewSubCommand("language", "Set the language",
+			NewStringOption("value", "The language", true).
+				AddChoice("English", "en").
+				AddChoice("French", "fr"),
+		),
+	)
+	config.DefaultMemberPermissions = optional.Some[Permissions](0)
+
+	res := r.RegisterGlobalCommands(1, []ApplicationCommandOptions{
+		config,
+		{Type: ApplicationCommandTypeUser, Name: "Profile"},
+	})
+	if res.IsErr() {
+		t.Fatalf("RegisterGlobalCommands: %v", res.Err())
+	}
+	commands := res.Value()
+	if len(commands) != 2 {
+		t.Fatalf("got %d commands, want 2", len(commands))
+	}
+	if _, ok := commands[0].(*ChatInputCommand); !ok || commands[0].GetID() != 10 {
+		t.Errorf("commands[0] = %#v", commands[0])
+	}
+	if _, ok := commands[1].(*ApplicationUserCommand); !ok {
+		t.Errorf("commands[1] = %#v", commands[1])
+	}
+}
+
+func TestRegisterGlobalCommandsEmpty(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != "[]" {
+			t.Errorf("body = %s, want []", body)
+		}
+		io.WriteString(w, `[]`)
+	})
+
+	if res := r.RegisterGlobalCommands(1, nil); res.IsErr() {
+		t.Fatalf("RegisterGlobalCommands: %v", res.Err())
+	}
+}
+
+func TestUnmarshalCommandNestedOptions(t *testing.T) {
+	payload := `{
+		"id": "10", "application_id": "1", "type": 1, "name": "settings", "description": "Settings",
+		"options": [{
+			"type": 2, "name": "notifications", "description": "Notification settings",
+			"options": [{
+				"type": 1, "name": "channel", "description": "Set the channel",
+				"options": [
+					{"type": 7, "name": "target", "description": "The channel", "required": true, "channel_types": [0]},
+					{"type": 4, "name": "delay", "description": "Delay", "choices": [{"name": "Short", "value": 5}]}
+				]
+			}]
+		}]
+	}`
+
+	command, err := UnmarshalApplicationCommand([]byte(payload))
+	if err != nil {
+		t.Fatalf("UnmarshalApplicationCommand: %v", err)
+	}
+	chatInput, ok := command.(*ChatInputCommand)
+	if !ok || len(chatInput.Options) != 1 {
+		t.Fatalf("command = %#v", command)
+	}
+	group, ok := chatInput.Options[0].(*ApplicationCommandOptionSubCommandGroup)
+	if !ok || len(group.Options) != 1 || group.Options[0].Name != "channel" {
+		t.Fatalf("group = %#v", chatInput.Options[0])
+	}
+	options := group.Options[0].Options
+	if len(options) != 2 {
+		t.Fatalf("sub-command options = %#v", options)
+	}
+	if channel, ok := options[0].(*ApplicationCommandOptionChannel); !ok || !channel.Required || len(channel.ChannelTypes) != 1 {
+		t.Errorf("options[0] = %#v", options[0])
+	}
+	if delay, ok := options[1].(*ApplicationCommandOptionInteger); !ok || len(delay.Choices) != 1 || delay.Choices[0].Value != 5 {
+		t.Errorf("options[1] = %#v", options[1])
+	}
+}
+
+func TestRegisterGuildCommands(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/applications/1/guilds/5/commands" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body []map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body) != 1 || body[0]["name"] != "ping" || body[0]["description"] != "Replies with pong" {
+			t.Errorf("body = %v", body)
+		}
+		io.WriteString(w, `[{"id": "10", "application_id": "1", "guild_id": "5", "type": 1, "name": "ping", "description": "Replies with pong", "version": "1"}]`)
+	})
+
+	res := r.RegisterGuildCommands(1, 5, []ApplicationCommandOptions{NewChatInputCommandOptions("ping", "Replies with pong")})
+	if res.IsErr() {
+		t.Fatalf("RegisterGuildCommands: %v", res.Err())
+	}
+	if commands := res.Value(); len(commands) != 1 || commands[0].GetGuildID() != 5 {
+		t.Errorf("commands = %#v", commands)
+	}
+}
+
+func TestFetchCommandPermissions(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet || req.URL.Path != "/applications/1/guilds/5/commands/10/permissions" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		io.WriteString(w, `{"id": "10", "application_id": "1", "guild_id": "5", "permissions": [{"id": "5", "type": 1, "permission": false}]}`)
+	})
+
+	res := r.FetchCommandPermissions(1, 5, 10)
+	if res.IsErr() {
+		t.Fatalf("FetchCommandPermissions: %v", res.Err())
+	}
+	permissions := res.Value()
+	if permissions.AppliesToAllCommands() || len(permissions.Permissions) != 1 {
+		t.Fatalf("permissions = %+v", permissions)
+	}
+	if p := permissions.Permissions[0]; p.ID != 5 || p.Type != ApplicationCommandPermissionTypeRole || p.Permission {
+		t.Errorf("permission = %+v", p)
+	}
+}
@@ -0,0 +1,718 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
+
+// AuditLogChangeKey is the name of the property changed in an audit log entry.
+//
+// Reference: https://discord.com/developers/docs/resources/audit-log#audit-log-change-object-audit-log-change-key
+type AuditLogChangeKey string
+
+const (
+	AuditLogChangeKeyAfkChannelID                AuditLogChangeKey = "afk_channel_id"
+	AuditLogChangeKeyAfkTimeout                  AuditLogChangeKey = "afk_timeout"
+	AuditLogChangeKeyAllow                       AuditLogChangeKey = "allow"
+	AuditLogChangeKeyApplicationID               AuditLogChangeKey = "application_id"
+	AuditLogChangeKeyArchived                    AuditLogChangeKey = "archived"
+	AuditLogChangeKeyAsset                       AuditLogChangeKey = "asset"
+	AuditLogChangeKeyAutoArchiveDuration         AuditLogChangeKey = "auto_archive_duration"
+	AuditLogChangeKeyAvailable                   AuditLogChangeKey = "available"
+	AuditLogChangeKeyAvatarHash                  AuditLogChangeKey = "avatar_hash"
+	AuditLogChangeKeyBannerHash                  AuditLogChangeKey = "banner_hash"
+	AuditLogChangeKeyBitrate                     AuditLogChangeKey = "bitrate"
+	AuditLogChangeKeyChannelID                   AuditLogChangeKey = "channel_id"
+	AuditLogChangeKeyCode                        AuditLogChangeKey = "code"
+	AuditLogChangeKeyColor                       AuditLogChangeKey = "color"
+	AuditLogChangeKeyCommunicationDisabledUntil  AuditLogChangeKey = "communication_disabled_until"
+	AuditLogChangeKeyDeaf                        AuditLogChangeKey = "deaf"
+	AuditLogChangeKeyDefaultAutoArchiveDuration  AuditLogChangeKey = "default_auto_archive_duration"
+	AuditLogChangeKeyDefaultMessageNotifications AuditLogChangeKey = "default_message_notifications"
+	AuditLogChangeKeyDeny                        AuditLogChangeKey = "deny"
+	AuditLogChangeKeyDescription                 AuditLogChangeKey = "description"
+	AuditLogChangeKeyDiscoverySplashHash         AuditLogChangeKey = "discovery_splash_hash"
+	AuditLogChangeKeyEnableEmoticons             AuditLogChangeKey = "enable_emoticons"
+	AuditLogChangeKeyEntityType                  AuditLogChangeKey = "entity_type"
+	AuditLogChangeKeyExpireBehavior              AuditLogChangeKey = "expire_behavior"
+	AuditLogChangeKeyExpireGracePeriod           AuditLogChangeKey = "expire_grace_period"
+	AuditLogChangeKeyExplicitContentFilter       AuditLogChangeKey = "explicit_content_filter"
+	AuditLogChangeKeyFormatType                  AuditLogChangeKey = "format_type"
+	AuditLogChangeKeyGuildID                     AuditLogChangeKey = "guild_id"
+	AuditLogChangeKeyHoist                       AuditLogChangeKey = "hoist"
+	AuditLogChangeKeyIconHash                    AuditLogChangeKey = "icon_hash"
+	AuditLogChangeKeyID                          AuditLogChangeKey = "id"
+	AuditLogChangeKeyImageHash                   AuditLogChangeKey = "image_hash"
+	AuditLogChangeKeyInvitable                   AuditLogChangeKey = "invitable"
+	AuditLogChangeKeyInviterID                   AuditLogChangeKey = "inviter_id"
+	AuditLogChangeKeyLocation                    AuditLogChangeKey = "location"
+	AuditLogChangeKeyLocked                      AuditLogChangeKey = "locked"
+	AuditLogChangeKeyMaxAge                      AuditLogChangeKey = "max_age"
+	AuditLogChangeKeyMaxUses                     AuditLogChangeKey = "max_uses"
+	AuditLogChangeKeyMentionable                 AuditLogChangeKey = "mentionable"
+	AuditLogChangeKeyMFALevel                    AuditLogChangeKey = "mfa_level"
+	AuditLogChangeKeyMute                        AuditLogChangeKey = "mute"
+	AuditLogChangeKeyName                        AuditLogChangeKey = "name"
+	AuditLogChangeKeyNick                        AuditLogChangeKey = "nick"
+	AuditLogChangeKeyNSFW                        AuditLogChangeKey = "nsfw"
+	AuditLogChangeKeyOwnerID                     AuditLogChangeKey = "owner_id"
+	AuditLogChangeKeyPermissionOverwrites        AuditLogChangeKey = "permission_overwrites"
+	AuditLogChangeKeyPermissions                 AuditLogChangeKey = "permissions"
+	AuditLogChangeKeyPosition                    AuditLogChangeKey = "position"
+	AuditLogChangeKeyPreferredLocale             AuditLogChangeKey = "preferred_locale"
+	AuditLogChangeKeyPrivacyLevel                AuditLogChangeKey = "privacy_level"
+	AuditLogChangeKeyPruneDeleteDays             AuditLogChangeKey = "prune_delete_days"
+	AuditLogChangeKeyPublicUpdatesChannelID      AuditLogChangeKey = "public_updates_channel_id"
+	AuditLogChangeKeyRateLimitPerUser            AuditLogChangeKey = "rate_limit_per_user"
+	AuditLogChangeKeyRegion                      AuditLogChangeKey = "region"
+	AuditLogChangeKeyRulesChannelID              AuditLogChangeKey = "rules_channel_id"
+	AuditLogChangeKeySplashHash                  AuditLogChangeKey = "splash_hash"
+	AuditLogChangeKeyStatus                      AuditLogChangeKey = "status"
+	AuditLogChangeKeySystemChannelID             AuditLogChangeKey = "system_channel_id"
+	AuditLogChangeKeyTags                        AuditLogChangeKey = "tags"
+	AuditLogChangeKeyTemporary                   AuditLogChangeKey = "temporary"
+	AuditLogChangeKeyTopic                       AuditLogChangeKey = "topic"
+	AuditLogChangeKeyType                        AuditLogChangeKey = "type"
+	AuditLogChangeKeyUnicodeEmoji                AuditLogChangeKey = "unicode_emoji"
+	AuditLogChangeKeyUserLimit                   AuditLogChangeKey = "user_limit"
+	AuditLogChangeKeyUses                        AuditLogChangeKey = "uses"
+	AuditLogChangeKeyVanityURLCode               AuditLogChangeKey = "vanity_url_code"
+	AuditLogChangeKeyVerificationLevel           AuditLogChangeKey = "verification_level"
+	AuditLogChangeKeyWidgetChannelID             AuditLogChangeKey = "widget_channel_id"
+	AuditLogChangeKeyWidgetEnabled               AuditLogChangeKey = "widget_enabled"
+
+	// AuditLogChangeKeyAddRoles is the special key used when roles are added to a member.
+	// The values are partial role objects (id and name).
+	AuditLogChangeKeyAddRoles AuditLogChangeKey = "$add"
+
+	// AuditLogChangeKeyRemoveRoles is the special key used when roles are removed from a member.
+	// The values are partial role objects (id and name).
+	AuditLogChangeKeyRemoveRoles AuditLogChangeKey = "$remove"
+)
+
+// AuditLogChangeValue is the raw JSON value of an audit log change.
+//
+// Its type depends on the change key, use the As* helpers to decode it.
+type AuditLogChangeValue json.RawMessage
+
+var (
+	_ json.Marshaler   = (*AuditLogChangeValue)(nil)
+	_ json.Unmarshaler = (*AuditLogChangeValue)(nil)
+)
+
+func (v *AuditLogChangeValue) UnmarshalJSON(buf []byte) error {
+	*v = append((*v)[:0], buf...)
+	return nil
+}
+
+func (v AuditLogChangeValue) MarshalJSON() ([]byte, error) {
+	if len(v) == 0 {
+		return []byte("null"), nil
+	}
+	return v, nil
+}
+
+// IsPresent returns true if the value was sent by Discord and is not null.
+func (v AuditLogChangeValue) IsPresent() bool {
+	return len(v) != 0 && string(v) != "null"
+}
+
+// Decode unmarshals the raw value into dst.
+func (v AuditLogChangeValue) Decode(dst any) error {
+	return json.Unmarshal(v, dst)
+}
+
+// AsSnowflake decodes the value as a Snowflake.
+//
+// Returns None if the value is missing or is not a snowflake.
+func (v AuditLogChangeValue) AsSnowflake() optional.Option[Snowflake] {
+	var id Snowflake
+	if !v.IsPresent() || v.Decode(&id) != nil {
+		return optional.None[Snowflake]()
+	}
+	return optional.Some(id)
+}
+
+// AsString decodes the value as a string.
+//
+// Returns None if the value is missing or is not a string.
+func (v AuditLogChangeValue) AsString() optional.Option[string] {
+	var s string
+	if !v.IsPresent() || v.Decode(&s) != nil {
+		return optional.None[string]()
+	}
+	return optional.Some(s)
+}
+
+// AsPermissions decodes the value as a Permissions bitfield.
+//
+// Returns None if the value is missing or is not a permissions string.
+func (v AuditLogChangeValue) AsPermissions() optional.Option[Permissions] {
+	var p Permissions
+	if !v.IsPresent() || v.Decode(&p) != nil {
+		return optional.None[Permissions]()
+	}
+	return optional.Some(p)
+}
+
+// AsInt decodes the value as an integer.
+//
+// Numeric strings are accepted as well since Discord sends some integer values quoted.
+// Returns None if the value is missing or is not an integer.
+func (v AuditLogChangeValue) AsInt() optional.Option[int] {
+	if !v.IsPresent() {
+		return optional.None[int]()
+	}
+	var n int
+	if v.Decode(&n) == nil {
+		return optional.Some(n)
+	}
+	var s string
+	if v.Decode(&s) == nil {
+		if n, err := strconv.Atoi(s); err == nil {
+			return optional.Some(n)
+		}
+	}
+	return optional.None[int]()
+}
+
+// AsBool decodes the value as a boolean.
+//
+// Returns None if the value is missing or is not a boolean.
+func (v AuditLogChangeValue) AsBool() optional.Option[bool] {
+	var b bool
+	if !v.IsPresent() || v.Decode(&b) != nil {
+		return optional.None[bool]()
+	}
+	return optional.Some(b)
+}
+
+// AuditLogChange represents a single property change of an audit log entry.
+//
+// Reference: https://discord.com/developers/docs/resources/audit-log#audit-log-change-object
+type AuditLogChange struct {
+	// Key is the name of the changed entity property.
+	Key AuditLogChangeKey `json:"key"`
+
+	// NewValue is the new value of the key.
+	//
+	// Optional:
+	//   - Will be absent if the property was removed.
+	NewValue AuditLogChangeValue `json:"new_value,omitempty"`
+
+	// OldValue is the old value of the key.
+	//
+	// Optional:
+	//   - Will be absent if the property was added.
+	OldValue AuditLogChangeValue `json:"old_value,omitempty"`
+}
+
+// AsSnowflake returns the old and new values decoded as Snowflakes.
+//
+// Example usage:
+//
+//	if change.Key == AuditLogChangeKeyOwnerID {
+//		oldOwner, newOwner := change.AsSnowflake()
+//	}
+func (c *AuditLogChange) AsSnowflake() (oldValue, newValue optional.Option[Snowflake]) {
+	return c.OldValue.AsSnowflake(), c.NewValue.AsSnowflake()
+}
+
+// AsString returns the old and new values decoded as strings.
+func (c *AuditLogChange) AsString() (oldValue, newValue optional.Option[string]) {
+	return c.OldValue.AsString(), c.NewValue.AsString()
+}
+
+// AsPermissions returns the old and new values decoded as Permissions.
+func (c *AuditLogChange) AsPermissions() (oldValue, newValue optional.Option[Permissions]) {
+	return c.OldValue.AsPermissions(), c.NewValue.AsPermissions()
+}
+
+// AsInt returns the old and new values decoded as integers.
+func (c *AuditLogChange) AsInt() (oldValue, newValue optional.Option[int]) {
+	return c.OldValue.AsInt(), c.NewValue.AsInt()
+}
+
+// AsBool returns the old and new values decoded as booleans.
+func (c *AuditLogChange) AsBool() (oldValue, newValue optional.Option[bool]) {
+	return c.OldValue.AsBool(), c.NewValue.AsBool()
+}
+
+// AuditLogActionType is the type of action that occurred in an audit log entry.
+//
+// Reference: https://discord.com/developers/docs/resources/audit-log#audit-log-entry-object-audit-log-events
+type AuditLogActionType int
+
+const (
+	AuditLogActionTypeGuildUpdate AuditLogActionType = 1
+
+	AuditLogActionTypeChannelCreate          AuditLogActionType = 10
+	AuditLogActionTypeChannelUpdate          AuditLogActionType = 11
+	AuditLogActionTypeChannelDelete          AuditLogActionType = 12
+	AuditLogActionTypeChannelOverwriteCreate AuditLogActionType = 13
+	AuditLogActionTypeChannelOverwriteUpdate AuditLogActionType = 14
+	AuditLogActionTypeChannelOverwriteDelete AuditLogActionType = 15
+
+	AuditLogActionTypeMemberKick       AuditLogActionType = 20
+	AuditLogActionTypeMemberPrune      AuditLogActionType = 21
+	AuditLogActionTypeMemberBanAdd     AuditLogActionType = 22
+	AuditLogActionTypeMemberBanRemove  AuditLogActionType = 23
+	AuditLogActionTypeMemberUpdate     AuditLogActionType = 24
+	AuditLogActionTypeMemberRoleUpdate AuditLogActionType = 25
+	AuditLogActionTypeMemberMove       AuditLogActionType = 26
+	AuditLogActionTypeMemberDisconnect AuditLogActionType = 27
+	AuditLogActionTypeBotAdd           AuditLogActionType = 28
+
+	AuditLogActionTypeRoleCreate AuditLogActionType = 30
+	AuditLogActionTypeRoleUpdate AuditLogActionType = 31
+	AuditLogActionTypeRoleDelete AuditLogActionType = 32
+
+	AuditLogActionTypeInviteCreate AuditLogActionType = 40
+	AuditLogActionTypeInviteUpdate AuditLogActionType = 41
+	AuditLogActionTypeInviteDelete AuditLogActionType = 42
+
+	AuditLogActionTypeWebhookCreate AuditLogActionType = 50
+	AuditLogActionTypeWebhookUpdate AuditLogActionType = 51
+	AuditLogActionTypeWebhookDelete AuditLogActionType = 52
+
+	AuditLogActionTypeEmojiCreate AuditLogActionType = 60
+	AuditLogActionTypeEmojiUpdate AuditLogActionType = 61
+	AuditLogActionTypeEmojiDelete AuditLogActionType = 62
+
+	AuditLogActionTypeMessageDelete     AuditLogActionType = 72
+	AuditLogActionTypeMessageBulkDelete AuditLogActionType = 73
+	AuditLogActionTypeMessagePin        AuditLogActionType = 74
+	AuditLogActionTypeMessageUnpin      AuditLogActionType = 75
+
+	AuditLogActionTypeIntegrationCreate   AuditLogActionType = 80
+	AuditLogActionTypeIntegrationUpdate   AuditLogActionType = 81
+	AuditLogActionTypeIntegrationDelete   AuditLogActionType = 82
+	AuditLogActionTypeStageInstanceCreate AuditLogActionType = 83
+	AuditLogActionTypeStageInstanceUpdate AuditLogActionType = 84
+	AuditLogActionTypeStageInstanceDelete AuditLogActionType = 85
+
+	AuditLogActionTypeStickerCreate AuditLogActionType = 90
+	AuditLogActionTypeStickerUpdate AuditLogActionType = 91
+	AuditLogActionTypeStickerDelete AuditLogActionType = 92
+
+	AuditLogActionTypeGuildScheduledEventCreate AuditLogActionType = 100
+	AuditLogActionTypeGuildScheduledEventUpdate AuditLogActionType = 101
+	AuditLogActionTypeGuildScheduledEventDelete AuditLogActionType = 102
+
+	AuditLogActionTypeThreadCreate AuditLogActionType = 110
+	AuditLogActionTypeThreadUpdate AuditLogActionType = 111
+	AuditLogActionTypeThreadDelete AuditLogActionType = 112
+
+	AuditLogActionTypeApplicationCommandPermissionUpdate AuditLogActionType = 121
+
+	AuditLogActionTypeSoundboardSoundCreate AuditLogActionType = 130
+	AuditLogActionTypeSoundboardSoundUpdate AuditLogActionType = 131
+	AuditLogActionTypeSoundboardSoundDelete AuditLogActionType = 132
+
+	AuditLogActionTypeAutoModerationRuleCreate                AuditLogActionType = 140
+	AuditLogActionTypeAutoModerationRuleUpdate                AuditLogActionType = 141
+	AuditLogActionTypeAutoModerationRuleDelete                AuditLogActionType = 142
+	AuditLogActionTypeAutoModerationBlockMessage              AuditLogActionType = 143
+	AuditLogActionTypeAutoModerationFlagToChannel             AuditLogActionType = 144
+	AuditLogActionTypeAutoModerationUserCommunicationDisabled AuditLogActionType = 145
+
+	AuditLogActionTypeCreatorMonetizationRequestCreated AuditLogActionType = 150
+	AuditLogActionTypeCreatorMonetizationTermsAccepted  AuditLogActionType = 151
+
+	AuditLogActionTypeOnboardingPromptCreate AuditLogActionType = 163
+	AuditLogActionTypeOnboardingPromptUpdate AuditLogActionType = 164
+	AuditLogActionTypeOnboardingPromptDelete AuditLogActionType = 165
+	AuditLogActionTypeOnboardingCreate       AuditLogActionType = 166
+	AuditLogActionTypeOnboardingUpdate       AuditLogActionType = 167
+
+	AuditLogActionTypeHomeSettingsCreate AuditLogActionType = 190
+	AuditLogActionTypeHomeSettingsUpdate AuditLogActionType = 191
+)
+
+// Is returns true if the action type matches the provided one.
+func (t AuditLogActionType) Is(actionType AuditLogActionType) bool {
+	return t == actionType
+}
+
+// IsBan returns true if the action is a member ban.
+func (t AuditLogActionType) IsBan() bool {
+	return t == AuditLogActionTypeMemberBanAdd
+}
+
+// IsUnban returns true if the action is a member unban.
+func (t AuditLogActionType) IsUnban() bool {
+	return t == AuditLogActionTypeMemberBanRemove
+}
+
+// IsKick returns true if the action is a member kick.
+func (t AuditLogActionType) IsKick() bool {
+	return t == AuditLogActionTypeMemberKick
+}
+
+// IsMemberUpdate returns true if the action updated a member (nickname, timeout, ...).
+func (t AuditLogActionType) IsMemberUpdate() bool {
+	return t == AuditLogActionTypeMemberUpdate
+}
+
+// IsMemberRoleUpdate returns true if the action added or removed roles of a member.
+func (t AuditLogActionType) IsMemberRoleUpdate() bool {
+	return t == AuditLogActionTypeMemberRoleUpdate
+}
+
+// IsChannelCreate returns true if the action created a channel.
+func (t AuditLogActionType) IsChannelCreate() bool {
+	return t == AuditLogActionTypeChannelCreate
+}
+
+// IsChannelUpdate returns true if the action updated a channel.
+func (t AuditLogActionType) IsChannelUpdate() bool {
+	return t == AuditLogActionTypeChannelUpdate
+}
+
+// IsChannelDelete returns true if the action deleted a channel.
+func (t AuditLogActionType) IsChannelDelete() bool {
+	return t == AuditLogActionTypeChannelDelete
+}
+
+// IsRoleCreate returns true if the action created a role.
+func (t AuditLogActionType) IsRoleCreate() bool {
+	return t == AuditLogActionTypeRoleCreate
+}
+
+// IsRoleUpdate returns true if the action updated a role.
+func (t AuditLogActionType) IsRoleUpdate() bool {
+	return t == AuditLogActionTypeRoleUpdate
+}
+
+// IsRoleDelete returns true if the action deleted a role.
+func (t AuditLogActionType) IsRoleDelete() bool {
+	return t == AuditLogActionTypeRoleDelete
+}
+
+// IsMessageDelete returns true if the action deleted one or many messages.
+func (t AuditLogActionType) IsMessageDelete() bool {
+	return t == AuditLogActionTypeMessageDelete || t == AuditLogActionTypeMessageBulkDelete
+}
+
+// AuditLogEntryOptions contains additional info for certain action types.
+//
+// Numeric fields that Discord sends as strings are decoded into integers.
+//
+// Reference: https://discord.com/developers/docs/resources/audit-log#audit-log-entry-object-optional-audit-entry-info
+type AuditLogEntryOptions struct {
+	// ApplicationID is the ID of the app whose permissions were targeted.
+	//
+	// Set for: ApplicationCommandPermissionUpdate.
+	ApplicationID Snowflake
+
+	// AutoModerationRuleName is the name of the Auto Moderation rule that was triggered.
+	//
+	// Set for: AutoModerationBlockMessage, AutoModerationFlagToChannel, AutoModerationUserCommunicationDisabled.
+	AutoModerationRuleName string
+
+	// AutoModerationRuleTriggerType is the trigger type of the Auto Moderation rule that was triggered.
+	//
+	// Set for: AutoModerationBlockMessage, AutoModerationFlagToChannel, AutoModerationUserCommunicationDisabled.
+	AutoModerationRuleTriggerType int
+
+	// ChannelID is the channel in which the entities were targeted.
+	//
+	// Set for: MemberMove, MessagePin, MessageUnpin, MessageDelete, StageInstance* and AutoModeration* actions.
+	ChannelID Snowflake
+
+	// Count is the number of entities that were targeted.
+	//
+	// Set for: MessageDelete, MessageBulkDelete, MemberDisconnect, MemberMove.
+	Count int
+
+	// DeleteMemberDays is the number of days after which inactive members were kicked.
+	//
+	// Set for: MemberPrune.
+	DeleteMemberDays int
+
+	// ID is the ID of the overwritten entity.
+	//
+	// Set for: ChannelOverwriteCreate, ChannelOverwriteUpdate, ChannelOverwriteDelete.
+	ID Snowflake
+
+	// MembersRemoved is the number of members removed by the prune.
+	//
+	// Set for: MemberPrune.
+	MembersRemoved int
+
+	// MessageID is the ID of the message that was targeted.
+	//
+	// Set for: MessagePin, MessageUnpin.
+	MessageID Snowflake
+
+	// RoleName is the name of the role if the overwritten entity is a role.
+	//
+	// Set for: ChannelOverwriteCreate, ChannelOverwriteUpdate, ChannelOverwriteDelete.
+	RoleName string
+
+	// OverwriteType is the type of the overwritten entity.
+	//
+	// Set for: ChannelOverwriteCreate, ChannelOverwriteUpdate, ChannelOverwriteDelete.
+	OverwriteType PermissionOverwriteType
+
+	// IntegrationType is the type of integration which performed the action.
+	//
+	// Set for: MemberKick, MemberRoleUpdate.
+	IntegrationType string
+}
+
+var _ json.Unmarshaler = (*AuditLogEntryOptions)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler for AuditLogEntryOptions.
+func (o *AuditLogEntryOptions) UnmarshalJSON(buf []byte) error {
+	var raw struct {
+		ApplicationID                 Snowflake `json:"application_id"`
+		AutoModerationRuleName        string    `json:"auto_moderation_rule_name"`
+		AutoModerationRuleTriggerType string    `json:"auto_moderation_rule_trigger_type"`
+		ChannelID                     Snowflake `json:"channel_id"`
+		Count                         string    `json:"count"`
+		DeleteMemberDays              string    `json:"delete_member_days"`
+		ID                            Snowflake `json:"id"`
+		MembersRemoved                string    `json:"members_removed"`
+		MessageID                     Snowflake `json:"message_id"`
+		RoleName                      string    `json:"role_name"`
+		Type                          string    `json:"type"`
+		IntegrationType               string    `json:"integration_type"`
+	}
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return err
+	}
+
+	o.ApplicationID = raw.ApplicationID
+	o.AutoModerationRuleName = raw.AutoModerationRuleName
+	o.AutoModerationRuleTriggerType = atoiOrZero(raw.AutoModerationRuleTriggerType)
+	o.ChannelID = raw.ChannelID
+	o.Count = atoiOrZero(raw.Count)
+	o.DeleteMemberDays = atoiOrZero(raw.DeleteMemberDays)
+	o.ID = raw.ID
+	o.MembersRemoved = atoiOrZero(raw.MembersRemoved)
+	o.MessageID = raw.MessageID
+	o.RoleName = raw.RoleName
+	o.OverwriteType = PermissionOverwriteType(atoiOrZero(raw.Type))
+	o.IntegrationType = raw.IntegrationType
+	return nil
+}
+
+// atoiOrZero converts a numeric string to int, returning 0 if it's empty or invalid.
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// AuditLogEntry represents a single administrative action.
+//
+// Reference: https://discord.com/developers/docs/resources/audit-log#audit-log-entry-object
+type AuditLogEntry struct {
+	// ID is the ID of the entry.
+	ID Snowflake `json:"id"`
+
+	// TargetID is the ID of the affected entity (webhook, user, role, etc.).
+	//
+	// Optional:
+	//   - Will be 0 if the action has no target.
+	TargetID Snowflake `json:"target_id"`
+
+	// Changes contains the changes made to the target.
+	Changes []AuditLogChange `json:"changes"`
+
+	// UserID is the ID of the user or app that made the changes.
+	//
+	// Optional:
+	//   - Will be 0 if unknown.
+	UserID Snowflake `json:"user_id"`
+
+	// ActionType is the type of action that occurred.
+	ActionType AuditLogActionType `json:"action_type"`
+
+	// Options contains additional info for certain action types.
+	//
+	// Optional:
+	//   - Will be nil if the action type has no additional info.
+	Options *AuditLogEntryOptions `json:"options,omitempty"`
+
+	// Reason is the reason for the change (1-512 characters).
+	//
+	// Optional:
+	//   - Will be empty string if no reason was provided.
+	Reason string `json:"reason"`
+}
+
+// Change returns the change for the provided key if present.
+//
+// Example usage:
+//
+//	if change, ok := entry.Change(AuditLogChangeKeyName); ok {
+//		oldName, newName := change.AsString()
+//	}
+func (e *AuditLogEntry) Change(key AuditLogChangeKey) (AuditLogChange, bool) {
+	for _, change := range e.Changes {
+		if change.Key == key {
+			return change, true
+		}
+	}
+	return AuditLogChange{}, false
+}
+
+// CreatedAt returns the time when this entry was created.
+func (e *AuditLogEntry) CreatedAt() time.Time {
+	return e.ID.Timestamp()
+}
+
+// AuditLog is the audit log of a guild, with the entities referenced by its entries.
+//
+// Reference: https://discord.com/developers/docs/resources/audit-log#audit-log-object
+type AuditLog struct {
+	// AuditLogEntries is the list of audit log entries, ordered from most to least recent.
+	AuditLogEntries []AuditLogEntry `json:"audit_log_entries"`
+
+	// Users is the list of users referenced in the audit log.
+	Users []User `json:"users"`
+
+	// Webhooks is the list of webhooks referenced in the audit log.
+	Webhooks []Webhook `json:"webhooks"`
+
+	// Integrations is the list of partial integration objects referenced in the audit log.
+	Integrations []Integration `json:"integrations"`
+
+	// Threads is the list of threads referenced in the audit log.
+	Threads []ThreadChannel `json:"threads"`
+
+	// AutoModerationRules is the list of auto moderation rules referenced in the audit log.
+	AutoModerationRules []AutoModerationRule `json:"auto_moderation_rules"`
+
+	// GuildScheduledEvents is the list of guild scheduled events referenced in the audit log.
+	GuildScheduledEvents []GuildScheduledEvent `json:"guild_scheduled_events"`
+
+	// ApplicationCommands is the list of application commands referenced in the audit log.
+	ApplicationCommands []ApplicationCommand `json:"-"`
+}
+
+var _ json.Unmarshaler = (*AuditLog)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler for AuditLog.
+func (l *AuditLog) UnmarshalJSON(buf []byte) error {
+	type NoMethod AuditLog
+	var temp struct {
+		*NoMethod
+		ApplicationCommands []json.RawMessage `json:"application_commands"`
+	}
+	temp.NoMethod = (*NoMethod)(l)
+	if err := json.Unmarshal(buf, &temp); err != nil {
+		return err
+	}
+
+	l.ApplicationCommands = make([]ApplicationCommand, 0, len(temp.ApplicationCommands))
+	for _, raw := range temp.ApplicationCommands {
+		command, err := UnmarshalApplicationCommand(raw)
+		if err != nil {
+			return err
+		}
+		l.ApplicationCommands = append(l.ApplicationCommands, command)
+	}
+	return nil
+}
+
+// User returns the user referenced in the audit log with the given ID.
+func (l *AuditLog) User(userID Snowflake) (User, bool) {
+	for _, user := range l.Users {
+		if user.ID == userID {
+			return user, true
+		}
+	}
+	return User{}, false
+}
+
+// FetchAuditLogOptions contains the filters for fetching a guild audit log.
+//
+// Reference: https://discord.com/developers/docs/resources/audit-log#get-guild-audit-log-query-string-params
+type FetchAuditLogOptions struct {
+	// UserID filters the entries to actions made by this user.
+	UserID Snowflake
+
+	// ActionType filters the entries to this type of action.
+	ActionType AuditLogActionType
+
+	// Before returns the entries before this entry ID.
+	Before Snowflake
+
+	// After returns the entries after this entry ID.
+	After Snowflake
+
+	// Limit is the maximum number of entries to return (1-100), 0 uses Discord's default of 50.
+	Limit int
+}
+
+// FetchGuildAuditLog returns the audit log of a guild.
+//
+// Requires the PermissionViewAuditLog permission.
+//
+// Reference: https://discord.com/developers/docs/resources/audit-log#get-guild-audit-log
+func (r *requester) FetchGuildAuditLog(guildID Snowflake, opts FetchAuditLogOptions) result.Result[AuditLog] {
+	if opts.Limit < 0 || opts.Limit > 100 {
+		return result.Err[AuditLog](fmt.Errorf("FetchGuildAuditLog: limit must be between 1 and 100, got %d", opts.Limit))
+	}
+
+	query := url.Values{}
+	if !opts.UserID.UnSet() {
+		query.Set("user_id", opts.UserID.String())
+	}
+	if opts.ActionType != 0 {
+		query.Set("action_type", strconv.Itoa(int(opts.ActionType)))
+	}
+	if !opts.Before.UnSet() {
+		query.Set("before", opts.Before.String())
+	}
+	if !opts.After.UnSet() {
+		query.Set("after", opts.After.String())
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	endpoint := "/guilds/" + guildID.String() + "/audit-logs"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	if res.IsErr() {
+		return result.Err[AuditLog](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var auditLog AuditLog
+	if err := json.NewDecoder(body).Decode(&auditLog); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/audit-logs",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[AuditLog](err)
+	}
+	return result.Ok(auditLog)
+}
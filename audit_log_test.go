@@ -0,0 +1,248 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestAuditLogChangeTypedValues(t *testing.T) {
+	data := `[
+		{"key": "owner_id", "old_value": "111111111111111111", "new_value": "222222222222222222"},
+		{"key": "name", "old_value": "general", "new_value": "chat"},
+		{"key": "permissions", "old_value": "8", "new_value": "2048"},
+		{"key": "rate_limit_per_user", "old_value": 0, "new_value": 30},
+		{"key": "nsfw", "new_value": true}
+	]`
+
+	var changes []AuditLogChange
+	if err := json.Unmarshal([]byte(data), &changes); err != nil {
+		t.Fatalf("failed to unmarshal changes: %v", err)
+	}
+	if len(changes) != 5 {
+		t.Fatalf("expected 5 changes, got %d", len(changes))
+	}
+
+	if changes[0].Key != AuditLogChangeKeyOwnerID {
+		t.Errorf("expected key %q, got %q", AuditLogChangeKeyOwnerID, changes[0].Key)
+	}
+	oldOwner, newOwner := changes[0].AsSnowflake()
+	if oldOwner.Get() != 111111111111111111 || newOwner.Get() != 222222222222222222 {
+		t.Errorf("unexpected owner ids: %v -> %v", oldOwner, newOwner)
+	}
+
+	oldName, newName := changes[1].AsString()
+	if oldName.Get() != "general" || newName.Get() != "chat" {
+		t.Errorf("unexpected names: %v -> %v", oldName, newName)
+	}
+
+	oldPerms, newPerms := changes[2].AsPermissions()
+	if oldPerms.Get() != PermissionAdministrator || newPerms.Get() != PermissionSendMessages {
+		t.Errorf("unexpected permissions: %v -> %v", oldPerms, newPerms)
+	}
+
+	oldRate, newRate := changes[3].AsInt()
+	if oldRate.Get() != 0 || newRate.Get() != 30 {
+		t.Errorf("unexpected rate limits: %v -> %v", oldRate, newRate)
+	}
+
+	oldNsfw, newNsfw := changes[4].AsBool()
+	if oldNsfw.IsPresent() {
+		t.Errorf("expected old nsfw value to be absent, got %v", oldNsfw)
+	}
+	if !newNsfw.Get() {
+		t.Errorf("expected new nsfw value to be true")
+	}
+}
+
+func TestAuditLogChangeWrongType(t *testing.T) {
+	change := AuditLogChange{
+		Key:      AuditLogChangeKeyName,
+		NewValue: AuditLogChangeValue(`"chat"`),
+	}
+
+	if _, id := change.AsSnowflake(); id.IsPresent() {
+		t.Errorf("expected non numeric string to not decode as snowflake, got %v", id)
+	}
+	if _, n := change.AsInt(); n.IsPresent() {
+		t.Errorf("expected non numeric string to not decode as int, got %v", n)
+	}
+}
+
+func TestGuildAuditLogEntryCreateEventBanAdd(t *testing.T) {
+	data := `{
+		"guild_id": "100000000000000000",
+		"id": "200000000000000000",
+		"target_id": "300000000000000000",
+		"user_id": "400000000000000000",
+		"action_type": 22,
+		"reason": "spamming",
+		"changes": []
+	}`
+
+	var evt GuildAuditLogEntryCreateEvent
+	if err := json.Unmarshal([]byte(data), &evt); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+
+	if evt.GuildID != 100000000000000000 {
+		t.Errorf("unexpected guild id %d", evt.GuildID)
+	}
+	if !evt.ActionType.IsBan() {
+		t.Errorf("expected action type to be a ban, got %d", evt.ActionType)
+	}
+	if evt.ActionType.IsKick() || evt.ActionType.IsUnban() {
+		t.Errorf("expected ban action to not match kick or unban")
+	}
+	if evt.TargetID != 300000000000000000 || evt.UserID != 400000000000000000 {
+		t.Errorf("unexpected target/user ids: %d/%d", evt.TargetID, evt.UserID)
+	}
+	if evt.Reason != "spamming" {
+		t.Errorf("expected reason 'spamming', got %q", evt.Reason)
+	}
+	if evt.Options != nil {
+		t.Errorf("expected no options for ban entry")
+	}
+}
+
+func TestAuditLogEntryChannelUpdate(t *testing.T) {
+	data := `{
+		"id": "200000000000000000",
+		"target_id": "500000000000000000",
+		"user_id": "400000000000000000",
+		"action_type": 11,
+		"changes": [
+			{"key": "name", "old_value": "general", "new_value": "lounge"},
+			{"key": "rate_limit_per_user", "old_value": 0, "new_value": 10}
+		]
+	}`
+
+	var entry AuditLogEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		t.Fatalf("failed to unmarshal entry: %v", err)
+	}
+
+	if !entry.ActionType.IsChannelUpdate() {
+		t.Errorf("expected action type to be a channel update, got %d", entry.ActionType)
+	}
+
+	change, ok := entry.Change(AuditLogChangeKeyName)
+	if !ok {
+		t.Fatalf("expected a name change")
+	}
+	oldName, newName := change.AsString()
+	if oldName.Get() != "general" || newName.Get() != "lounge" {
+		t.Errorf("unexpected names: %v -> %v", oldName, newName)
+	}
+
+	if _, ok := entry.Change(AuditLogChangeKeyTopic); ok {
+		t.Errorf("expected no topic change")
+	}
+}
+
+func TestAuditLogEntryOptions(t *testing.T) {
+	data := `{
+		"action_type": 21,
+		"options": {"delete_member_days": "7", "members_removed": "42"}
+	}`
+
+	var entry AuditLogEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		t.Fatalf("failed to unmarshal entry: %v", err)
+	}
+	if entry.Options == nil {
+		t.Fatalf("expected options to be set")
+	}
+	if entry.Options.DeleteMemberDays != 7 || entry.Options.MembersRemoved != 42 {
+		t.Errorf("unexpected prune options: %+v", *entry.Options)
+	}
+
+	data = `{
+		"action_type": 13,
+		"options": {"id": "600000000000000000", "type": "1"}
+	}`
+	entry = AuditLogEntry{}
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		t.Fatalf("failed to unmarshal entry: %v", err)
+	}
+	if entry.Options.ID != 600000000000000000 || entry.Options.OverwriteType != PermissionOverwriteTypeMember {
+		t.Errorf("unexpected overwrite options: %+v", *entry.Options)
+	}
+}
+
+func TestFetchGuildAuditLog(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/guilds/1/audit-logs" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		query := req.URL.Query()
+		if query.Get("user_id") != "5" || query.Get("action_type") != "24" || query.Get("limit") != "10" {
+			t.Errorf("unexpected query %q", req.URL.RawQuery)
+		}
+		if query.Has("before") || query.Has("after") {
+			t.Errorf("unset filters should be omitted, got %q", req.URL.RawQuery)
+		}
+		io.WriteString(w, `{
+			"audit_log_entries": [{
+				"id": "100", "target_id": "7", "user_id": "5", "action_type": 24,
+				"changes": [{"key": "nick", "old_value": "old", "new_value": "new"}]
+			}],
+			"users": [{"id": "5", "username": "mod"}, {"id": "7", "username": "member"}],
+			"webhooks": [], "integrations": [], "threads": [],
+			"auto_moderation_rules": [], "guild_scheduled_events": [],
+			"application_commands": [{"id": "30", "type": 1, "name": "ping", "description": "pong"}]
+		}`)
+	})
+
+	res := r.FetchGuildAuditLog(1, FetchAuditLogOptions{UserID: 5, ActionType: AuditLogActionTypeMemberUpdate, Limit: 10})
+	if res.IsErr() {
+		t.Fatalf("FetchGuildAuditLog: %v", res.Err())
+	}
+	auditLog := res.Value()
+	if len(auditLog.AuditLogEntries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(auditLog.AuditLogEntries))
+	}
+	entry := auditLog.AuditLogEntries[0]
+	if !entry.ActionType.IsMemberUpdate() || entry.TargetID != 7 {
+		t.Errorf("entry = %+v", entry)
+	}
+	change, ok := entry.Change(AuditLogChangeKeyNick)
+	if !ok {
+		t.Fatal("expected a nick change")
+	}
+	if oldNick, newNick := change.AsString(); oldNick.Get() != "old" || newNick.Get() != "new" {
+		t.Errorf("nick change = %v -> %v", oldNick, newNick)
+	}
+	if user, ok := auditLog.User(7); !ok || user.Username != "member" {
+		t.Errorf("User(7) = %+v, %v", user, ok)
+	}
+	if len(auditLog.ApplicationCommands) != 1 {
+		t.Fatalf("expected 1 application command, got %d", len(auditLog.ApplicationCommands))
+	}
+	if _, ok := auditLog.ApplicationCommands[0].(*ChatInputCommand); !ok {
+		t.Errorf("application command = %T, want *ChatInputCommand", auditLog.ApplicationCommands[0])
+	}
+}
+
+func TestFetchGuildAuditLogLimit(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Error("invalid limit should not send a request")
+	})
+	if res := r.FetchGuildAuditLog(1, FetchAuditLogOptions{Limit: 101}); res.IsOk() {
+		t.Error("expected an error for a limit above 100")
+	}
+}
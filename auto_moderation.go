@@ -0,0 +1,433 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
+
+// AutoModerationTriggerType is the type of content which can trigger an auto moderation rule.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-types
+type AutoModerationTriggerType int
+
+const (
+	// AutoModerationTriggerTypeKeyword checks if content contains words from a user defined list of keywords.
+	AutoModerationTriggerTypeKeyword AutoModerationTriggerType = 1
+
+	// AutoModerationTriggerTypeSpam checks if content represents generic spam.
+	AutoModerationTriggerTypeSpam AutoModerationTriggerType = 3
+
+	// AutoModerationTriggerTypeKeywordPreset checks if content contains words from internal pre-defined wordsets.
+	AutoModerationTriggerTypeKeywordPreset AutoModerationTriggerType = 4
+
+	// AutoModerationTriggerTypeMentionSpam checks if content contains more unique mentions than allowed.
+	AutoModerationTriggerTypeMentionSpam AutoModerationTriggerType = 5
+
+	// AutoModerationTriggerTypeMemberProfile checks if member profile contains words from a user defined list of keywords.
+	AutoModerationTriggerTypeMemberProfile AutoModerationTriggerType = 6
+)
+
+// Is returns true if the trigger type matches the provided one.
+func (t AutoModerationTriggerType) Is(triggerType AutoModerationTriggerType) bool {
+	return t == triggerType
+}
+
+// AutoModerationEventType indicates in what event context a rule should be checked.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-event-types
+type AutoModerationEventType int
+
+const (
+	// AutoModerationEventTypeMessageSend is when a member sends or edits a message in the guild.
+	AutoModerationEventTypeMessageSend AutoModerationEventType = 1
+
+	// AutoModerationEventTypeMemberUpdate is when a member edits their profile.
+	AutoModerationEventTypeMemberUpdate AutoModerationEventType = 2
+)
+
+// Is returns true if the event type matches the provided one.
+func (t AutoModerationEventType) Is(eventType AutoModerationEventType) bool {
+	return t == eventType
+}
+
+// AutoModerationKeywordPresetType is an internal pre-defined wordset of Discord.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-keyword-preset-types
+type AutoModerationKeywordPresetType int
+
+const (
+	// AutoModerationKeywordPresetTypeProfanity contains words that may be considered forms of swearing or cursing.
+	AutoModerationKeywordPresetTypeProfanity AutoModerationKeywordPresetType = 1
+
+	// AutoModerationKeywordPresetTypeSexualContent contains words that refer to sexually explicit behavior or activity.
+	AutoModerationKeywordPresetTypeSexualContent AutoModerationKeywordPresetType = 2
+
+	// AutoModerationKeywordPresetTypeSlurs contains personal insults or words that may be considered hate speech.
+	AutoModerationKeywordPresetTypeSlurs AutoModerationKeywordPresetType = 3
+)
+
+// AutoModerationTriggerMetadata is the additional data used to determine whether a rule should be triggered.
+//
+// Each field is only used by some trigger types.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-metadata
+type AutoModerationTriggerMetadata struct {
+	// KeywordFilter is the list of substrings which will be searched for in content (max 1000).
+	//
+	// Used by: Keyword, MemberProfile.
+	KeywordFilter []string `json:"keyword_filter,omitempty"`
+
+	// RegexPatterns is the list of Rust flavored regular expressions which will be matched against content (max 10).
+	//
+	// Used by: Keyword, MemberProfile.
+	RegexPatterns []string `json:"regex_patterns,omitempty"`
+
+	// Presets is the list of internal pre-defined wordsets which will be searched for in content.
+	//
+	// Used by: KeywordPreset.
+	Presets []AutoModerationKeywordPresetType `json:"presets,omitempty"`
+
+	// AllowList is the list of substrings which should not trigger the rule.
+	//
+	// Used by: Keyword, KeywordPreset, MemberProfile.
+	AllowList []string `json:"allow_list,omitempty"`
+
+	// MentionTotalLimit is the total number of unique role and user mentions allowed per message (max 50).
+	//
+	// Used by: MentionSpam.
+	MentionTotalLimit int `json:"mention_total_limit,omitempty"`
+
+	// MentionRaidProtectionEnabled is whether to automatically detect mention raids.
+	//
+	// Used by: MentionSpam.
+	MentionRaidProtectionEnabled bool `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+// AutoModerationActionType is the type of action taken when a rule is triggered.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-types
+type AutoModerationActionType int
+
+const (
+	// AutoModerationActionTypeBlockMessage blocks a member's message and prevents it from being posted.
+	AutoModerationActionTypeBlockMessage AutoModerationActionType = 1
+
+	// AutoModerationActionTypeSendAlertMessage logs user content to a specified channel.
+	AutoModerationActionTypeSendAlertMessage AutoModerationActionType = 2
+
+	// AutoModerationActionTypeTimeout times out a user for a specified duration.
+	AutoModerationActionTypeTimeout AutoModerationActionType = 3
+
+	// AutoModerationActionTypeBlockMemberInteraction prevents a member from using text, voice, or other interactions.
+	AutoModerationActionTypeBlockMemberInteraction AutoModerationActionType = 4
+)
+
+// Is returns true if the action type matches the provided one.
+func (t AutoModerationActionType) Is(actionType AutoModerationActionType) bool {
+	return t == actionType
+}
+
+// AutoModerationActionMetadata is the additional data used when an action is executed.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-metadata
+type AutoModerationActionMetadata struct {
+	// ChannelID is the channel to which user content should be logged.
+	//
+	// Used by: SendAlertMessage.
+	ChannelID Snowflake `json:"channel_id,omitempty"`
+
+	// DurationSeconds is the timeout duration in seconds (max 2419200, 4 weeks).
+	//
+	// Used by: Timeout.
+	DurationSeconds int `json:"duration_seconds,omitempty"`
+
+	// CustomMessage is shown to members whenever their message is blocked (max 150 characters).
+	//
+	// Used by: BlockMessage.
+	CustomMessage string `json:"custom_message,omitempty"`
+}
+
+// AutoModerationAction is an action which will execute whenever a rule is triggered.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object
+type AutoModerationAction struct {
+	// Type is the type of action.
+	Type AutoModerationActionType `json:"type"`
+
+	// Metadata is the additional data needed to execute the action.
+	//
+	// Optional:
+	//   - Will be nil for actions without metadata.
+	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
+}
+
+// AutoModerationRule is a rule of the auto moderation feature of a guild.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object
+type AutoModerationRule struct {
+	// ID is the ID of the rule.
+	ID Snowflake `json:"id"`
+
+	// GuildID is the ID of the guild which this rule belongs to.
+	GuildID Snowflake `json:"guild_id"`
+
+	// Name is the name of the rule.
+	Name string `json:"name"`
+
+	// CreatorID is the ID of the user which first created this rule.
+	CreatorID Snowflake `json:"creator_id"`
+
+	// EventType is the event context in which the rule is checked.
+	EventType AutoModerationEventType `json:"event_type"`
+
+	// TriggerType is the type of content which can trigger the rule.
+	TriggerType AutoModerationTriggerType `json:"trigger_type"`
+
+	// TriggerMetadata is the additional data used to determine whether the rule should be triggered.
+	TriggerMetadata AutoModerationTriggerMetadata `json:"trigger_metadata"`
+
+	// Actions is the list of actions which will execute when the rule is triggered.
+	Actions []AutoModerationAction `json:"actions"`
+
+	// Enabled is whether the rule is enabled.
+	Enabled bool `json:"enabled"`
+
+	// ExemptRoles is the list of role IDs that should not be affected by the rule (max 20).
+	ExemptRoles []Snowflake `json:"exempt_roles"`
+
+	// ExemptChannels is the list of channel IDs that should not be affected by the rule (max 50).
+	ExemptChannels []Snowflake `json:"exempt_channels"`
+}
+
+// CreatedAt returns the time when this rule was created.
+func (r *AutoModerationRule) CreatedAt() time.Time {
+	return r.ID.Timestamp()
+}
+
+/*****************************
+ *   Auto Moderation REST
+ *****************************/
+
+// ListAutoModerationRules returns the auto moderation rules of a guild.
+//
+// Requires the PermissionManageGuild permission.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#list-auto-moderation-rules-for-guild
+func (r *requester) ListAutoModerationRules(guildID Snowflake) result.Result[[]AutoModerationRule] {
+	res := r.DoRequest(Request{
+		Method: "GET",
+		URL:    "/guilds/" + guildID.String() + "/auto-moderation/rules",
+	})
+	if res.IsErr() {
+		return result.Err[[]AutoModerationRule](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var rules []AutoModerationRule
+	if err := json.NewDecoder(body).Decode(&rules); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/auto-moderation/rules",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]AutoModerationRule](err)
+	}
+	return result.Ok(rules)
+}
+
+// FetchAutoModerationRule returns an auto moderation rule of a guild.
+//
+// Requires the PermissionManageGuild permission.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#get-auto-moderation-rule
+func (r *requester) FetchAutoModerationRule(guildID, ruleID Snowflake) result.Result[AutoModerationRule] {
+	res := r.DoRequest(Request{
+		Method: "GET",
+		URL:    "/guilds/" + guildID.String() + "/auto-moderation/rules/" + ruleID.String(),
+	})
+	if res.IsErr() {
+		return result.Err[AutoModerationRule](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var rule AutoModerationRule
+	if err := json.NewDecoder(body).Decode(&rule); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/auto-moderation/rules/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[AutoModerationRule](err)
+	}
+	return result.Ok(rule)
+}
+
+// CreateAutoModerationRuleOptions contains parameters for creating an auto moderation rule.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#create-auto-moderation-rule-json-params
+type CreateAutoModerationRuleOptions struct {
+	// Name is the name of the rule.
+	Name string `json:"name"`
+
+	// EventType is the event context in which the rule is checked.
+	EventType AutoModerationEventType `json:"event_type"`
+
+	// TriggerType is the type of content which can trigger the rule.
+	TriggerType AutoModerationTriggerType `json:"trigger_type"`
+
+	// TriggerMetadata is the additional data used to determine whether the rule should be triggered.
+	//
+	// Note:
+	//   - Required depending on TriggerType.
+	TriggerMetadata *AutoModerationTriggerMetadata `json:"trigger_metadata,omitempty"`
+
+	// Actions is the list of actions which will execute when the rule is triggered.
+	Actions []AutoModerationAction `json:"actions"`
+
+	// Enabled is whether the rule is enabled, false by default.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ExemptRoles is the list of role IDs that should not be affected by the rule (max 20).
+	ExemptRoles []Snowflake `json:"exempt_roles,omitempty"`
+
+	// ExemptChannels is the list of channel IDs that should not be affected by the rule (max 50).
+	ExemptChannels []Snowflake `json:"exempt_channels,omitempty"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// CreateAutoModerationRule creates an auto moderation rule in a guild.
+//
+// Requires the PermissionManageGuild permission.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#create-auto-moderation-rule
+func (r *requester) CreateAutoModerationRule(guildID Snowflake, opts CreateAutoModerationRuleOptions) result.Result[AutoModerationRule] {
+	reqBody, err := json.Marshal(opts)
+	if err != nil {
+		return result.Err[AutoModerationRule](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/guilds/" + guildID.String() + "/auto-moderation/rules",
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[AutoModerationRule](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var rule AutoModerationRule
+	if err := json.NewDecoder(body).Decode(&rule); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/guilds/{id}/auto-moderation/rules",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[AutoModerationRule](err)
+	}
+	return result.Ok(rule)
+}
+
+// ModifyAutoModerationRuleOptions contains parameters for modifying an auto moderation rule.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#modify-auto-moderation-rule-json-params
+type ModifyAutoModerationRuleOptions struct {
+	// Name is the name of the rule.
+	Name optional.Option[string] `json:"name,omitzero"`
+
+	// EventType is the event context in which the rule is checked.
+	EventType optional.Option[AutoModerationEventType] `json:"event_type,omitzero"`
+
+	// TriggerMetadata is the additional data used to determine whether the rule should be triggered.
+	TriggerMetadata optional.Option[AutoModerationTriggerMetadata] `json:"trigger_metadata,omitzero"`
+
+	// Actions is the list of actions which will execute when the rule is triggered.
+	Actions optional.Option[[]AutoModerationAction] `json:"actions,omitzero"`
+
+	// Enabled is whether the rule is enabled.
+	Enabled optional.Option[bool] `json:"enabled,omitzero"`
+
+	// ExemptRoles is the list of role IDs that should not be affected by the rule (max 20).
+	ExemptRoles optional.Option[[]Snowflake] `json:"exempt_roles,omitzero"`
+
+	// ExemptChannels is the list of channel IDs that should not be affected by the rule (max 50).
+	ExemptChannels optional.Option[[]Snowflake] `json:"exempt_channels,omitzero"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// ModifyAutoModerationRule modifies an auto moderation rule of a guild.
+//
+// Requires the PermissionManageGuild permission.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#modify-auto-moderation-rule
+func (r *requester) ModifyAutoModerationRule(guildID, ruleID Snowflake, opts ModifyAutoModerationRuleOptions) result.Result[AutoModerationRule] {
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[AutoModerationRule](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "PATCH",
+		URL:    "/guilds/" + guildID.String() + "/auto-moderation/rules/" + ruleID.String(),
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[AutoModerationRule](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var rule AutoModerationRule
+	if err := json.NewDecoder(body).Decode(&rule); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/guilds/{id}/auto-moderation/rules/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[AutoModerationRule](err)
+	}
+	return result.Ok(rule)
+}
+
+// DeleteAutoModerationRule deletes an auto moderation rule of a guild.
+//
+// Requires the PermissionManageGuild permission.
+//
+// Reference: https://discord.com/developers/docs/resources/auto-moderation#delete-auto-moderation-rule
+func (r *requester) DeleteAutoModerationRule(guildID, ruleID Snowflake, reason string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/guilds/" + guildID.String() + "/auto-moderation/rules/" + ruleID.String(),
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
@@ -0,0 +1,89 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCreateKeywordAutoModerationRule(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/guilds/1/auto-moderation/rules" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["name"] != "no spoilers" || body["event_type"] != float64(1) || body["trigger_type"] != float64(1) {
+			t.Errorf("body = %v", body)
+		}
+		metadata, _ := body["trigger_metadata"].(map[string]any)
+		if keywords, _ := metadata["keyword_filter"].([]any); len(keywords) != 2 || keywords[0] != "spoiler*" {
+			t.Errorf("trigger_metadata = %v", body["trigger_metadata"])
+		}
+		if _, ok := metadata["presets"]; ok {
+			t.Error("unused trigger metadata should be omitted")
+		}
+		if _, ok := body["exempt_roles"]; ok {
+			t.Error("empty exempt_roles should be omitted")
+		}
+		io.WriteString(w, `{"id":"40","guild_id":"1","name":"no spoilers","event_type":1,"trigger_type":1,"enabled":true}`)
+	})
+
+	res := r.CreateAutoModerationRule(1, CreateAutoModerationRuleOptions{
+		Name:            "no spoilers",
+		EventType:       AutoModerationEventTypeMessageSend,
+		TriggerType:     AutoModerationTriggerTypeKeyword,
+		TriggerMetadata: &AutoModerationTriggerMetadata{KeywordFilter: []string{"spoiler*", "ending"}},
+		Actions:         []AutoModerationAction{{Type: AutoModerationActionTypeBlockMessage}},
+		Enabled:         true,
+	})
+	if res.IsErr() {
+		t.Fatalf("CreateAutoModerationRule: %v", res.Err())
+	}
+	if rule := res.Value(); rule.ID != 40 || !rule.Enabled {
+		t.Errorf("rule = %+v", rule)
+	}
+}
+
+func TestAutoModerationRuleDecode(t *testing.T) {
+	data := `{
+		"id": "40", "guild_id": "1", "name": "no spoilers", "creator_id": "5",
+		"event_type": 1, "trigger_type": 1,
+		"trigger_metadata": {"keyword_filter": ["spoiler*"], "regex_patterns": [], "allow_list": []},
+		"actions": [{"type": 1, "metadata": {"custom_message": "No spoilers please"}}],
+		"enabled": true, "exempt_roles": ["7"], "exempt_channels": []
+	}`
+
+	var rule AutoModerationRule
+	if err := json.Unmarshal([]byte(data), &rule); err != nil {
+		t.Fatalf("unmarshal rule: %v", err)
+	}
+	if !rule.TriggerType.Is(AutoModerationTriggerTypeKeyword) || !rule.EventType.Is(AutoModerationEventTypeMessageSend) {
+		t.Errorf("rule types = %d, %d", rule.TriggerType, rule.EventType)
+	}
+	if len(rule.Actions) != 1 || !rule.Actions[0].Type.Is(AutoModerationActionTypeBlockMessage) {
+		t.Fatalf("actions = %+v", rule.Actions)
+	}
+	if metadata := rule.Actions[0].Metadata; metadata == nil || metadata.CustomMessage != "No spoilers please" {
+		t.Errorf("action metadata = %+v", metadata)
+	}
+	if len(rule.ExemptRoles) != 1 || rule.ExemptRoles[0] != 7 {
+		t.Errorf("exempt roles = %v", rule.ExemptRoles)
+	}
+}
@@ -102,3 +102,18 @@ func BitFieldMissing[T BitField](bitfield T, bitmasks ...T) T {
 	}
 	return missing
 }
+
+// BitFieldToggle returns a new bitfield with the specified bitmasks flipped.
+// Each bitmask corresponds to a flag value that will be set if it was
+// cleared, or cleared if it was set (XORed).
+//
+// Example:
+//
+//	var flags uint8 = 5 // 0101
+//	flags = BitFieldToggle(flags, 1, 2) // flips bit 0 and 1 → flags = 6
+func BitFieldToggle[T BitField](bitfield T, bitmasks ...T) T {
+	for _, bitmask := range bitmasks {
+		bitfield ^= bitmask
+	}
+	return bitfield
+}
@@ -14,7 +14,10 @@
 package dwaz
 
 import (
+	"maps"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/marouanesouiri/stdx/optional"
 )
@@ -46,10 +49,52 @@ type SnowflakePairKey struct {
 	B Snowflake
 }
 
+// CacheLookups counts the lookups of a cache collection.
+type CacheLookups struct {
+	// Hits is the number of lookups that found the entry.
+	Hits uint64
+	// Misses is the number of lookups that did not.
+	Misses uint64
+}
+
+// HitRatio returns the share of lookups that were hits, or 0 if there were none.
+func (l CacheLookups) HitRatio() float64 {
+	total := l.Hits + l.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(l.Hits) / float64(total)
+}
+
+// CacheStats is a snapshot of the size and lookup counters of a cache.
+//
+// Counts are the current number of cached entries, lookups are cumulative since the cache was created.
+// Guild-wide lookups such as GetGuildMembers count once in the lookups of their collection.
+type CacheStats struct {
+	Guilds      int
+	Channels    int
+	Roles       int
+	Members     int
+	Users       int
+	Messages    int
+	VoiceStates int
+
+	GuildLookups      CacheLookups
+	ChannelLookups    CacheLookups
+	RoleLookups       CacheLookups
+	MemberLookups     CacheLookups
+	UserLookups       CacheLookups
+	MessageLookups    CacheLookups
+	VoiceStateLookups CacheLookups
+}
+
 type CacheManager interface {
 	Flags() CacheFlags
 	SetFlags(flags ...CacheFlags)
 
+	// Stats returns the entry counts and cumulative hit/miss counters of the cache.
+	Stats() CacheStats
+
 	GetUser(userID Snowflake) optional.Option[User]
 	GetGuild(guildID Snowflake) optional.Option[Guild]
 	GetMember(guildID, userID Snowflake) optional.Option[Member]
@@ -62,6 +107,12 @@ type CacheManager interface {
 	GetGuildRoles(guildID Snowflake) optional.Option[map[Snowflake]Role]
 	GetRoles(rolesIDs ...Snowflake) map[Snowflake]Role
 
+	// GuildChannels returns the cached channels of the guild, threads included, sorted by position then ID.
+	// Channels without a position, like threads, come last, sorted by ID.
+	GuildChannels(guildID Snowflake) []GuildChannel
+	// GuildRoles returns the cached roles of the guild, sorted by position then ID.
+	GuildRoles(guildID Snowflake) []Role
+
 	HasUser(userID Snowflake) bool
 	HasGuild(guildID Snowflake) bool
 	HasMember(guildID, userID Snowflake) bool
@@ -109,8 +160,7 @@ type CacheManager interface {
 type InMemoryCacheManager struct {
 	flags CacheFlags
 
-	usersCache   map[Snowflake]User
-	usersCacheMu sync.RWMutex
+	usersCache CacheStore[User]
 
 	guildsCache   map[Snowflake]Guild
 	guildsCacheMu sync.RWMutex
@@ -121,8 +171,7 @@ type InMemoryCacheManager struct {
 	channelsCache   map[Snowflake]Channel
 	channelsCacheMu sync.RWMutex
 
-	messagesCache   map[Snowflake]Message
-	messagesCacheMu sync.RWMutex
+	messagesCache CacheStore[Message]
 
 	voiceStatesCache   map[SnowflakePairKey]VoiceState
 	voiceStatesCacheMu sync.RWMutex
@@ -145,23 +194,80 @@ type InMemoryCacheManager struct {
 	// Index: guildID -> map[roleID]
 	guildToRoleIDs   map[Snowflake]map[Snowflake]struct{}
 	guildToRoleIDsMu sync.RWMutex
+
+	// Guilds whose roles are all cached, guarded by guildToRoleIDsMu.
+	completeRoleGuilds map[Snowflake]struct{}
+
+	guildLookups      lookupCounter
+	channelLookups    lookupCounter
+	roleLookups       lookupCounter
+	memberLookups     lookupCounter
+	userLookups       lookupCounter
+	messageLookups    lookupCounter
+	voiceStateLookups lookupCounter
+}
+
+// lookupCounter is the lock-free counterpart of CacheLookups.
+type lookupCounter struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// record counts a lookup as a hit or a miss and returns found.
+func (l *lookupCounter) record(found bool) bool {
+	if found {
+		l.hits.Add(1)
+	} else {
+		l.misses.Add(1)
+	}
+	return found
+}
+
+func (l *lookupCounter) snapshot() CacheLookups {
+	return CacheLookups{Hits: l.hits.Load(), Misses: l.misses.Load()}
 }
 
-func NewInMemoryCacheManager(flags CacheFlags) *InMemoryCacheManager {
-	return &InMemoryCacheManager{
+func NewInMemoryCacheManager(flags CacheFlags, options ...InMemoryCacheOption) *InMemoryCacheManager {
+	c := &InMemoryCacheManager{
 		flags: flags,
 
-		usersCache:               make(map[Snowflake]User),
+		usersCache:               NewMapCacheStore[User](),
 		guildsCache:              make(map[Snowflake]Guild),
 		membersCache:             make(map[SnowflakePairKey]Member),
 		channelsCache:            make(map[Snowflake]Channel),
-		messagesCache:            make(map[Snowflake]Message),
+		messagesCache:            NewMapCacheStore[Message](),
 		voiceStatesCache:         make(map[SnowflakePairKey]VoiceState),
 		rolesCache:               make(map[Snowflake]Role),
 		guildToMemberIDs:         make(map[Snowflake]map[Snowflake]struct{}),
 		guildToChannelIDs:        make(map[Snowflake]map[Snowflake]struct{}),
 		guildToVoiceStateUserIDs: make(map[Snowflake]map[Snowflake]struct{}),
 		guildToRoleIDs:           make(map[Snowflake]map[Snowflake]struct{}),
+		completeRoleGuilds:       make(map[Snowflake]struct{}),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// InMemoryCacheOption configures an InMemoryCacheManager on creation.
+type InMemoryCacheOption func(*InMemoryCacheManager)
+
+// WithUserCacheStore sets the store of the users cache, e.g. NewLRUCacheStore to bound its size.
+//
+// Default is an unbounded NewMapCacheStore.
+func WithUserCacheStore(store CacheStore[User]) InMemoryCacheOption {
+	return func(c *InMemoryCacheManager) {
+		c.usersCache = store
+	}
+}
+
+// WithMessageCacheStore sets the store of the messages cache, e.g. NewLRUCacheStore to bound its size.
+//
+// Default is an unbounded NewMapCacheStore.
+func WithMessageCacheStore(store CacheStore[Message]) InMemoryCacheOption {
+	return func(c *InMemoryCacheManager) {
+		c.messagesCache = store
 	}
 }
 
@@ -176,53 +282,69 @@ func (c *InMemoryCacheManager) SetFlags(flags ...CacheFlags) {
 	}
 }
 
+func (c *InMemoryCacheManager) Stats() CacheStats {
+	return CacheStats{
+		Guilds:      c.CountGuilds(),
+		Channels:    c.CountChannels(),
+		Roles:       c.CountRoles(),
+		Members:     c.CountMembers(),
+		Users:       c.CountUsers(),
+		Messages:    c.CountMessages(),
+		VoiceStates: c.CountVoiceStates(),
+
+		GuildLookups:      c.guildLookups.snapshot(),
+		ChannelLookups:    c.channelLookups.snapshot(),
+		RoleLookups:       c.roleLookups.snapshot(),
+		MemberLookups:     c.memberLookups.snapshot(),
+		UserLookups:       c.userLookups.snapshot(),
+		MessageLookups:    c.messageLookups.snapshot(),
+		VoiceStateLookups: c.voiceStateLookups.snapshot(),
+	}
+}
+
 func (c *InMemoryCacheManager) GetUser(userID Snowflake) optional.Option[User] {
-	c.usersCacheMu.RLock()
-	val, ok := c.usersCache[userID]
-	c.usersCacheMu.RUnlock()
-	return optional.FromPair(val, ok)
+	val, ok := c.usersCache.Get(userID)
+	return optional.FromPair(val, c.userLookups.record(ok))
 }
 
 func (c *InMemoryCacheManager) GetGuild(guildID Snowflake) optional.Option[Guild] {
 	c.guildsCacheMu.RLock()
 	val, ok := c.guildsCache[guildID]
 	c.guildsCacheMu.RUnlock()
-	return optional.FromPair(val, ok)
+	return optional.FromPair(val, c.guildLookups.record(ok))
 }
 
 func (c *InMemoryCacheManager) GetMember(guildID, userID Snowflake) optional.Option[Member] {
 	c.membersCacheMu.RLock()
 	val, ok := c.membersCache[SnowflakePairKey{A: guildID, B: userID}]
 	c.membersCacheMu.RUnlock()
-	return optional.FromPair(val, ok)
+	return optional.FromPair(val, c.memberLookups.record(ok))
 }
 
 func (c *InMemoryCacheManager) GetChannel(channelID Snowflake) optional.Option[Channel] {
 	c.channelsCacheMu.RLock()
 	val, ok := c.channelsCache[channelID]
 	c.channelsCacheMu.RUnlock()
-	return optional.FromPair(val, ok)
+	return optional.FromPair(val, c.channelLookups.record(ok))
 }
 
 func (c *InMemoryCacheManager) GetMessage(messageID Snowflake) optional.Option[Message] {
-	c.messagesCacheMu.RLock()
-	val, ok := c.messagesCache[messageID]
-	c.messagesCacheMu.RUnlock()
-	return optional.FromPair(val, ok)
+	val, ok := c.messagesCache.Get(messageID)
+	return optional.FromPair(val, c.messageLookups.record(ok))
 }
 
 func (c *InMemoryCacheManager) GetVoiceState(guildID, userID Snowflake) optional.Option[VoiceState] {
 	c.voiceStatesCacheMu.RLock()
 	val, ok := c.voiceStatesCache[SnowflakePairKey{A: guildID, B: userID}]
 	c.voiceStatesCacheMu.RUnlock()
-	return optional.FromPair(val, ok)
+	return optional.FromPair(val, c.voiceStateLookups.record(ok))
 }
 
 func (c *InMemoryCacheManager) GetGuildChannels(guildID Snowflake) optional.Option[map[Snowflake]GuildChannel] {
 	c.guildToChannelIDsMu.RLock()
 	set, ok := c.guildToChannelIDs[guildID]
 	c.guildToChannelIDsMu.RUnlock()
-	if !ok {
+	if !c.channelLookups.record(ok) {
 		return optional.None[map[Snowflake]GuildChannel]()
 	}
 	c.channelsCacheMu.RLock()
@@ -240,7 +362,7 @@ func (c *InMemoryCacheManager) GetGuildMembers(guildID Snowflake) optional.Optio
 	c.guildToMemberIDsMu.RLock()
 	set, ok := c.guildToMemberIDs[guildID]
 	c.guildToMemberIDsMu.RUnlock()
-	if !ok {
+	if !c.memberLookups.record(ok) {
 		return optional.None[map[Snowflake]Member]()
 	}
 	c.membersCacheMu.RLock()
@@ -259,7 +381,7 @@ func (c *InMemoryCacheManager) GetGuildVoiceStates(guildID Snowflake) optional.O
 	c.guildToVoiceStateUserIDsMu.RLock()
 	set, ok := c.guildToVoiceStateUserIDs[guildID]
 	c.guildToVoiceStateUserIDsMu.RUnlock()
-	if !ok {
+	if !c.voiceStateLookups.record(ok) {
 		return optional.None[map[Snowflake]VoiceState]()
 	}
 	c.voiceStatesCacheMu.RLock()
@@ -278,7 +400,7 @@ func (c *InMemoryCacheManager) GetGuildRoles(guildID Snowflake) optional.Option[
 	c.guildToRoleIDsMu.RLock()
 	set, ok := c.guildToRoleIDs[guildID]
 	c.guildToRoleIDsMu.RUnlock()
-	if !ok {
+	if !c.roleLookups.record(ok) {
 		return optional.None[map[Snowflake]Role]()
 	}
 	c.rolesCacheMu.RLock()
@@ -296,9 +418,7 @@ func (c *InMemoryCacheManager) HasUser(userID Snowflake) bool {
 	if !c.flags.Has(CacheFlagUsers) {
 		return false
 	}
-	c.usersCacheMu.RLock()
-	_, exists := c.usersCache[userID]
-	c.usersCacheMu.RUnlock()
+	_, exists := c.usersCache.Get(userID)
 	return exists
 }
 
@@ -336,9 +456,7 @@ func (c *InMemoryCacheManager) HasMessage(messageID Snowflake) bool {
 	if !c.flags.Has(CacheFlagMessages) {
 		return false
 	}
-	c.messagesCacheMu.RLock()
-	_, exists := c.messagesCache[messageID]
-	c.messagesCacheMu.RUnlock()
+	_, exists := c.messagesCache.Get(messageID)
 	return exists
 }
 
@@ -393,10 +511,7 @@ func (c *InMemoryCacheManager) HasGuildRoles(guildID Snowflake) bool {
 }
 
 func (c *InMemoryCacheManager) CountUsers() int {
-	c.usersCacheMu.RLock()
-	count := len(c.usersCache)
-	c.usersCacheMu.RUnlock()
-	return count
+	return c.usersCache.Len()
 }
 
 func (c *InMemoryCacheManager) CountGuilds() int {
@@ -421,10 +536,7 @@ func (c *InMemoryCacheManager) CountChannels() int {
 }
 
 func (c *InMemoryCacheManager) CountMessages() int {
-	c.messagesCacheMu.RLock()
-	count := len(c.messagesCache)
-	c.messagesCacheMu.RUnlock()
-	return count
+	return c.messagesCache.Len()
 }
 
 func (c *InMemoryCacheManager) CountVoiceStates() int {
@@ -475,9 +587,7 @@ func (c *InMemoryCacheManager) PutUser(user User) {
 	if !c.flags.Has(CacheFlagUsers) {
 		return
 	}
-	c.usersCacheMu.Lock()
-	c.usersCache[user.ID] = user
-	c.usersCacheMu.Unlock()
+	c.usersCache.Put(user.ID, user)
 }
 
 func (c *InMemoryCacheManager) PutGuild(guild Guild) {
@@ -530,9 +640,7 @@ func (c *InMemoryCacheManager) PutMessage(message Message) {
 	if !c.flags.Has(CacheFlagMessages) {
 		return
 	}
-	c.messagesCacheMu.Lock()
-	c.messagesCache[message.ID] = message
-	c.messagesCacheMu.Unlock()
+	c.messagesCache.Put(message.ID, message)
 }
 
 func (c *InMemoryCacheManager) PutVoiceState(voiceState VoiceState) {
@@ -571,13 +679,7 @@ func (c *InMemoryCacheManager) PutRole(role Role) {
 }
 
 func (c *InMemoryCacheManager) DelUser(userID Snowflake) bool {
-	c.usersCacheMu.Lock()
-	_, ok := c.usersCache[userID]
-	if ok {
-		delete(c.usersCache, userID)
-	}
-	c.usersCacheMu.Unlock()
-	return ok
+	return c.usersCache.Del(userID)
 }
 
 func (c *InMemoryCacheManager) DelGuild(guildID Snowflake) bool {
@@ -634,13 +736,7 @@ func (c *InMemoryCacheManager) DelChannel(channelID Snowflake) bool {
 }
 
 func (c *InMemoryCacheManager) DelMessage(messageID Snowflake) bool {
-	c.messagesCacheMu.Lock()
-	_, ok := c.messagesCache[messageID]
-	if ok {
-		delete(c.messagesCache, messageID)
-	}
-	c.messagesCacheMu.Unlock()
-	return ok
+	return c.messagesCache.Del(messageID)
 }
 
 func (c *InMemoryCacheManager) DelVoiceState(guildID, userID Snowflake) bool {
@@ -677,6 +773,7 @@ func (c *InMemoryCacheManager) DelRole(guildID, roleID Snowflake) bool {
 			delete(m, roleID)
 			if len(m) == 0 {
 				delete(c.guildToRoleIDs, guildID)
+				delete(c.completeRoleGuilds, guildID)
 			}
 		}
 		c.guildToRoleIDsMu.Unlock()
@@ -724,13 +821,23 @@ func (c *InMemoryCacheManager) GetRoles(rolesIDs ...Snowflake) map[Snowflake]Rol
 	defer c.rolesCacheMu.RUnlock()
 	res := make(map[Snowflake]Role, len(rolesIDs))
 	for _, id := range rolesIDs {
-		if role, ok := c.rolesCache[id]; ok {
+		if role, ok := c.rolesCache[id]; c.roleLookups.record(ok) {
 			res[id] = role
 		}
 	}
 	return res
 }
 
+func (c *InMemoryCacheManager) GuildChannels(guildID Snowflake) []GuildChannel {
+	channels := c.GetGuildChannels(guildID).OrEmpty()
+	return sortGuildChannels(slices.Collect(maps.Values(channels)))
+}
+
+func (c *InMemoryCacheManager) GuildRoles(guildID Snowflake) []Role {
+	roles := c.GetGuildRoles(guildID).OrEmpty()
+	return sortRoles(slices.Collect(maps.Values(roles)))
+}
+
 func (c *InMemoryCacheManager) HasRoles(rolesIDs ...Snowflake) bool {
 	if !c.flags.Has(CacheFlagRoles) {
 		return false
@@ -751,6 +858,39 @@ func (c *InMemoryCacheManager) PutRoles(roles ...Role) {
 	}
 }
 
+// guildRolesCompleter is implemented by cache managers recording whether every role of a guild
+// is cached. Cache managers without it are never considered complete, so roles get fetched.
+type guildRolesCompleter interface {
+	// markGuildRolesComplete records that every role of the guild is cached, e.g. after
+	// GUILD_CREATE or a FetchRoles. The marker is dropped when the guild's last cached role is deleted.
+	markGuildRolesComplete(guildID Snowflake)
+	// hasCompleteGuildRoles reports whether every role of the guild is cached.
+	hasCompleteGuildRoles(guildID Snowflake) bool
+}
+
+var _ guildRolesCompleter = (*InMemoryCacheManager)(nil)
+
+func (c *InMemoryCacheManager) markGuildRolesComplete(guildID Snowflake) {
+	if !c.flags.Has(CacheFlagRoles) {
+		return
+	}
+	c.guildToRoleIDsMu.Lock()
+	if _, exists := c.guildToRoleIDs[guildID]; exists {
+		c.completeRoleGuilds[guildID] = struct{}{}
+	}
+	c.guildToRoleIDsMu.Unlock()
+}
+
+func (c *InMemoryCacheManager) hasCompleteGuildRoles(guildID Snowflake) bool {
+	if !c.flags.Has(CacheFlagRoles) {
+		return false
+	}
+	c.guildToRoleIDsMu.RLock()
+	_, ok := c.completeRoleGuilds[guildID]
+	c.guildToRoleIDsMu.RUnlock()
+	return ok
+}
+
 func (c *InMemoryCacheManager) DelRoles(roleIDs ...Snowflake) bool {
 	anyDeleted := false
 	for _, id := range roleIDs {
@@ -0,0 +1,238 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// CacheStore is the storage backing the users or messages cache of InMemoryCacheManager.
+//
+// Implementations must be safe for concurrent use. A store may evict entries on its own,
+// the library treats an evicted entry like one that was never cached.
+type CacheStore[V any] interface {
+	// Get returns the value stored for id, if any.
+	Get(id Snowflake) (V, bool)
+	// Put stores value for id, replacing the previous one.
+	Put(id Snowflake, value V)
+	// Del removes the value of id, reporting whether it was present.
+	Del(id Snowflake) bool
+	// Len returns the number of stored values.
+	Len() int
+}
+
+/*****************************
+ *        Map store
+ *****************************/
+
+// mapCacheStore is an unbounded CacheStore.
+type mapCacheStore[V any] struct {
+	mu     sync.RWMutex
+	values map[Snowflake]V
+}
+
+// NewMapCacheStore returns an unbounded CacheStore, keeping every value until it is deleted.
+//
+// This is the default store of InMemoryCacheManager.
+func NewMapCacheStore[V any]() CacheStore[V] {
+	return &mapCacheStore[V]{values: make(map[Snowflake]V)}
+}
+
+func (s *mapCacheStore[V]) Get(id Snowflake) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[id]
+	return value, ok
+}
+
+func (s *mapCacheStore[V]) Put(id Snowflake, value V) {
+	s.mu.Lock()
+	s.values[id] = value
+	s.mu.Unlock()
+}
+
+func (s *mapCacheStore[V]) Del(id Snowflake) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.values[id]
+	delete(s.values, id)
+	return ok
+}
+
+func (s *mapCacheStore[V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.values)
+}
+
+/*****************************
+ *        LRU store
+ *****************************/
+
+// lruEntry is an element of the recency list of lruCacheStore.
+type lruEntry[V any] struct {
+	id    Snowflake
+	value V
+}
+
+// lruCacheStore is a CacheStore holding at most capacity values.
+type lruCacheStore[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is the most recently used
+	elements map[Snowflake]*list.Element
+}
+
+// NewLRUCacheStore returns a CacheStore holding at most capacity values.
+// When full, storing a new value evicts the least recently read or stored one.
+//
+// Panics if capacity is not positive.
+func NewLRUCacheStore[V any](capacity int) CacheStore[V] {
+	if capacity <= 0 {
+		panic("NewLRUCacheStore: capacity must be positive")
+	}
+	return &lruCacheStore[V]{
+		capacity: capacity,
+		order:    list.New(),
+		elements: make(map[Snowflake]*list.Element, capacity),
+	}
+}
+
+func (s *lruCacheStore[V]) Get(id Snowflake) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	element, ok := s.elements[id]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	s.order.MoveToFront(element)
+	return element.Value.(*lruEntry[V]).value, true
+}
+
+func (s *lruCacheStore[V]) Put(id Snowflake, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if element, ok := s.elements[id]; ok {
+		element.Value.(*lruEntry[V]).value = value
+		s.order.MoveToFront(element)
+		return
+	}
+	if s.order.Len() >= s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.elements, oldest.Value.(*lruEntry[V]).id)
+	}
+	s.elements[id] = s.order.PushFront(&lruEntry[V]{id: id, value: value})
+}
+
+func (s *lruCacheStore[V]) Del(id Snowflake) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	element, ok := s.elements[id]
+	if ok {
+		s.order.Remove(element)
+		delete(s.elements, id)
+	}
+	return ok
+}
+
+func (s *lruCacheStore[V]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+/*****************************
+ *        TTL store
+ *****************************/
+
+// ttlCacheStore is a CacheStore whose values expire a fixed duration after being stored.
+type ttlCacheStore[V any] struct {
+	clock Clock
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	entries   map[Snowflake]ttlEntry[V]
+	lastSweep time.Time
+}
+
+// NewTTLCacheStore returns a CacheStore whose values expire ttl after being stored.
+//
+// Expired values are never returned, and are freed at the latest one ttl after expiring.
+// If clock is nil the system clock is used.
+//
+// Panics if ttl is not positive.
+func NewTTLCacheStore[V any](ttl time.Duration, clock Clock) CacheStore[V] {
+	if ttl <= 0 {
+		panic("NewTTLCacheStore: ttl must be positive")
+	}
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &ttlCacheStore[V]{
+		clock:     clock,
+		ttl:       ttl,
+		entries:   make(map[Snowflake]ttlEntry[V]),
+		lastSweep: clock.Now(),
+	}
+}
+
+func (s *ttlCacheStore[V]) Get(id Snowflake) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[id]
+	if !ok || !s.clock.Now().Before(entry.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (s *ttlCacheStore[V]) Put(id Snowflake, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	s.entries[id] = ttlEntry[V]{value: value, expiresAt: now.Add(s.ttl)}
+	if now.Sub(s.lastSweep) >= s.ttl {
+		s.sweep(now)
+	}
+}
+
+func (s *ttlCacheStore[V]) Del(id Snowflake) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[id]
+	delete(s.entries, id)
+	return ok && s.clock.Now().Before(entry.expiresAt)
+}
+
+func (s *ttlCacheStore[V]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(s.clock.Now())
+	return len(s.entries)
+}
+
+// sweep frees the expired entries. Callers must hold s.mu.
+func (s *ttlCacheStore[V]) sweep(now time.Time) {
+	for id, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, id)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,118 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUCacheStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewLRUCacheStore[int](2)
+	store.Put(1, 1)
+	store.Put(2, 2)
+
+	if _, ok := store.Get(1); !ok {
+		t.Fatal("expected 1 to be cached")
+	}
+	store.Put(3, 3)
+
+	if _, ok := store.Get(2); ok {
+		t.Fatal("expected 2 to be evicted")
+	}
+	if _, ok := store.Get(1); !ok {
+		t.Fatal("expected 1 to survive eviction after being read")
+	}
+	if store.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", store.Len())
+	}
+	if store.Del(2) {
+		t.Fatal("expected deleting an evicted entry to report false")
+	}
+}
+
+func TestTTLCacheStoreExpiresEntries(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	store := NewTTLCacheStore[int](time.Minute, clock)
+	store.Put(1, 1)
+
+	clock.advance(30 * time.Second)
+	store.Put(2, 2)
+	if v, ok := store.Get(1); !ok || v != 1 {
+		t.Fatal("expected 1 to be cached before its TTL")
+	}
+
+	clock.advance(45 * time.Second)
+	if _, ok := store.Get(1); ok {
+		t.Fatal("expected 1 to be expired")
+	}
+	if _, ok := store.Get(2); !ok {
+		t.Fatal("expected 2 to be cached before its TTL")
+	}
+	if store.Len() != 1 {
+		t.Fatalf("expected 1 entry, got %d", store.Len())
+	}
+}
+
+func TestInMemoryCacheManagerWithBoundedMessageStore(t *testing.T) {
+	cache := NewInMemoryCacheManager(CacheFlagsAll, WithMessageCacheStore(NewLRUCacheStore[Message](2)))
+	for id := Snowflake(1); id <= 3; id++ {
+		cache.PutMessage(Message{ID: id})
+	}
+
+	if cache.HasMessage(1) {
+		t.Fatal("expected the oldest message to be evicted")
+	}
+	if !cache.HasMessage(3) {
+		t.Fatal("expected the newest message to be cached")
+	}
+	if cache.DelMessage(1) {
+		t.Fatal("expected deleting an evicted message to report false")
+	}
+	if !cache.DelMessage(3) {
+		t.Fatal("expected deleting a cached message to report true")
+	}
+}
+
+func BenchmarkMapCacheStorePut(b *testing.B) {
+	store := NewMapCacheStore[int]()
+	for i := 0; i < b.N; i++ {
+		store.Put(Snowflake(i%4096), i)
+	}
+}
+
+func BenchmarkLRUCacheStorePut(b *testing.B) {
+	store := NewLRUCacheStore[int](1024)
+	for i := 0; i < b.N; i++ {
+		store.Put(Snowflake(i%4096), i)
+	}
+}
+
+func BenchmarkLRUCacheStoreGet(b *testing.B) {
+	store := NewLRUCacheStore[int](1024)
+	for i := 0; i < 1024; i++ {
+		store.Put(Snowflake(i), i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		store.Get(Snowflake(i % 1024))
+	}
+}
+
+func BenchmarkTTLCacheStorePut(b *testing.B) {
+	store := NewTTLCacheStore[int](time.Minute, nil)
+	for i := 0; i < b.N; i++ {
+		store.Put(Snowflake(i%4096), i)
+	}
+}
@@ -0,0 +1,101 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInMemoryCacheManagerStats(t *testing.T) {
+	cache := NewInMemoryCacheManager(CacheFlagsAll)
+	cache.PutUser(User{ID: 1})
+	cache.PutUser(User{ID: 2})
+	cache.PutGuild(Guild{ID: 10})
+	cache.PutMember(Member{ID: 1, GuildID: 10})
+
+	cache.GetUser(1)
+	cache.GetUser(2)
+	cache.GetUser(3) // never cached
+	cache.GetGuild(10)
+	cache.GetMember(10, 2)
+	cache.DelUser(2)
+	cache.GetUser(2)
+
+	stats := cache.Stats()
+	if stats.Users != 1 || stats.Guilds != 1 || stats.Members != 1 || stats.Messages != 0 {
+		t.Fatalf("unexpected counts: %+v", stats)
+	}
+	if stats.UserLookups != (CacheLookups{Hits: 2, Misses: 2}) {
+		t.Fatalf("UserLookups = %+v", stats.UserLookups)
+	}
+	if stats.GuildLookups != (CacheLookups{Hits: 1}) {
+		t.Fatalf("GuildLookups = %+v", stats.GuildLookups)
+	}
+	if stats.MemberLookups != (CacheLookups{Misses: 1}) {
+		t.Fatalf("MemberLookups = %+v", stats.MemberLookups)
+	}
+	if ratio := stats.UserLookups.HitRatio(); ratio != 0.5 {
+		t.Fatalf("HitRatio = %v, want 0.5", ratio)
+	}
+	if ratio := stats.MessageLookups.HitRatio(); ratio != 0 {
+		t.Fatalf("HitRatio without lookups = %v, want 0", ratio)
+	}
+}
+
+func TestInMemoryCacheManagerGuildChannelsAndRoles(t *testing.T) {
+	cache := NewInMemoryCacheManager(CacheFlagsAll)
+	for _, data := range []string{
+		`{"id":"13","type":0,"guild_id":"1","name":"second","position":1}`,
+		`{"id":"20","type":11,"guild_id":"1","name":"thread","parent_id":"11"}`,
+		`{"id":"12","type":0,"guild_id":"1","name":"tie","position":0}`,
+		`{"id":"11","type":0,"guild_id":"1","name":"first","position":0}`,
+		`{"id":"30","type":0,"guild_id":"2","name":"other guild","position":0}`,
+	} {
+		channel, err := UnmarshalChannel([]byte(data))
+		if err != nil {
+			t.Fatalf("UnmarshalChannel(%s): %v", data, err)
+		}
+		cache.PutChannel(channel)
+	}
+	cache.PutRoles(
+		Role{ID: 3, GuildID: 1, Position: 2},
+		Role{ID: 1, GuildID: 1, Position: 0},
+		Role{ID: 2, GuildID: 1, Position: 2},
+		Role{ID: 4, GuildID: 2, Position: 1},
+	)
+
+	var channelIDs []Snowflake
+	for _, channel := range cache.GuildChannels(1) {
+		channelIDs = append(channelIDs, channel.GetID())
+	}
+	if want := []Snowflake{11, 12, 13, 20}; !slices.Equal(channelIDs, want) {
+		t.Errorf("GuildChannels(1) = %v, want %v", channelIDs, want)
+	}
+
+	var roleIDs []Snowflake
+	for _, role := range cache.GuildRoles(1) {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	if want := []Snowflake{1, 2, 3}; !slices.Equal(roleIDs, want) {
+		t.Errorf("GuildRoles(1) = %v, want %v", roleIDs, want)
+	}
+
+	if n := len(cache.GuildChannels(2)); n != 1 {
+		t.Errorf("expected 1 channel in guild 2, got %d", n)
+	}
+	if n := len(cache.GuildRoles(3)); n != 0 {
+		t.Errorf("expected no role for an uncached guild, got %d", n)
+	}
+}
@@ -14,13 +14,18 @@
 package dwaz
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/marouanesouiri/stdx/optional"
 	"github.com/marouanesouiri/stdx/result"
@@ -226,7 +231,7 @@ type DefaultReactionEmoji struct {
 	//
 	// Info:
 	//  - If 0, EmojiName will be set instead.
-	EmojiID Snowflake `json:"emoji_id"`
+	EmojiID Snowflake `json:"emoji_id,omitempty"`
 
 	// EmojiName is the Unicode character of the emoji.
 	//
@@ -235,7 +240,26 @@ type DefaultReactionEmoji struct {
 	//
 	// Info:
 	//  - If empty, EmojiID will be set instead.
-	EmojiName string `json:"emoji_name"`
+	EmojiName string `json:"emoji_name,omitempty"`
+}
+
+// NewDefaultReactionEmoji creates a DefaultReactionEmoji from the given emoji,
+// setting EmojiID for custom emojis and EmojiName for unicode emojis.
+//
+// Example:
+//
+//	emoji, _ := dwaz.ParseEmoji("👍")
+//	opts.DefaultReactionEmoji = optional.Some(dwaz.NewDefaultReactionEmoji(emoji))
+func NewDefaultReactionEmoji(e Emoji) DefaultReactionEmoji {
+	if e.IsCustom() {
+		return DefaultReactionEmoji{EmojiID: e.ID}
+	}
+	return DefaultReactionEmoji{EmojiName: e.Name}
+}
+
+// IsCustom reports whether the default reaction is a custom (guild) emoji.
+func (e DefaultReactionEmoji) IsCustom() bool {
+	return e.EmojiID != 0
 }
 
 // AutoArchiveDuration represents the auto archive duration of a thread channel
@@ -341,6 +365,10 @@ func (c *GuildChannelFields) GetGuildID() Snowflake {
 	return c.GuildID
 }
 
+func (c *GuildChannelFields) setGuildID(guildID Snowflake) {
+	c.GuildID = guildID
+}
+
 func (c *GuildChannelFields) GetName() string {
 	return c.Name
 }
@@ -385,6 +413,10 @@ func (c *ThreadChannelFields) GetGuildID() Snowflake {
 	return c.GuildID
 }
 
+func (c *ThreadChannelFields) setGuildID(guildID Snowflake) {
+	c.GuildID = guildID
+}
+
 func (c *ThreadChannelFields) GetName() string {
 	return c.Name
 }
@@ -417,6 +449,11 @@ func (c *CategorizedChannelFields) GetParentID() Snowflake {
 	return c.ParentID
 }
 
+// Parent returns the ID of the parent category of the channel, None if the channel is not in a category.
+func (c *CategorizedChannelFields) Parent() optional.Option[Snowflake] {
+	return c.ParentID.Option()
+}
+
 // MessageChannelFields holds fields related to text-based features like messaging.
 type MessageChannelFields struct {
 	// LastMessageID is the id of the last message sent in this channel.
@@ -430,13 +467,42 @@ func (t *MessageChannelFields) GetLastMessageID() Snowflake {
 // GuildMessageChannelFields holds fields related to text-based features like messaging.
 type GuildMessageChannelFields struct {
 	MessageChannelFields
-	// RateLimitPerUser is the amount of seconds a user has to wait before sending another message.
+	// RateLimitPerUser is the time a user has to wait before sending another message.
 	// Bots, as well as users with the permission manageMessages or manageChannel, are unaffected.
-	RateLimitPerUser time.Duration `json:"rate_limit_per_user"`
+	RateLimitPerUser DurationSeconds `json:"rate_limit_per_user"`
 }
 
 func (t *GuildMessageChannelFields) GetRateLimitPerUser() time.Duration {
-	return t.RateLimitPerUser
+	return t.RateLimitPerUser.Duration()
+}
+
+// DurationSeconds is a duration encoded in JSON as a whole number of seconds, like Discord's slowmode.
+type DurationSeconds time.Duration
+
+// Duration returns d as a time.Duration.
+func (d DurationSeconds) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// Seconds returns d as a whole number of seconds.
+func (d DurationSeconds) Seconds() int {
+	return int(time.Duration(d) / time.Second)
+}
+
+func (d DurationSeconds) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(d.Seconds()), 10), nil
+}
+
+func (d *DurationSeconds) UnmarshalJSON(buf []byte) error {
+	if string(buf) == "null" {
+		return nil
+	}
+	seconds, err := strconv.ParseInt(string(buf), 10, 64)
+	if err != nil {
+		return err
+	}
+	*d = DurationSeconds(time.Duration(seconds) * time.Second)
+	return nil
 }
 
 // NsfwChannelFields holds the NSFW indicator field.
@@ -595,8 +661,55 @@ type MediaChannel struct {
 	ForumChannel
 }
 
+// ThreadMemberFlags are the user-thread settings of a thread member, currently only used for notifications.
+//
+// Discord does not document these flags, their values follow what the official client sends.
 type ThreadMemberFlags int
 
+const (
+	// The user has interacted with the thread (sent a message, reacted...)
+	ThreadMemberFlagHasInteracted ThreadMemberFlags = 1 << iota
+
+	// The user is notified of all messages of the thread
+	ThreadMemberFlagAllMessages
+
+	// The user is only notified of the messages mentioning them
+	ThreadMemberFlagOnlyMentions
+
+	// The user is never notified of the thread messages
+	ThreadMemberFlagNoMessages
+)
+
+// Has returns true if all provided flags are set.
+func (f ThreadMemberFlags) Has(flags ...ThreadMemberFlags) bool {
+	return BitFieldHas(f, flags...)
+}
+
+// ThreadNotificationLevel is the notification setting of a thread member.
+type ThreadNotificationLevel int
+
+const (
+	// The thread member did not pick a notification setting, the one of the parent channel applies.
+	ThreadNotificationLevelDefault ThreadNotificationLevel = iota
+	ThreadNotificationLevelAllMessages
+	ThreadNotificationLevelOnlyMentions
+	ThreadNotificationLevelNoMessages
+)
+
+// NotificationLevel returns the notification setting encoded in the flags.
+func (f ThreadMemberFlags) NotificationLevel() ThreadNotificationLevel {
+	switch {
+	case f.Has(ThreadMemberFlagNoMessages):
+		return ThreadNotificationLevelNoMessages
+	case f.Has(ThreadMemberFlagOnlyMentions):
+		return ThreadNotificationLevelOnlyMentions
+	case f.Has(ThreadMemberFlagAllMessages):
+		return ThreadNotificationLevelAllMessages
+	default:
+		return ThreadNotificationLevelDefault
+	}
+}
+
 // ThreadMember represents Discord thread channel member.
 //
 // Reference: https://discord.com/developers/docs/resources/channel#channel-object-channel-types
@@ -623,6 +736,16 @@ type ThreadMember struct {
 	Member *Member `json:"member"`
 }
 
+// CreatedAt returns the time when the thread of this member was created.
+func (m *ThreadMember) CreatedAt() time.Time {
+	return m.ThreadID.Timestamp()
+}
+
+// JoinedAt returns the time when the user last joined the thread.
+func (m *ThreadMember) JoinedAt() time.Time {
+	return m.JoinTimestamp
+}
+
 // ThreadChannel represents the base for thread channels.
 type ThreadChannel struct {
 	ThreadChannelFields
@@ -980,6 +1103,25 @@ var (
 	_ PositionedChannel = (*MediaChannel)(nil)
 )
 
+// sortGuildChannels sorts channels by position then by ID, channels that
+// aren't a PositionedChannel come last, sorted by ID.
+func sortGuildChannels(channels []GuildChannel) []GuildChannel {
+	slices.SortFunc(channels, func(a, b GuildChannel) int {
+		pa, aPositioned := a.(PositionedChannel)
+		pb, bPositioned := b.(PositionedChannel)
+		switch {
+		case aPositioned && !bPositioned:
+			return -1
+		case !aPositioned && bPositioned:
+			return 1
+		case aPositioned && pa.GetPosition() != pb.GetPosition():
+			return cmp.Compare(pa.GetPosition(), pb.GetPosition())
+		}
+		return cmp.Compare(a.GetID(), b.GetID())
+	})
+	return channels
+}
+
 // CategorizedChannel represents a Discord channel that can be placed under a parent category channel within a guild.
 //
 // This interface is used for guild channels that can be organized under a category, such as text channels,
@@ -1028,6 +1170,134 @@ var (
 	_ CategorizedChannel = (*ThreadChannel)(nil)
 )
 
+// ChannelCategoryNode is a category of a channel tree with its channels, see BuildChannelTree.
+type ChannelCategoryNode struct {
+	// Category is the category channel, nil for the node of the uncategorized channels.
+	Category *CategoryChannel
+	// Channels is the channels of the category, in the order the Discord client displays them.
+	Channels []GuildChannel
+}
+
+// BuildChannelTree groups channels under their category in the order the Discord client displays them.
+//
+// The uncategorized channels come first, in a node without Category, followed by the categories sorted by
+// position then ID. Within a node, text channels come before voice and stage channels, each sorted by
+// position then ID. Channels whose category isn't in channels are uncategorized, and channels without
+// a position, like threads, are left out.
+//
+// Usage example:
+//
+//	for _, node := range dwaz.BuildChannelTree(client.GuildChannels(guildID)) {
+//		if node.Category != nil {
+//			fmt.Println(node.Category.Name)
+//		}
+//		for _, channel := range node.Channels {
+//			fmt.Println("  #" + channel.GetName())
+//		}
+//	}
+func BuildChannelTree(channels []GuildChannel) []ChannelCategoryNode {
+	var categories []GuildChannel
+	children := make(map[Snowflake][]GuildChannel)
+	for _, channel := range channels {
+		if _, ok := channel.(PositionedChannel); !ok {
+			continue
+		}
+		if category, ok := channel.(*CategoryChannel); ok {
+			categories = append(categories, category)
+			continue
+		}
+		parentID := Snowflake(0)
+		if categorized, ok := channel.(CategorizedChannel); ok {
+			parentID = categorized.GetParentID()
+		}
+		children[parentID] = append(children[parentID], channel)
+	}
+
+	tree := make([]ChannelCategoryNode, 1, len(categories)+1)
+	for _, category := range sortGuildChannels(categories) {
+		tree = append(tree, ChannelCategoryNode{
+			Category: category.(*CategoryChannel),
+			Channels: sortCategoryChildren(children[category.GetID()]),
+		})
+		delete(children, category.GetID())
+	}
+	// channels of a missing category join the uncategorized ones
+	for _, orphans := range children {
+		tree[0].Channels = append(tree[0].Channels, orphans...)
+	}
+	tree[0].Channels = sortCategoryChildren(tree[0].Channels)
+	if len(tree[0].Channels) == 0 {
+		tree = tree[1:]
+	}
+	return tree
+}
+
+// sortCategoryChildren sorts the channels of a category like the Discord client,
+// text channels first then voice channels, each by position then ID.
+func sortCategoryChildren(channels []GuildChannel) []GuildChannel {
+	sortGuildChannels(channels)
+	slices.SortStableFunc(channels, func(a, b GuildChannel) int {
+		_, aVoice := a.(AudioChannel)
+		_, bVoice := b.(AudioChannel)
+		switch {
+		case !aVoice && bVoice:
+			return -1
+		case aVoice && !bVoice:
+			return 1
+		}
+		return 0
+	})
+	return channels
+}
+
+// MaxChannelNameLength is the maximum length of a channel name, in characters.
+const MaxChannelNameLength = 100
+
+// NormalizeChannelName formats name the way Discord does for text channels: lowercased, runs of
+// whitespace and dashes turned into a single dash, ASCII punctuation removed, leading and trailing
+// dashes trimmed and the result cut to MaxChannelNameLength characters.
+//
+// Letters, digits, underscores and non-ASCII characters like emojis are kept.
+//
+// Usage example:
+//
+//	dwaz.NormalizeChannelName("  Off Topic!! ") // "off-topic"
+func NormalizeChannelName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	dash := false
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case unicode.IsSpace(r) || r == '-':
+			dash = true
+			continue
+		case unicode.IsControl(r):
+			continue
+		case r < utf8.RuneSelf && r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			continue
+		}
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		dash = false
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	normalized := b.String()
+	if utf8.RuneCountInString(normalized) > MaxChannelNameLength {
+		normalized = strings.TrimRight(string([]rune(normalized)[:MaxChannelNameLength]), "-")
+	}
+	return normalized
+}
+
+// ValidateChannelName returns an error if name isn't 1-MaxChannelNameLength characters long.
+func ValidateChannelName(name string) error {
+	if n := utf8.RuneCountInString(name); n < 1 || n > MaxChannelNameLength {
+		return fmt.Errorf("channel name must be 1-%d characters, got %d", MaxChannelNameLength, n)
+	}
+	return nil
+}
+
 // AudioChannel represents a Discord channel that supports voice or audio functionality.
 //
 // This interface is used for guild channels that have voice-related features, such as voice channels
@@ -1127,6 +1397,51 @@ func UnmarshalChannel(buf []byte) (Channel, error) {
 	}
 }
 
+// unmarshalGuildChannel unmarshals a channel, returning an error if it isn't a guild channel.
+func unmarshalGuildChannel(buf []byte) (GuildChannel, error) {
+	channel, err := UnmarshalChannel(buf)
+	if err != nil {
+		return nil, err
+	}
+	guildChannel, ok := channel.(GuildChannel)
+	if !ok {
+		return nil, errors.New("not a guild channel")
+	}
+	return guildChannel, nil
+}
+
+// withoutParent returns a copy of a categorized channel moved out of its category.
+// Channels without a category are returned as is.
+func withoutParent(channel GuildChannel) GuildChannel {
+	switch c := channel.(type) {
+	case *TextChannel:
+		cp := *c
+		cp.ParentID = 0
+		return &cp
+	case *VoiceChannel:
+		cp := *c
+		cp.ParentID = 0
+		return &cp
+	case *AnnouncementChannel:
+		cp := *c
+		cp.ParentID = 0
+		return &cp
+	case *StageVoiceChannel:
+		cp := *c
+		cp.ParentID = 0
+		return &cp
+	case *ForumChannel:
+		cp := *c
+		cp.ParentID = 0
+		return &cp
+	case *MediaChannel:
+		cp := *c
+		cp.ParentID = 0
+		return &cp
+	}
+	return channel
+}
+
 type ResolvedChannel struct {
 	Channel
 	Permissions Permissions `json:"permissions"`
@@ -1246,6 +1561,21 @@ func (r *requester) FetchChannel(channelID Snowflake) result.Result[Channel] {
 	return result.Ok(channel)
 }
 
+// Channel returns a channel from the cache, falling back to FetchChannel on a cache miss.
+//
+// The fetched channel is put in the cache if CacheFlagChannels is enabled.
+// Use GetChannel to look up the cache only.
+func (c *Client) Channel(channelID Snowflake) result.Result[Channel] {
+	if channel := c.GetChannel(channelID); channel.IsPresent() {
+		return result.Ok(channel.Get())
+	}
+	res := c.FetchChannel(channelID)
+	if res.IsOk() && c.Flags().Has(CacheFlagChannels) {
+		c.PutChannel(res.Value())
+	}
+	return res
+}
+
 func (r *requester) modifyChannel(channelID Snowflake, reqBody []byte, reason string) (Channel, error) {
 	res := r.DoRequest(Request{
 		Method: "PATCH",
@@ -1714,9 +2044,18 @@ func (c *FollowedChannel) String() string {
 //
 // Note:
 //   - Only usable for guild channels.
+//   - Returns an error without sending a request if opts.WebhookChannelID is not set.
+//   - A missing permission in the target channel is returned as a *RestError
+//     with Code RestErrorCodeMissingPermissions.
 //
 // Requires the PermissionManageWebhooks permission in the target channel.
+//
+// Reference: https://discord.com/developers/docs/resources/channel#follow-announcement-channel
 func (r *requester) FollowAnnouncementChannel(channelID Snowflake, opts FollowAnnouncementChannelOptions) result.Result[FollowedChannel] {
+	if opts.WebhookChannelID.UnSet() {
+		return result.Err[FollowedChannel](errors.New("FollowAnnouncementChannel: WebhookChannelID must be set to the target channel"))
+	}
+
 	reqBody, _ := json.Marshal(opts)
 	res := r.DoRequest(Request{
 		Method: "POST",
@@ -1795,6 +2134,36 @@ func (r *requester) GroupDMRemoveRecipient(channelID Snowflake, userID Snowflake
 	return result.OkVoid()
 }
 
+// CreateDM opens a DM channel with a user, or returns the existing one.
+//
+// Reference: https://discord.com/developers/docs/resources/user#create-dm
+func (r *requester) CreateDM(userID Snowflake) result.Result[DMChannel] {
+	reqBody, _ := json.Marshal(struct {
+		RecipientID Snowflake `json:"recipient_id"`
+	}{userID})
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/users/@me/channels",
+		Body:   reqBody,
+	})
+	if res.IsErr() {
+		return result.Err[DMChannel](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var channel DMChannel
+	if err := json.NewDecoder(body).Decode(&channel); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/users/@me/channels",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[DMChannel](err)
+	}
+	return result.Ok(channel)
+}
+
 // StartThreadFromMessageOptions contains parameters for starting a thread from a message.
 type StartThreadFromMessageOptions struct {
 	// Name is a 1-100 character channel name
@@ -1823,7 +2192,7 @@ type StartThreadFromMessageOptions struct {
 //   - The id of the created thread will be the same as the id of the source message,
 //     and as such a message can only have a single thread created from it.
 //
-// Requires the PermissionManageWebhooks permission in the target channel.
+// Requires the PermissionCreatePublicThreads permission.
 func (r *requester) StartThreadFromMessage(channelID Snowflake, messageID Snowflake, opts StartThreadFromMessageOptions) result.Result[GuildChannel] {
 	reqBody, _ := json.Marshal(opts)
 	res := r.DoRequest(Request{
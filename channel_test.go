@@ -0,0 +1,528 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestFollowAnnouncementChannelRequiresTarget(t *testing.T) {
+	requests := 0
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		io.WriteString(w, `{"channel_id":"10","webhook_id":"50"}`)
+	})
+
+	if res := r.FollowAnnouncementChannel(10, FollowAnnouncementChannelOptions{}); !res.IsErr() {
+		t.Error("expected an error when WebhookChannelID is not set")
+	}
+	if requests != 0 {
+		t.Fatalf("invalid call sent %d requests, want 0", requests)
+	}
+
+	res := r.FollowAnnouncementChannel(10, FollowAnnouncementChannelOptions{WebhookChannelID: 20})
+	if res.IsErr() {
+		t.Fatalf("FollowAnnouncementChannel: %v", res.Err())
+	}
+	if res.Value().WebhookID != 50 {
+		t.Errorf("WebhookID = %d, want 50", res.Value().WebhookID)
+	}
+}
+
+func TestFollowAnnouncementChannelMissingPermissions(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"code":50013,"message":"Missing Permissions"}`)
+	})
+
+	res := r.FollowAnnouncementChannel(10, FollowAnnouncementChannelOptions{WebhookChannelID: 20})
+	var restErr *RestError
+	if !errors.As(res.Err(), &restErr) || restErr.Code != RestErrorCodeMissingPermissions {
+		t.Errorf("expected a missing permissions RestError, got %v", res.Err())
+	}
+}
+
+func TestChannelDeleteEvictsCache(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	})
+
+	var created ChannelCreateEvent
+	client.OnChannelCreate(func(evt ChannelCreateEvent) { created = evt })
+	client.handlersManagers["CHANNEL_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"10","type":0,"guild_id":"1","name":"general"}`))
+
+	if created.Channel == nil || created.Channel.GetName() != "general" {
+		t.Fatalf("created event = %+v", created)
+	}
+	if !client.GetChannel(10).IsPresent() {
+		t.Fatal("channel was not cached on CHANNEL_CREATE")
+	}
+
+	var deleted ChannelDeleteEvent
+	client.OnChannelDelete(func(evt ChannelDeleteEvent) { deleted = evt })
+	client.handlersManagers["CHANNEL_DELETE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"10","type":0,"guild_id":"1","name":"general"}`))
+
+	if deleted.Channel == nil || deleted.Channel.GetID() != 10 {
+		t.Errorf("deleted event = %+v", deleted)
+	}
+	if client.GetChannel(10).IsPresent() {
+		t.Error("channel is still cached after CHANNEL_DELETE")
+	}
+}
+
+func TestCategoryDeleteReparentsChildren(t *testing.T) {
+	client := newTestClient(t, nil)
+	for _, channel := range []string{
+		`{"id":"5","type":4,"guild_id":"1","name":"category"}`,
+		`{"id":"10","type":0,"guild_id":"1","name":"general","parent_id":"5"}`,
+		`{"id":"11","type":2,"guild_id":"1","name":"voice","parent_id":"5"}`,
+		`{"id":"12","type":0,"guild_id":"1","name":"other"}`,
+	} {
+		client.handlersManagers["CHANNEL_CREATE"].handleEvent(client, false, 0, []byte(channel))
+	}
+	client.handlersManagers["THREAD_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"20","type":11,"guild_id":"1","name":"thread","parent_id":"12"}`))
+
+	client.handlersManagers["CHANNEL_DELETE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"5","type":4,"guild_id":"1","name":"category"}`))
+
+	if client.GetChannel(5).IsPresent() {
+		t.Error("category is still cached")
+	}
+	for _, id := range []Snowflake{10, 11} {
+		channelOpt := client.GetChannel(id)
+		if !channelOpt.IsPresent() {
+			t.Fatalf("child %d was evicted", id)
+		}
+		if parent := channelOpt.Get().(CategorizedChannel).GetParentID(); parent != 0 {
+			t.Errorf("child %d parent = %d, want 0", id, parent)
+		}
+	}
+	if !client.GetChannel(20).IsPresent() {
+		t.Error("thread of another channel was evicted")
+	}
+
+	client.handlersManagers["CHANNEL_DELETE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"12","type":0,"guild_id":"1","name":"other"}`))
+	if client.GetChannel(20).IsPresent() {
+		t.Error("thread is still cached after its parent was deleted")
+	}
+}
+
+func TestThreadDeleteEvictsCache(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	var created ThreadCreateEvent
+	client.OnThreadCreate(func(evt ThreadCreateEvent) { created = evt })
+	client.handlersManagers["THREAD_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"20","type":11,"guild_id":"1","name":"thread","parent_id":"12","newly_created":true}`))
+
+	if !created.NewlyCreated || created.Thread.Name != "thread" {
+		t.Errorf("created event = %+v", created)
+	}
+	if !client.GetChannel(20).IsPresent() {
+		t.Fatal("thread was not cached on THREAD_CREATE")
+	}
+
+	var deleted ThreadDeleteEvent
+	client.OnThreadDelete(func(evt ThreadDeleteEvent) { deleted = evt })
+	client.handlersManagers["THREAD_DELETE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"20","type":11,"guild_id":"1","parent_id":"12"}`))
+
+	if deleted.Thread.Name != "thread" {
+		t.Errorf("deleted event should carry the cached thread, got %+v", deleted.Thread)
+	}
+	if client.GetChannel(20).IsPresent() {
+		t.Error("thread is still cached after THREAD_DELETE")
+	}
+}
+
+func TestChannelUpdateRefreshesCache(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.handlersManagers["CHANNEL_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"10","type":0,"guild_id":"1","name":"general"}`))
+
+	var updated ChannelUpdateEvent
+	client.OnChannelUpdate(func(evt ChannelUpdateEvent) { updated = evt })
+	client.handlersManagers["CHANNEL_UPDATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"10","type":0,"guild_id":"1","name":"chat"}`))
+
+	if updated.OldChannel == nil || updated.OldChannel.GetName() != "general" {
+		t.Errorf("old channel = %+v", updated.OldChannel)
+	}
+	if updated.NewChannel.GetName() != "chat" {
+		t.Errorf("new channel = %+v", updated.NewChannel)
+	}
+	channelOpt := client.GetChannel(10)
+	if !channelOpt.IsPresent() || channelOpt.Get().(GuildChannel).GetName() != "chat" {
+		t.Error("cache was not refreshed on CHANNEL_UPDATE")
+	}
+
+	client.handlersManagers["CHANNEL_UPDATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"11","type":2,"guild_id":"1","name":"voice"}`))
+	if updated.OldChannel != nil {
+		t.Errorf("old channel of an uncached channel = %+v", updated.OldChannel)
+	}
+	if !client.GetChannel(11).IsPresent() {
+		t.Error("uncached channel was not added on CHANNEL_UPDATE")
+	}
+}
+
+func TestThreadMemberFlags(t *testing.T) {
+	flags := ThreadMemberFlagHasInteracted | ThreadMemberFlagOnlyMentions
+
+	if !flags.Has(ThreadMemberFlagHasInteracted, ThreadMemberFlagOnlyMentions) {
+		t.Errorf("expected flags to have HasInteracted and OnlyMentions")
+	}
+	if flags.Has(ThreadMemberFlagAllMessages) {
+		t.Errorf("expected flags to not have AllMessages")
+	}
+	if level := flags.NotificationLevel(); level != ThreadNotificationLevelOnlyMentions {
+		t.Errorf("NotificationLevel() = %d, want OnlyMentions", level)
+	}
+	if level := ThreadMemberFlagHasInteracted.NotificationLevel(); level != ThreadNotificationLevelDefault {
+		t.Errorf("NotificationLevel() = %d, want Default", level)
+	}
+	if ThreadMemberFlagNoMessages != 1<<3 {
+		t.Errorf("unexpected NoMessages value %d", ThreadMemberFlagNoMessages)
+	}
+}
+
+func TestThreadMemberUpdateEvent(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	var got ThreadMemberUpdateEvent
+	client.OnThreadMemberUpdate(func(evt ThreadMemberUpdateEvent) { got = evt })
+	client.handlersManagers["THREAD_MEMBER_UPDATE"].handleEvent(client, false, 2,
+		[]byte(`{"id":"175928847299117063","user_id":"5","guild_id":"1","flags":9,"join_timestamp":"2024-01-02T03:04:05Z"}`))
+
+	if got.GuildID != 1 || got.ShardID != 2 || got.Member.UserID != 5 {
+		t.Fatalf("event = %+v", got)
+	}
+	if got.Member.Flags.NotificationLevel() != ThreadNotificationLevelNoMessages {
+		t.Errorf("unexpected notification level for flags %d", got.Member.Flags)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC); !got.Member.JoinedAt().Equal(want) {
+		t.Errorf("JoinedAt() = %v, want %v", got.Member.JoinedAt(), want)
+	}
+	if want := Snowflake(175928847299117063).Timestamp(); !got.Member.CreatedAt().Equal(want) {
+		t.Errorf("CreatedAt() = %v, want %v", got.Member.CreatedAt(), want)
+	}
+}
+
+func TestClientChannelReadThrough(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Path != "/channels/10" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		io.WriteString(w, `{"id":"10","type":0,"guild_id":"1","name":"general"}`)
+	})
+
+	for range 2 {
+		res := client.Channel(10)
+		if res.IsErr() || res.Value().GetID() != 10 {
+			t.Fatalf("Channel(10) = %+v", res)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected a single request, got %d", requests)
+	}
+}
+
+func TestThreadListSyncReconcilesCache(t *testing.T) {
+	client := newTestClient(t, nil)
+	for _, thread := range []string{
+		`{"id":"20","type":11,"guild_id":"1","name":"a","parent_id":"12"}`,
+		`{"id":"21","type":11,"guild_id":"1","name":"b","parent_id":"12"}`,
+		`{"id":"22","type":11,"guild_id":"1","name":"stale","parent_id":"12"}`,
+		`{"id":"30","type":11,"guild_id":"1","name":"other","parent_id":"13"}`,
+	} {
+		client.handlersManagers["THREAD_CREATE"].handleEvent(client, false, 0, []byte(thread))
+	}
+
+	var synced ThreadListSyncEvent
+	client.OnThreadListSync(func(evt ThreadListSyncEvent) { synced = evt })
+	client.handlersManagers["THREAD_LIST_SYNC"].handleEvent(client, false, 0, []byte(`{
+		"guild_id":"1",
+		"channel_ids":["12"],
+		"threads":[
+			{"id":"20","type":11,"name":"a","parent_id":"12"},
+			{"id":"21","type":11,"name":"b renamed","parent_id":"12"}
+		],
+		"members":[{"id":"20","user_id":"5","flags":2}]
+	}`))
+
+	if synced.GuildID != 1 || len(synced.Threads) != 2 || len(synced.Members) != 1 {
+		t.Fatalf("synced event = %+v", synced)
+	}
+	if client.GetChannel(22).IsPresent() {
+		t.Error("stale thread of a synced channel was not evicted")
+	}
+	if !client.GetChannel(30).IsPresent() {
+		t.Error("thread of a channel outside the sync was evicted")
+	}
+	thread, ok := client.GetChannel(21).Get().(*ThreadChannel)
+	if !ok || thread.Name != "b renamed" || thread.GuildID != 1 {
+		t.Errorf("synced thread = %+v", client.GetChannel(21))
+	}
+}
+
+// channelPayloads holds a representative payload of every channel type, with each field set to a non-zero value.
+var channelPayloads = map[string]string{
+	"category": `{"id":"1","type":4,"guild_id":"100","name":"category","position":2,` +
+		`"permission_overwrites":[{"id":"100","type":0,"allow":"1024","deny":"2048"}],"flags":0}`,
+	"text": `{"id":"2","type":0,"guild_id":"100","name":"general","position":1,"parent_id":"1",` +
+		`"permission_overwrites":[],"last_message_id":"500","rate_limit_per_user":10,"nsfw":true,"topic":"hello"}`,
+	"voice": `{"id":"3","type":2,"guild_id":"100","name":"voice","position":3,"parent_id":"1",` +
+		`"last_message_id":"501","rate_limit_per_user":5,"nsfw":false,"bitrate":64000,"user_limit":10,"rtc_region":"europe"}`,
+	"announcement": `{"id":"4","type":5,"guild_id":"100","name":"news","position":4,"parent_id":"1",` +
+		`"last_message_id":"502","rate_limit_per_user":0,"nsfw":false,"topic":"news"}`,
+	"stage": `{"id":"5","type":13,"guild_id":"100","name":"stage","position":5,"parent_id":"1",` +
+		`"last_message_id":"503","rate_limit_per_user":30,"nsfw":false,"bitrate":64000,"user_limit":0,"rtc_region":"","topic":"talk"}`,
+	"forum": `{"id":"6","type":15,"guild_id":"100","name":"forum","position":6,"parent_id":"1","flags":16,` +
+		`"last_message_id":"504","rate_limit_per_user":60,"nsfw":false,"topic":"guidelines",` +
+		`"available_tags":[{"id":"600","name":"bug","moderated":true,"emoji_id":null,"emoji_name":"🐛"}],` +
+		`"default_reaction_emoji":{"emoji_id":null,"emoji_name":"👍"},"default_sort_order":1,"default_forum_layout":2}`,
+	"media": `{"id":"7","type":16,"guild_id":"100","name":"media","position":7,"parent_id":"1",` +
+		`"last_message_id":"505","rate_limit_per_user":0,"nsfw":true,"topic":"pics","available_tags":[],` +
+		`"default_reaction_emoji":{"emoji_id":"700","emoji_name":null},"default_sort_order":0,"default_forum_layout":0}`,
+	"thread": `{"id":"8","type":11,"guild_id":"100","name":"thread","parent_id":"2","flags":2,` +
+		`"last_message_id":"506","rate_limit_per_user":120,"owner_id":"900",` +
+		`"thread_metadata":{"archived":true,"auto_archive_duration":1440,"archive_timestamp":"2024-01-02T03:04:05Z","locked":true,"invitable":false}}`,
+	"dm":       `{"id":"9","type":1,"last_message_id":"507","recipients":[{"id":"901","username":"bob"}]}`,
+	"group dm": `{"id":"10","type":3,"last_message_id":"508","icon":"abc"}`,
+}
+
+func TestChannelJSONRoundTrip(t *testing.T) {
+	for name, payload := range channelPayloads {
+		t.Run(name, func(t *testing.T) {
+			channel, err := UnmarshalChannel([]byte(payload))
+			if err != nil {
+				t.Fatalf("UnmarshalChannel: %v", err)
+			}
+			encoded, err := json.Marshal(channel)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			decoded, err := UnmarshalChannel(encoded)
+			if err != nil {
+				t.Fatalf("UnmarshalChannel(re-encoded): %v", err)
+			}
+			if reencoded, _ := json.Marshal(decoded); string(reencoded) != string(encoded) {
+				t.Errorf("encoding is not stable:\n  first  %s\n  second %s", encoded, reencoded)
+			}
+
+			// every field of the payload must be re-encoded with the same value
+			var in, out any
+			json.Unmarshal([]byte(payload), &in)
+			json.Unmarshal(encoded, &out)
+			assertJSONSubset(t, "", out, in)
+		})
+	}
+}
+
+// assertJSONSubset fails if a field of want is missing from got or has a different value,
+// zero fields of want may be omitted from got.
+func assertJSONSubset(t *testing.T, path string, got, want any) {
+	t.Helper()
+	switch want := want.(type) {
+	case map[string]any:
+		gotMap, ok := got.(map[string]any)
+		if !ok {
+			t.Errorf("%s = %v after encoding, want an object", path, got)
+			return
+		}
+		for key, value := range want {
+			if gotValue, ok := gotMap[key]; ok {
+				assertJSONSubset(t, path+"."+key, gotValue, value)
+			} else if !isZeroJSON(value) {
+				t.Errorf("%s.%s was dropped on encoding", path, key)
+			}
+		}
+	case []any:
+		gotSlice, ok := got.([]any)
+		if !ok && len(want) > 0 || len(gotSlice) != len(want) {
+			t.Errorf("%s = %v after encoding, want %v", path, got, want)
+			return
+		}
+		for i := range want {
+			assertJSONSubset(t, path+"["+strconv.Itoa(i)+"]", gotSlice[i], want[i])
+		}
+	default:
+		if !reflect.DeepEqual(got, want) && !(isZeroJSON(got) && isZeroJSON(want)) {
+			t.Errorf("%s = %v after encoding, want %v", path, got, want)
+		}
+	}
+}
+
+// isZeroJSON reports whether v is a decoded JSON zero value, which may be omitted on encoding.
+func isZeroJSON(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+func TestChannelRateLimitPerUserIsSeconds(t *testing.T) {
+	channel, err := UnmarshalChannel([]byte(channelPayloads["text"]))
+	if err != nil {
+		t.Fatalf("UnmarshalChannel: %v", err)
+	}
+	messageChannel, ok := channel.(GuildMessageChannel)
+	if !ok {
+		t.Fatalf("%T is not a GuildMessageChannel", channel)
+	}
+	if got := messageChannel.GetRateLimitPerUser(); got != 10*time.Second {
+		t.Errorf("GetRateLimitPerUser() = %v, want 10s", got)
+	}
+}
+
+func TestBuildChannelTree(t *testing.T) {
+	var channels []GuildChannel
+	for _, data := range []string{
+		`{"id":"1","type":4,"guild_id":"100","name":"Voice","position":1}`,
+		`{"id":"2","type":4,"guild_id":"100","name":"Text","position":0}`,
+		`{"id":"10","type":2,"guild_id":"100","name":"lounge","position":0,"parent_id":"2"}`,
+		`{"id":"11","type":0,"guild_id":"100","name":"general","position":1,"parent_id":"2"}`,
+		`{"id":"12","type":5,"guild_id":"100","name":"news","position":0,"parent_id":"2"}`,
+		`{"id":"13","type":2,"guild_id":"100","name":"music","position":0,"parent_id":"1"}`,
+		`{"id":"14","type":13,"guild_id":"100","name":"stage","position":0,"parent_id":"1"}`,
+		`{"id":"20","type":0,"guild_id":"100","name":"rules","position":0}`,
+		`{"id":"21","type":2,"guild_id":"100","name":"afk","position":0}`,
+		`{"id":"22","type":0,"guild_id":"100","name":"orphan","position":5,"parent_id":"99"}`,
+		`{"id":"30","type":11,"guild_id":"100","name":"thread","parent_id":"11"}`,
+	} {
+		channel, err := unmarshalGuildChannel([]byte(data))
+		if err != nil {
+			t.Fatalf("unmarshalGuildChannel(%s): %v", data, err)
+		}
+		channels = append(channels, channel)
+	}
+
+	var got []string
+	for _, node := range BuildChannelTree(channels) {
+		if node.Category != nil {
+			got = append(got, node.Category.Name+":")
+		}
+		for _, channel := range node.Channels {
+			got = append(got, channel.GetName())
+		}
+	}
+	want := []string{
+		"rules", "orphan", "afk",
+		"Text:", "news", "general", "lounge",
+		"Voice:", "music", "stage",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("BuildChannelTree() = %v, want %v", got, want)
+	}
+
+	if tree := BuildChannelTree(channels[:2]); len(tree) != 2 || tree[0].Category.Name != "Text" {
+		t.Errorf("expected no uncategorized node without uncategorized channels, got %+v", tree)
+	}
+}
+
+func TestNormalizeChannelName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"General", "general"},
+		{"  Off Topic!! ", "off-topic"},
+		{"a  -- b", "a-b"},
+		{"--rules--", "rules"},
+		{"snake_case", "snake_case"},
+		{"Ünïcödé Ελληνικά", "ünïcödé-ελληνικά"},
+		{"🎮 gaming", "🎮-gaming"},
+		{"what's up?", "whats-up"},
+		{"!!!", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeChannelName(tt.name); got != tt.want {
+			t.Errorf("NormalizeChannelName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	long := NormalizeChannelName(strings.Repeat("é", 99) + " x")
+	if n := utf8.RuneCountInString(long); n != 99 || strings.HasSuffix(long, "-") {
+		t.Errorf("expected the name to be cut to 99 characters without a trailing dash, got %d: %q", n, long)
+	}
+	if n := utf8.RuneCountInString(NormalizeChannelName(strings.Repeat("ab", 80))); n != MaxChannelNameLength {
+		t.Errorf("expected the name to be cut to %d characters, got %d", MaxChannelNameLength, n)
+	}
+}
+
+func TestValidateChannelName(t *testing.T) {
+	if err := ValidateChannelName(""); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+	if err := ValidateChannelName(strings.Repeat("é", MaxChannelNameLength)); err != nil {
+		t.Errorf("unexpected error for a %d characters name: %v", MaxChannelNameLength, err)
+	}
+	if err := ValidateChannelName(strings.Repeat("a", MaxChannelNameLength+1)); err == nil {
+		t.Error("expected an error for an over-length name")
+	}
+
+	opts := CreateChannelOptions{Name: "!!!", NormalizeName: true}
+	if err := opts.Validate(); err == nil {
+		t.Error("expected an error for a name normalized to nothing")
+	}
+	opts.Type = ChannelTypeGuildVoice
+	if err := opts.Validate(); err != nil {
+		t.Errorf("voice channel names aren't normalized, got %v", err)
+	}
+}
+
+func TestCreateChannelNormalizesName(t *testing.T) {
+	var sent string
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		buf, _ := io.ReadAll(req.Body)
+		json.Unmarshal(buf, &body)
+		sent = body.Name
+		io.WriteString(w, `{"id":"10","type":0,"guild_id":"1","name":"`+body.Name+`"}`)
+	})
+
+	if res := r.CreateChannel(1, CreateChannelOptions{Name: "Bot Logs", NormalizeName: true}); res.IsErr() {
+		t.Fatalf("CreateChannel failed: %v", res.Err())
+	}
+	if sent != "bot-logs" {
+		t.Errorf("sent name %q, want bot-logs", sent)
+	}
+}
@@ -15,10 +15,15 @@ package dwaz
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"maps"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/marouanesouiri/stdx/xlog"
@@ -60,6 +65,13 @@ type Client struct {
 	*dispatcher                                    // event dispatcher
 	requesterConfig      RequesterConfig           // configuration for the HTTP requester
 	handlerExecutionMode HandlerExecutionMode      // mode for executing event handlers
+	handlerTimeout       time.Duration             // max duration a handler may run before being reported (0 disables it)
+	autoDeferAfter       time.Duration             // delay after which unanswered interactions are deferred (0 disables it)
+	imageConfig          ImageConfig               // default format and size of the image URL helpers of the client
+	cacheOptions         []InMemoryCacheOption     // options of the default cache manager
+	chunkGuildsOnStartup bool                      // whether to request all members of each guild when it becomes available
+	chunkMaxMembers      int                       // guilds with more members are not chunked (0 means no limit)
+	selfID               atomic.Uint64             // ID of the bot user, set on READY
 }
 
 // clientOption defines a function used to configure Client during creation.
@@ -127,8 +139,64 @@ func WithCacheManager(cacheManager CacheManager) clientOption {
 	}
 }
 
+// WithMessageCacheSize bounds the messages cache of the default cache manager to size messages,
+// evicting the least recently used ones first.
+//
+// Replaces WithMessageCacheTTL, and has no effect with WithCacheManager.
+//
+// Usage:
+//
+//	dwaz.New(..., dwaz.WithMessageCacheSize(10_000))
+//
+// Default is unbounded.
+func WithMessageCacheSize(size int) clientOption {
+	return func(c *Client) {
+		c.cacheOptions = append(c.cacheOptions, WithMessageCacheStore(NewLRUCacheStore[Message](size)))
+	}
+}
+
+// WithMessageCacheTTL makes messages of the default cache manager expire ttl after being cached.
+//
+// Replaces WithMessageCacheSize, and has no effect with WithCacheManager.
+//
+// Default is no expiry.
+func WithMessageCacheTTL(ttl time.Duration) clientOption {
+	return func(c *Client) {
+		c.cacheOptions = append(c.cacheOptions, WithMessageCacheStore(NewTTLCacheStore[Message](ttl, nil)))
+	}
+}
+
+// WithUserCacheSize bounds the users cache of the default cache manager to size users,
+// evicting the least recently used ones first.
+//
+// Replaces WithUserCacheTTL, and has no effect with WithCacheManager.
+//
+// Default is unbounded.
+func WithUserCacheSize(size int) clientOption {
+	return func(c *Client) {
+		c.cacheOptions = append(c.cacheOptions, WithUserCacheStore(NewLRUCacheStore[User](size)))
+	}
+}
+
+// WithUserCacheTTL makes users of the default cache manager expire ttl after being cached.
+//
+// Replaces WithUserCacheSize, and has no effect with WithCacheManager.
+//
+// Usage:
+//
+//	dwaz.New(..., dwaz.WithUserCacheTTL(time.Hour))
+//
+// Default is no expiry.
+func WithUserCacheTTL(ttl time.Duration) clientOption {
+	return func(c *Client) {
+		c.cacheOptions = append(c.cacheOptions, WithUserCacheStore(NewTTLCacheStore[User](ttl, nil)))
+	}
+}
+
 // WithRequesterConfig sets the configuration for the HTTP requester.
-// Use this to configure a proxy URL or custom HTTP client.
+// Use this to configure a proxy URL, a custom HTTP client or the retry policy.
+//
+// Start from DefaultRequesterConfig, zero fields get their default value except MaxRetries.
 func WithRequesterConfig(config RequesterConfig) clientOption {
 	return func(c *Client) {
 		// Preserve token if already set via WithToken, unless config has it
@@ -184,6 +252,27 @@ func WithShardsIdentifyRateLimiter(rateLimiter ShardsIdentifyRateLimiter) client
 	}
 }
 
+// WithShardsSessionStore sets the ShardsSessionStore used to persist the Gateway
+// session state of shards, so they can RESUME after a process restart.
+//
+// Usage:
+//
+//	y := dwaz.New(dwaz.WithShardsSessionStore(myRedisSessionStore))
+//
+// Notes:
+//   - Defaults to an InMemorySessionStore, which doesn't persist across restarts.
+//   - Discord doesn't replay GUILD_CREATE on RESUME, so after a restart the cache starts empty
+//     and only fills up from later events or REST fetches.
+//   - Logs fatal and exits if the provided store is nil.
+func WithShardsSessionStore(store ShardsSessionStore) clientOption {
+	if store == nil {
+		log.Fatal("WithShardsSessionStore: store must not be nil")
+	}
+	return func(c *Client) {
+		c.shardManagerConfig.SessionStore = store
+	}
+}
+
 // WithIntents sets Gateway intents for the client shards.
 //
 // Usage:
@@ -216,6 +305,115 @@ func WithHandlerExecutionMode(mode HandlerExecutionMode) clientOption {
 	}
 }
 
+// WithHandlerTimeout sets the maximum duration an event handler may run.
+//
+// When a handler exceeds the timeout a warning is logged. In HandlerExecutionSync mode
+// the dispatch goroutine stops waiting for it and moves on to the next handler, so a
+// blocked handler can no longer stall the other handlers of the event.
+//
+// Note:
+//   - The timeout only reports handlers, it doesn't cancel them. Handlers don't receive a context,
+//     a handler that never returns keeps its goroutine. Bound the blocking calls of handlers with
+//     their own context instead, e.g. with the Ctx variants of the REST methods.
+//
+// Usage:
+//
+//	dwaz.New(..., dwaz.WithHandlerTimeout(5*time.Second))
+//
+// Default is 0 (no timeout).
+func WithHandlerTimeout(timeout time.Duration) clientOption {
+	return func(c *Client) {
+		c.handlerTimeout = timeout
+	}
+}
+
+// WithAutoDeferAfter enables automatically deferring interactions that weren't responded to in time.
+//
+// Discord fails an interaction that isn't acknowledged within 3 seconds. When a handler hasn't
+// responded after the given delay, a deferred response is sent on its behalf: a loading state for
+// commands and modal submits, and a deferred update for components. The handler can then follow
+// up with EditOriginal or FollowUp. Autocomplete interactions are never deferred.
+//
+// Note:
+//   - Once the deferral is sent, Respond and the other initial responses of the handler return
+//     ErrInteractionAlreadyAcknowledged, check Interaction.Acknowledged when a handler can be slow.
+//   - The deferral stays scheduled until a response goes through, a failed Respond doesn't cancel it.
+//
+// Usage:
+//
+//	dwaz.New(..., dwaz.WithAutoDeferAfter(2500*time.Millisecond))
+//
+// Default is 0 (disabled).
+func WithAutoDeferAfter(delay time.Duration) clientOption {
+	return func(c *Client) {
+		c.autoDeferAfter = delay
+	}
+}
+
+// WithDefaultImageFormat sets the image format used by the image URL helpers of the client,
+// like Client.UserAvatarURL and Client.GuildIconURL.
+//
+// Formats not supported by an asset fall back to its first supported format, usually PNG.
+//
+// Usage:
+//
+//	dwaz.New(..., dwaz.WithDefaultImageFormat(dwaz.ImageFormatWebP))
+//
+// Default is ImageFormatDefault (GIF for animated assets, otherwise PNG).
+func WithDefaultImageFormat(format ImageFormat) clientOption {
+	return func(c *Client) {
+		c.imageConfig.Format = format
+	}
+}
+
+// WithDefaultImageSize sets the image size used by the image URL helpers of the client,
+// like Client.UserAvatarURL and Client.GuildIconURL.
+//
+// Usage:
+//
+//	dwaz.New(..., dwaz.WithDefaultImageSize(dwaz.ImageSize256))
+//
+// Default is ImageSizeDefault (the size chosen by Discord).
+func WithDefaultImageSize(size ImageSize) clientOption {
+	return func(c *Client) {
+		c.imageConfig.Size = size
+	}
+}
+
+// WithChunkGuildsOnStartup enables requesting all the members of each guild when it becomes available.
+//
+// The received members are put in the cache, which fills the member cache of the bot on boot.
+// Requests go through the send rate limit of the guild's shard.
+//
+// Usage:
+//
+//	dwaz.New(..., dwaz.WithChunkGuildsOnStartup(true))
+//
+// Notes:
+//   - Requires the GatewayIntentGuildMembers intent, guilds are not chunked without it.
+//   - Use WithChunkGuildsMaxMembers to skip large guilds.
+//
+// Default is false.
+func WithChunkGuildsOnStartup(enabled bool) clientOption {
+	return func(c *Client) {
+		c.chunkGuildsOnStartup = enabled
+	}
+}
+
+// WithChunkGuildsMaxMembers sets the member count above which guilds are not chunked
+// by WithChunkGuildsOnStartup, to limit the memory used by the member cache.
+//
+// Usage:
+//
+//	dwaz.New(..., dwaz.WithChunkGuildsOnStartup(true), dwaz.WithChunkGuildsMaxMembers(10000))
+//
+// Default is 0 (no limit).
+func WithChunkGuildsMaxMembers(maxMembers int) clientOption {
+	return func(c *Client) {
+		c.chunkMaxMembers = maxMembers
+	}
+}
+
 // WithCompression enables or disables zlib-stream compression for Gateway connections.
 //
 // When enabled (default), Gateway messages are compressed, reducing bandwidth by 60-80%.
@@ -270,12 +468,11 @@ func New(ctx context.Context, options ...clientOption) *Client {
 	}
 
 	client := &Client{
-		ctx:    ctx,
-		Logger: xlog.NewTextLogger(os.Stdout, xlog.LogLevelInfoLevel),
-		intents: GatewayIntentGuilds |
-			GatewayIntentGuildMessages |
-			GatewayIntentGuildMembers,
-		useCompression: true,
+		ctx:             ctx,
+		Logger:          xlog.NewTextLogger(os.Stdout, xlog.LogLevelInfoLevel),
+		intents:         GatewayIntentsDefault,
+		useCompression:  true,
+		requesterConfig: DefaultRequesterConfig(),
 	}
 
 	for _, option := range options {
@@ -287,10 +484,17 @@ func New(ctx context.Context, options ...clientOption) *Client {
 	}
 
 	client.requester = newRequester(client.requesterConfig, client.Logger)
-	client.CacheManager = NewInMemoryCacheManager(
-		CacheFlagGuilds | CacheFlagMembers | CacheFlagChannels | CacheFlagRoles | CacheFlagUsers | CacheFlagVoiceStates,
-	)
+	if client.CacheManager == nil {
+		client.CacheManager = NewInMemoryCacheManager(
+			CacheFlagGuilds|CacheFlagMembers|CacheFlagChannels|CacheFlagRoles|CacheFlagUsers|CacheFlagVoiceStates,
+			client.cacheOptions...,
+		)
+	}
 	client.dispatcher = newDispatcher(client.Logger, client, client.handlerExecutionMode)
+
+	if client.chunkGuildsOnStartup && client.intents&GatewayIntentGuildMembers == 0 {
+		client.Logger.Warn("ChunkGuildsOnStartup is enabled without the GatewayIntentGuildMembers intent, guilds will not be chunked")
+	}
 	return client
 }
 
@@ -435,3 +639,76 @@ func (c *Client) Shutdown() {
 		c.shardManager = nil
 	}
 }
+
+// SelfID returns the ID of the bot user, 0 until the first READY event is received.
+func (c *Client) SelfID() Snowflake {
+	return Snowflake(c.selfID.Load())
+}
+
+// VerifyIntents cross-references the registered event handlers with the configured intents,
+// and returns a warning for every handler that will never run, or will run with partial data.
+//
+// Call it after registering all handlers, e.g:
+//
+//	for _, w := range client.VerifyIntents() {
+//		log.Println(w)
+//	}
+func (c *Client) VerifyIntents() []IntentWarning {
+	var warnings []IntentWarning
+	for _, event := range slices.Sorted(maps.Keys(c.registeredEvents)) {
+		required := RequiredIntentsFor(event)
+		if required != 0 && c.intents&required == 0 {
+			warnings = append(warnings, IntentWarning{
+				Event:   event,
+				Missing: required,
+				Reason:  "the event is never sent without the required intents",
+			})
+			continue
+		}
+		if (event == "MESSAGE_CREATE" || event == "MESSAGE_UPDATE") && !c.intents.Has(GatewayIntentMessageContent) {
+			warnings = append(warnings, IntentWarning{
+				Event:   event,
+				Missing: GatewayIntentMessageContent,
+				Reason:  "content, embeds, attachments and components are empty for messages not sent in DMs or mentioning the bot",
+			})
+		}
+	}
+	return warnings
+}
+
+// RequestGuildMembers asks Discord to send the members of a guild as GuildMembersChunkEvent events,
+// on the shard the guild belongs to.
+//
+// Received members are put in the cache if CacheFlagMembers is enabled.
+//
+// Returns an error if the guild's shard is not managed by this client.
+func (c *Client) RequestGuildMembers(opts RequestGuildMembersOptions) error {
+	if c.shardManager == nil {
+		return errors.New("RequestGuildMembers: client is not started")
+	}
+	shard, ok := c.shardManager.ShardForGuild(opts.GuildID)
+	if !ok {
+		return fmt.Errorf("RequestGuildMembers: the shard of guild %s is not managed by this client", opts.GuildID)
+	}
+	return shard.RequestGuildMembers(opts)
+}
+
+// chunkGuild requests all the members of a guild which became available, see WithChunkGuildsOnStartup.
+func (c *Client) chunkGuild(guild GatewayGuild) {
+	if guild.Unavailable || c.intents&GatewayIntentGuildMembers == 0 {
+		return
+	}
+	if c.chunkMaxMembers > 0 && guild.MemberCount > c.chunkMaxMembers {
+		c.Logger.WithFields(map[string]any{
+			"guild_id":     guild.ID,
+			"member_count": guild.MemberCount,
+		}).Debug("skipping guild chunking, too many members")
+		return
+	}
+	if err := c.RequestGuildMembers(RequestGuildMembersOptions{GuildID: guild.ID}); err != nil {
+		c.Logger.WithFields(map[string]any{
+			"guild_id": guild.ID,
+			"error":    err.Error(),
+		}).Warn("failed requesting guild members")
+	}
+}
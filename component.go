@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 )
 
@@ -25,6 +26,9 @@ const (
 	// ActionRowMaxComponents is the maximum number of interactive components in an ActionRow.
 	ActionRowMaxComponents = 5
 
+	// MessageMaxActionRows is the maximum number of ActionRows in a message without components V2.
+	MessageMaxActionRows = 5
+
 	// StringSelectMenuMaxOptions is the maximum number of options in a StringSelectMenu.
 	StringSelectMenuMaxOptions = 25
 
@@ -257,7 +261,8 @@ type InteractiveComponentFields struct {
 	//   - Max 100 characters.
 	//   - Must be unique among components in the same message.
 	//   - Used to maintain state or pass data when a user interacts with the component.
-	CustomID string `json:"custom_id"`
+	//   - Omitted when empty, as link and premium buttons must not have one.
+	CustomID string `json:"custom_id,omitempty"`
 }
 
 func (c *InteractiveComponentFields) GetCustomID() string {
@@ -552,6 +557,9 @@ const (
 
 	// TextInputStyleLong represents a multi-line text input.
 	TextInputStyleLong
+
+	// TextInputStyleParagraph is the name Discord uses for TextInputStyleLong.
+	TextInputStyleParagraph = TextInputStyleLong
 )
 
 // Is returns true if the text input style matches the provided one.
@@ -1317,6 +1325,7 @@ func (c *LabelComponent) MarshalJSON() ([]byte, error) {
 	return json.Marshal((*NoMethod)(c))
 }
 
+// Helper func to Unmarshal any component type to a Component interface.
 func UnmarshalComponent(buf []byte) (Component, error) {
 	var meta struct {
 		Type ComponentType `json:"type"`
@@ -1379,6 +1388,80 @@ func UnmarshalComponent(buf []byte) (Component, error) {
 	}
 }
 
+// DisableComponents returns a copy of components with every button and select menu disabled,
+// including the ones nested in sections and containers.
+//
+// The given components are not modified, labels, URLs and other fields are preserved.
+//
+// Usage example:
+//
+//	client.EditMessage(msg.ChannelID, msg.ID, dwaz.EditMessageOptions{
+//		Components: optional.Some(dwaz.DisableComponents(msg.Components)),
+//	})
+func DisableComponents(components []LayoutComponent) []LayoutComponent {
+	if components == nil {
+		return nil
+	}
+	disabled := make([]LayoutComponent, len(components))
+	for i, component := range components {
+		disabled[i] = disableComponent(component)
+	}
+	return disabled
+}
+
+// disableComponent returns a disabled copy of an interactive component,
+// or a copy of a layout component with its children disabled.
+func disableComponent(component Component) Component {
+	switch c := component.(type) {
+	case *ActionRowComponent:
+		row := *c
+		row.Components = make([]InteractiveComponent, len(c.Components))
+		for i, child := range c.Components {
+			row.Components[i] = disableComponent(child).(InteractiveComponent)
+		}
+		return &row
+	case *SectionComponent:
+		section := *c
+		if c.Accessory != nil {
+			section.Accessory = disableComponent(c.Accessory)
+		}
+		return &section
+	case *ContainerComponent:
+		container := *c
+		container.Components = make([]ContainerSubComponent, len(c.Components))
+		for i, child := range c.Components {
+			container.Components[i] = disableComponent(child)
+		}
+		return &container
+	case *ButtonComponent:
+		button := *c
+		button.Disabled = true
+		return &button
+	case *StringSelectMenuComponent:
+		menu := *c
+		menu.Disabled = true
+		return &menu
+	case *UserSelectMenuComponent:
+		menu := *c
+		menu.Disabled = true
+		return &menu
+	case *RoleSelectMenuComponent:
+		menu := *c
+		menu.Disabled = true
+		return &menu
+	case *MentionableSelectMenuComponent:
+		menu := *c
+		menu.Disabled = true
+		return &menu
+	case *ChannelSelectMenuComponent:
+		menu := *c
+		menu.Disabled = true
+		return &menu
+	default:
+		return component
+	}
+}
+
 /////////////
 
 // ButtonBuilder helps build a ButtonComponent with chainable methods.
@@ -2312,3 +2395,131 @@ func (b *LabelBuilder) Reset() {
 func (b *LabelBuilder) Build() *LabelComponent {
 	return &b.label
 }
+
+// ActionRowsBuilder builds the action rows of a message with chainable methods.
+//
+// Components are added to the current row, Row starts a new one. Select menus are always
+// placed alone in their own row. Limits are checked by Build.
+//
+// Usage example:
+//
+//	components, err := dwaz.NewActionRow().
+//		Button(dwaz.ButtonStyleSuccess, "Accept", "accept").
+//		Button(dwaz.ButtonStyleDanger, "Decline", "decline").
+//		LinkButton("Rules", "https://example.com/rules").
+//		StringSelect("color", dwaz.SelectOptionStructure{Label: "Red", Value: "red"}).
+//		Build()
+type ActionRowsBuilder struct {
+	rows [][]InteractiveComponent
+	// selectRow is true when the last row holds a select menu.
+	selectRow bool
+}
+
+// NewActionRow creates a new ActionRowsBuilder starting with an empty row.
+func NewActionRow() *ActionRowsBuilder {
+	return &ActionRowsBuilder{rows: [][]InteractiveComponent{nil}}
+}
+
+// Row starts a new row, components added after it go to the new row.
+func (b *ActionRowsBuilder) Row() *ActionRowsBuilder {
+	if len(b.rows[len(b.rows)-1]) > 0 {
+		b.rows = append(b.rows, nil)
+	}
+	b.selectRow = false
+	return b
+}
+
+// Component adds a component to the current row.
+func (b *ActionRowsBuilder) Component(component InteractiveComponent) *ActionRowsBuilder {
+	if b.selectRow {
+		b.Row()
+	}
+	last := len(b.rows) - 1
+	b.rows[last] = append(b.rows[last], component)
+	return b
+}
+
+// Button adds a button with the given style to the current row, use LinkButton for link buttons.
+func (b *ActionRowsBuilder) Button(style ButtonStyle, label, customID string) *ActionRowsBuilder {
+	return b.Component(NewButtonBuilder().SetStyle(style).SetLabel(label).SetCustomID(customID).Build())
+}
+
+// LinkButton adds a button opening url to the current row.
+func (b *ActionRowsBuilder) LinkButton(label, url string) *ActionRowsBuilder {
+	return b.Component(NewButtonBuilder().SetStyle(ButtonStyleLink).SetLabel(label).SetURL(url).Build())
+}
+
+// selectMenu adds a select menu alone in a new row.
+func (b *ActionRowsBuilder) selectMenu(menu InteractiveComponent) *ActionRowsBuilder {
+	b.Row().Component(menu)
+	b.selectRow = true
+	return b
+}
+
+// StringSelect adds a string select menu with the given options in its own row.
+func (b *ActionRowsBuilder) StringSelect(customID string, options ...SelectOptionStructure) *ActionRowsBuilder {
+	menu := &StringSelectMenuComponent{Options: options}
+	menu.Type = ComponentTypeStringSelect
+	menu.CustomID = customID
+	return b.selectMenu(menu)
+}
+
+// UserSelect adds a user select menu in its own row.
+func (b *ActionRowsBuilder) UserSelect(customID string) *ActionRowsBuilder {
+	menu := &UserSelectMenuComponent{}
+	menu.Type = ComponentTypeUserSelect
+	menu.CustomID = customID
+	return b.selectMenu(menu)
+}
+
+// RoleSelect adds a role select menu in its own row.
+func (b *ActionRowsBuilder) RoleSelect(customID string) *ActionRowsBuilder {
+	menu := &RoleSelectMenuComponent{}
+	menu.Type = ComponentTypeRoleSelect
+	menu.CustomID = customID
+	return b.selectMenu(menu)
+}
+
+// MentionableSelect adds a mentionable (users and roles) select menu in its own row.
+func (b *ActionRowsBuilder) MentionableSelect(customID string) *ActionRowsBuilder {
+	menu := &MentionableSelectMenuComponent{}
+	menu.Type = ComponentTypeMentionableSelect
+	menu.CustomID = customID
+	return b.selectMenu(menu)
+}
+
+// ChannelSelect adds a channel select menu in its own row, restricted to channelTypes if any are given.
+func (b *ActionRowsBuilder) ChannelSelect(customID string, channelTypes ...ChannelType) *ActionRowsBuilder {
+	menu := &ChannelSelectMenuComponent{ChannelTypes: channelTypes}
+	menu.Type = ComponentTypeChannelSelect
+	menu.CustomID = customID
+	return b.selectMenu(menu)
+}
+
+// Build returns the action rows, ready for CreateMessageOptions.Components or an interaction response.
+//
+// Returns an error if there are more than MessageMaxActionRows rows, a row has more than
+// ActionRowMaxComponents components, or a select menu has more than StringSelectMenuMaxOptions options.
+func (b *ActionRowsBuilder) Build() ([]LayoutComponent, error) {
+	rows := b.rows
+	if len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	if len(rows) > MessageMaxActionRows {
+		return nil, fmt.Errorf("a message can contain a maximum of %d action rows, got %d", MessageMaxActionRows, len(rows))
+	}
+
+	components := make([]LayoutComponent, 0, len(rows))
+	for i, row := range rows {
+		if len(row) > ActionRowMaxComponents {
+			return nil, fmt.Errorf("action row %d can contain a maximum of %d components, got %d", i, ActionRowMaxComponents, len(row))
+		}
+		if menu, ok := row[0].(*StringSelectMenuComponent); ok && len(menu.Options) > StringSelectMenuMaxOptions {
+			return nil, fmt.Errorf("select menu %q can contain a maximum of %d options, got %d", menu.CustomID, StringSelectMenuMaxOptions, len(menu.Options))
+		}
+		actionRow := &ActionRowComponent{Components: row}
+		actionRow.Type = ComponentTypeActionRow
+		components = append(components, actionRow)
+	}
+	return components, nil
+}
@@ -0,0 +1,166 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+func TestUnmarshalMessageWithButtonRow(t *testing.T) {
+	payload := `{
+		"id": "20",
+		"channel_id": "10",
+		"components": [{
+			"type": 1,
+			"id": 1,
+			"components": [
+				{"type": 2, "id": 2, "style": 1, "label": "Yes", "custom_id": "vote_yes"},
+				{"type": 2, "id": 3, "style": 5, "label": "Docs", "url": "https://example.com", "disabled": true}
+			]
+		}]
+	}`
+
+	var msg Message
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(msg.Components) != 1 {
+		t.Fatalf("expected 1 top level component, got %d", len(msg.Components))
+	}
+
+	row, ok := msg.Components[0].(*ActionRowComponent)
+	if !ok {
+		t.Fatalf("expected *ActionRowComponent, got %T", msg.Components[0])
+	}
+	if len(row.Components) != 2 {
+		t.Fatalf("expected 2 buttons, got %d", len(row.Components))
+	}
+
+	yes, ok := row.Components[0].(*ButtonComponent)
+	if !ok {
+		t.Fatalf("expected *ButtonComponent, got %T", row.Components[0])
+	}
+	if yes.CustomID != "vote_yes" || yes.Label != "Yes" || yes.Style != ButtonStylePrimary || yes.Disabled {
+		t.Errorf("unexpected first button %+v", yes)
+	}
+
+	docs := row.Components[1].(*ButtonComponent)
+	if docs.URL != "https://example.com" || !docs.Disabled {
+		t.Errorf("unexpected second button %+v", docs)
+	}
+}
+
+func TestUnmarshalComponentUnknownType(t *testing.T) {
+	if _, err := UnmarshalComponent([]byte(`{"type": 999}`)); err == nil {
+		t.Error("expected an error for an unknown component type")
+	}
+}
+
+func TestDisableComponents(t *testing.T) {
+	yes := &ButtonComponent{Style: ButtonStylePrimary, Label: "Yes"}
+	yes.CustomID = "vote_yes"
+	docs := &ButtonComponent{Style: ButtonStyleLink, Label: "Docs", URL: "https://example.com"}
+	menu := &StringSelectMenuComponent{}
+	menu.CustomID = "pick"
+
+	original := []LayoutComponent{
+		&ActionRowComponent{Components: []InteractiveComponent{yes, docs}},
+		&ActionRowComponent{Components: []InteractiveComponent{menu}},
+		&ContainerComponent{Components: []ContainerSubComponent{
+			&ActionRowComponent{Components: []InteractiveComponent{yes}},
+		}},
+	}
+
+	disabled := DisableComponents(original)
+	if len(disabled) != 3 {
+		t.Fatalf("expected 3 components, got %d", len(disabled))
+	}
+
+	row := disabled[0].(*ActionRowComponent)
+	gotYes := row.Components[0].(*ButtonComponent)
+	gotDocs := row.Components[1].(*ButtonComponent)
+	if !gotYes.Disabled || gotYes.Label != "Yes" || gotYes.CustomID != "vote_yes" {
+		t.Errorf("unexpected first button %+v", gotYes)
+	}
+	if !gotDocs.Disabled || gotDocs.URL != "https://example.com" || gotDocs.Label != "Docs" {
+		t.Errorf("unexpected link button %+v", gotDocs)
+	}
+
+	gotMenu := disabled[1].(*ActionRowComponent).Components[0].(*StringSelectMenuComponent)
+	if !gotMenu.Disabled || gotMenu.CustomID != "pick" {
+		t.Errorf("unexpected select menu %+v", gotMenu)
+	}
+
+	nested := disabled[2].(*ContainerComponent).Components[0].(*ActionRowComponent).Components[0].(*ButtonComponent)
+	if !nested.Disabled {
+		t.Error("expected the button inside the container to be disabled")
+	}
+
+	if yes.Disabled || docs.Disabled || menu.Disabled {
+		t.Error("DisableComponents must not modify the original components")
+	}
+}
+
+func TestActionRowsBuilderJSON(t *testing.T) {
+	components, err := NewActionRow().
+		Button(ButtonStyleSuccess, "Accept", "accept").
+		LinkButton("Rules", "https://example.com/rules").
+		StringSelect("color", SelectOptionStructure{Label: "Red", Value: "red"}).
+		Button(ButtonStyleDanger, "Cancel", "cancel").
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	data, err := json.Marshal(components)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `[` +
+		`{"type":1,"components":[{"type":2,"custom_id":"accept","style":3,"label":"Accept"},{"type":2,"style":5,"label":"Rules","url":"https://example.com/rules"}]},` +
+		`{"type":1,"components":[{"type":3,"custom_id":"color","options":[{"label":"Red","value":"red"}]}]},` +
+		`{"type":1,"components":[{"type":2,"custom_id":"cancel","style":4,"label":"Cancel"}]}` +
+		`]`
+	if string(data) != want {
+		t.Errorf("json =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestActionRowsBuilderLimits(t *testing.T) {
+	row := NewActionRow()
+	for i := range ActionRowMaxComponents + 1 {
+		row.Button(ButtonStylePrimary, "Button", strconv.Itoa(i))
+	}
+	if _, err := row.Build(); err == nil {
+		t.Error("expected an error for a row with too many components")
+	}
+
+	rows := NewActionRow()
+	for range MessageMaxActionRows + 1 {
+		rows.UserSelect("users")
+	}
+	if _, err := rows.Build(); err == nil {
+		t.Error("expected an error for too many rows")
+	}
+
+	full := NewActionRow()
+	for range MessageMaxActionRows {
+		full.Row().RoleSelect("roles")
+	}
+	if components, err := full.Build(); err != nil || len(components) != MessageMaxActionRows {
+		t.Errorf("Build() = %d rows, %v; want %d rows", len(components), err, MessageMaxActionRows)
+	}
+}
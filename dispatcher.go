@@ -14,7 +14,12 @@
 package dwaz
 
 import (
+	"context"
+	"fmt"
+	"maps"
 	"runtime/debug"
+	"sync"
+	"time"
 
 	"github.com/marouanesouiri/stdx/xlog"
 )
@@ -60,6 +65,15 @@ type dispatcher struct {
 	client               *Client
 	handlersManagers     map[string]eventhandlersManager
 	handlerExecutionMode HandlerExecutionMode
+	registeredEvents     map[string]struct{} // events with at least one user registered handler
+
+	// cacheUpdateLocks serializes the read-modify-write updates of cached entities
+	// done by concurrently dispatched events, indexed by entity ID.
+	cacheUpdateLocks [64]sync.Mutex
+
+	unhandledMu       sync.Mutex
+	unhandledCounts   map[string]int // dispatches of events without a handlers manager, by name
+	unhandledHandlers []func(UnhandledEvent)
 }
 
 // newDispatcher creates a new dispatcher instance.
@@ -74,15 +88,43 @@ func newDispatcher(logger xlog.Logger, client *Client, mode HandlerExecutionMode
 		client:               client,
 		handlerExecutionMode: mode,
 		handlersManagers:     make(map[string]eventhandlersManager, 20),
+		registeredEvents:     make(map[string]struct{}),
+		unhandledCounts:      make(map[string]int),
 	}
 
 	// Register some necessary events for caching
 	d.handlersManagers["READY"] = &readyHandlers{logger: logger}
 	d.handlersManagers["GUILD_CREATE"] = &guildCreateHandlers{logger: logger}
+	d.handlersManagers["GUILD_DELETE"] = &guildDeleteHandlers{logger: logger}
+	d.handlersManagers["GUILD_MEMBERS_CHUNK"] = &guildMembersChunkHandlers{logger: logger}
+	d.handlersManagers["GUILD_MEMBER_ADD"] = &guildMemberAddHandlers{logger: logger}
+	d.handlersManagers["GUILD_MEMBER_UPDATE"] = &guildMemberUpdateHandlers{logger: logger}
+	d.handlersManagers["GUILD_MEMBER_REMOVE"] = &guildMemberRemoveHandlers{logger: logger}
+	d.handlersManagers["CHANNEL_CREATE"] = &channelCreateHandlers{logger: logger}
+	d.handlersManagers["CHANNEL_UPDATE"] = &channelUpdateHandlers{logger: logger}
+	d.handlersManagers["CHANNEL_DELETE"] = &channelDeleteHandlers{logger: logger}
+	d.handlersManagers["THREAD_CREATE"] = &threadCreateHandlers{logger: logger}
+	d.handlersManagers["THREAD_DELETE"] = &threadDeleteHandlers{logger: logger}
+	d.handlersManagers["THREAD_LIST_SYNC"] = &threadListSyncHandlers{logger: logger}
+	d.handlersManagers["GUILD_ROLE_CREATE"] = &guildRoleCreateHandlers{logger: logger}
+	d.handlersManagers["GUILD_ROLE_UPDATE"] = &guildRoleUpdateHandlers{logger: logger}
+	d.handlersManagers["GUILD_ROLE_DELETE"] = &guildRoleDeleteHandlers{logger: logger}
+	d.handlersManagers["MESSAGE_REACTION_ADD"] = &messageReactionAddHandlers{logger: logger}
+	d.handlersManagers["MESSAGE_REACTION_REMOVE"] = &messageReactionRemoveHandlers{logger: logger}
+	d.handlersManagers["MESSAGE_REACTION_REMOVE_ALL"] = &messageReactionRemoveAllHandlers{logger: logger}
+	d.handlersManagers["MESSAGE_REACTION_REMOVE_EMOJI"] = &messageReactionRemoveEmojiHandlers{logger: logger}
+	d.handlersManagers["VOICE_STATE_UPDATE"] = &voiceStateUpdateHandlers{logger: logger}
 
 	return d
 }
 
+// lockCacheUpdate locks the cache updates of the entity with the given ID and returns the unlock function.
+func (d *dispatcher) lockCacheUpdate(id Snowflake) func() {
+	lock := &d.cacheUpdateLocks[id%Snowflake(len(d.cacheUpdateLocks))]
+	lock.Lock()
+	return lock.Unlock
+}
+
 /*****************************
  *     Dispatch Event
  *****************************/
@@ -92,11 +134,19 @@ func newDispatcher(logger xlog.Logger, client *Client, mode HandlerExecutionMode
 // The eventName must exactly match the Discord event string (e.g., "MESSAGE_CREATE").
 //
 // This method spawns a new goroutine for each dispatch to avoid blocking the main event loop.
+//
+// Events without handlers are counted, see UnhandledEventCounts, and passed to the OnUnhandledEvent handlers.
 func (d *dispatcher) dispatch(shardID int, eventName string, data []byte) {
 	d.logger.WithFields(map[string]any{
 		"shard_id": shardID,
 		"event":    eventName,
 	}).Debug("event dispatched")
+
+	_, handled := d.handlersManagers[eventName]
+	if !handled {
+		d.recordUnhandled(eventName)
+	}
+
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
@@ -110,8 +160,112 @@ func (d *dispatcher) dispatch(shardID int, eventName string, data []byte) {
 
 		if hm, ok := d.handlersManagers[eventName]; ok {
 			hm.handleEvent(d.client, d.handlerExecutionMode == HandlerExecutionAsync, shardID, data)
+		} else if len(d.unhandledHandlers) > 0 {
+			evt := UnhandledEvent{Client: d.client, ShardID: shardID, Name: eventName, Data: data}
+			runHandlers(d.client, d.logger, d.handlerExecutionMode == HandlerExecutionAsync, d.unhandledHandlers, evt)
+		}
+	}()
+}
+
+// recordUnhandled counts a dispatch of an event without handlers, logging the first one of each name.
+func (d *dispatcher) recordUnhandled(eventName string) {
+	d.unhandledMu.Lock()
+	d.unhandledCounts[eventName]++
+	first := d.unhandledCounts[eventName] == 1
+	d.unhandledMu.Unlock()
+
+	if first {
+		d.logger.WithField("event", eventName).Debug("received event without handlers, further ones are only counted")
+	}
+}
+
+// UnhandledEventCounts returns how many times each gateway event without handlers was received,
+// keyed by event name.
+//
+// This includes events the library doesn't model yet, which makes events newly added by Discord visible.
+func (d *dispatcher) UnhandledEventCounts() map[string]int {
+	d.unhandledMu.Lock()
+	defer d.unhandledMu.Unlock()
+	return maps.Clone(d.unhandledCounts)
+}
+
+// OnUnhandledEvent registers a handler for gateway events without any other handler,
+// including events the library doesn't model yet. The raw event data is passed as is.
+func (d *dispatcher) OnUnhandledEvent(h func(UnhandledEvent)) {
+	d.logger.Debug("unhandled event handler registered")
+	d.unhandledHandlers = append(d.unhandledHandlers, h)
+}
+
+// addHandler adds h to the handlers manager of the given event and marks the event as registered,
+// unlike the events that only have a handlers manager for caching.
+func (d *dispatcher) addHandler(eventName string, hm eventhandlersManager, h any) {
+	d.registeredEvents[eventName] = struct{}{}
+	hm.addHandler(h)
+}
+
+// runHandlers calls each handler with evt, either sequentially or each one in its own goroutine.
+//
+// If the client has a handler timeout configured, every handler is watched and a warning
+// is logged when it runs past the deadline. In sync mode the caller stops waiting for it
+// and continues with the next handler, the handler itself keeps running.
+func runHandlers[E any](client *Client, logger xlog.Logger, runAsync bool, handlers []func(E), evt E) {
+	var timeout time.Duration
+	if client != nil {
+		timeout = client.handlerTimeout
+	}
+
+	for _, handler := range handlers {
+		if timeout <= 0 {
+			if runAsync {
+				go handler(evt)
+			} else {
+				handler(evt)
+			}
+			continue
+		}
+
+		if runAsync {
+			go runHandlerWithTimeout(client.ctx, logger, timeout, handler, evt)
+		} else {
+			runHandlerWithTimeout(client.ctx, logger, timeout, handler, evt)
 		}
+	}
+}
+
+// runHandlerWithTimeout runs handler in a new goroutine and waits until it returns
+// or the timeout is exceeded, whichever comes first.
+//
+// The deadline only bounds the wait, a handler still running past it isn't canceled.
+func runHandlerWithTimeout[E any](ctx context.Context, logger xlog.Logger, timeout time.Duration, handler func(E), evt E) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				logger.WithField("panic", r).
+					WithField("stack", string(debug.Stack())).
+					Error("Recovered from panic while running event handler")
+			}
+		}()
+		handler(evt)
 	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			logger.WithFields(map[string]any{
+				"event":   fmt.Sprintf("%T", evt),
+				"timeout": timeout.String(),
+			}).Warn("event handler exceeded timeout")
+		}
+	}
 }
 
 /*****************************
@@ -128,7 +282,7 @@ func (d *dispatcher) OnMessageCreate(h func(MessageCreateEvent)) {
 		hm = &messageCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessageDelete registers a handler function for 'MESSAGE_DELETE' events.
@@ -141,7 +295,7 @@ func (d *dispatcher) OnMessageDelete(h func(MessageDeleteEvent)) {
 		hm = &messageDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessageUpdate registers a handler function for 'MESSAGE_UPDATE' events.
@@ -154,7 +308,7 @@ func (d *dispatcher) OnMessageUpdate(h func(MessageDeleteEvent)) {
 		hm = &messageUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnInteractionCreate registers a handler function for 'INTERACTION_CREATE' events.
@@ -167,7 +321,7 @@ func (d *dispatcher) OnInteractionCreate(h func(InteractionCreateEvent)) {
 		hm = &interactionCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnVoiceStateUpdate registers a handler function for 'VOICE_STATE_UPDATE' events.
@@ -180,7 +334,7 @@ func (d *dispatcher) OnVoiceStateUpdate(h func(VoiceStateUpdateEvent)) {
 		hm = &voiceStateUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnApplicationCommandPermissionsUpdate registers a handler for 'APPLICATION_COMMAND_PERMISSIONS_UPDATE' events.
@@ -193,7 +347,7 @@ func (d *dispatcher) OnApplicationCommandPermissionsUpdate(h func(ApplicationCom
 		hm = &applicationCommandPermissionsUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnAutoModerationRuleCreate registers a handler for 'AUTO_MODERATION_RULE_CREATE' events.
@@ -206,7 +360,7 @@ func (d *dispatcher) OnAutoModerationRuleCreate(h func(AutoModerationRuleCreateE
 		hm = &autoModerationRuleCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnAutoModerationRuleUpdate registers a handler for 'AUTO_MODERATION_RULE_UPDATE' events.
@@ -219,7 +373,7 @@ func (d *dispatcher) OnAutoModerationRuleUpdate(h func(AutoModerationRuleUpdateE
 		hm = &autoModerationRuleUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnAutoModerationRuleDelete registers a handler for 'AUTO_MODERATION_RULE_DELETE' events.
@@ -232,7 +386,7 @@ func (d *dispatcher) OnAutoModerationRuleDelete(h func(AutoModerationRuleDeleteE
 		hm = &autoModerationRuleDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnAutoModerationActionExecution registers a handler for 'AUTO_MODERATION_ACTION_EXECUTION' events.
@@ -245,7 +399,7 @@ func (d *dispatcher) OnAutoModerationActionExecution(h func(AutoModerationAction
 		hm = &autoModerationActionExecutionHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnChannelCreate registers a handler for 'CHANNEL_CREATE' events.
@@ -258,7 +412,7 @@ func (d *dispatcher) OnChannelCreate(h func(ChannelCreateEvent)) {
 		hm = &channelCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnChannelUpdate registers a handler for 'CHANNEL_UPDATE' events.
@@ -271,7 +425,7 @@ func (d *dispatcher) OnChannelUpdate(h func(ChannelUpdateEvent)) {
 		hm = &channelUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnChannelDelete registers a handler for 'CHANNEL_DELETE' events.
@@ -284,7 +438,7 @@ func (d *dispatcher) OnChannelDelete(h func(ChannelDeleteEvent)) {
 		hm = &channelDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnChannelPinsUpdate registers a handler for 'CHANNEL_PINS_UPDATE' events.
@@ -297,7 +451,7 @@ func (d *dispatcher) OnChannelPinsUpdate(h func(ChannelPinsUpdateEvent)) {
 		hm = &channelPinsUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnThreadCreate registers a handler for 'THREAD_CREATE' events.
@@ -310,7 +464,7 @@ func (d *dispatcher) OnThreadCreate(h func(ThreadCreateEvent)) {
 		hm = &threadCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnThreadUpdate registers a handler for 'THREAD_UPDATE' events.
@@ -323,7 +477,7 @@ func (d *dispatcher) OnThreadUpdate(h func(ThreadUpdateEvent)) {
 		hm = &threadUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnThreadDelete registers a handler for 'THREAD_DELETE' events.
@@ -336,7 +490,7 @@ func (d *dispatcher) OnThreadDelete(h func(ThreadDeleteEvent)) {
 		hm = &threadDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnThreadListSync registers a handler for 'THREAD_LIST_SYNC' events.
@@ -349,7 +503,7 @@ func (d *dispatcher) OnThreadListSync(h func(ThreadListSyncEvent)) {
 		hm = &threadListSyncHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnThreadMemberUpdate registers a handler for 'THREAD_MEMBER_UPDATE' events.
@@ -362,7 +516,7 @@ func (d *dispatcher) OnThreadMemberUpdate(h func(ThreadMemberUpdateEvent)) {
 		hm = &threadMemberUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnThreadMembersUpdate registers a handler for 'THREAD_MEMBERS_UPDATE' events.
@@ -375,7 +529,7 @@ func (d *dispatcher) OnThreadMembersUpdate(h func(ThreadMembersUpdateEvent)) {
 		hm = &threadMembersUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnEntitlementCreate registers a handler for 'ENTITLEMENT_CREATE' events.
@@ -388,7 +542,7 @@ func (d *dispatcher) OnEntitlementCreate(h func(EntitlementCreateEvent)) {
 		hm = &entitlementCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnEntitlementUpdate registers a handler for 'ENTITLEMENT_UPDATE' events.
@@ -401,7 +555,7 @@ func (d *dispatcher) OnEntitlementUpdate(h func(EntitlementUpdateEvent)) {
 		hm = &entitlementUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnEntitlementDelete registers a handler for 'ENTITLEMENT_DELETE' events.
@@ -414,7 +568,7 @@ func (d *dispatcher) OnEntitlementDelete(h func(EntitlementDeleteEvent)) {
 		hm = &entitlementDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildUpdate registers a handler for 'GUILD_UPDATE' events.
@@ -427,7 +581,7 @@ func (d *dispatcher) OnGuildUpdate(h func(GuildUpdateEvent)) {
 		hm = &guildUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildDelete registers a handler for 'GUILD_DELETE' events.
@@ -440,7 +594,7 @@ func (d *dispatcher) OnGuildDelete(h func(GuildDeleteEvent)) {
 		hm = &guildDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildAuditLogEntryCreate registers a handler for 'GUILD_AUDIT_LOG_ENTRY_CREATE' events.
@@ -453,7 +607,7 @@ func (d *dispatcher) OnGuildAuditLogEntryCreate(h func(GuildAuditLogEntryCreateE
 		hm = &guildAuditLogEntryCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildBanAdd registers a handler for 'GUILD_BAN_ADD' events.
@@ -466,7 +620,7 @@ func (d *dispatcher) OnGuildBanAdd(h func(GuildBanAddEvent)) {
 		hm = &guildBanAddHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildBanRemove registers a handler for 'GUILD_BAN_REMOVE' events.
@@ -479,7 +633,7 @@ func (d *dispatcher) OnGuildBanRemove(h func(GuildBanRemoveEvent)) {
 		hm = &guildBanRemoveHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildEmojisUpdate registers a handler for 'GUILD_EMOJIS_UPDATE' events.
@@ -492,7 +646,7 @@ func (d *dispatcher) OnGuildEmojisUpdate(h func(GuildEmojisUpdateEvent)) {
 		hm = &guildEmojisUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildStickersUpdate registers a handler for 'GUILD_STICKERS_UPDATE' events.
@@ -505,7 +659,7 @@ func (d *dispatcher) OnGuildStickersUpdate(h func(GuildStickersUpdateEvent)) {
 		hm = &guildStickersUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildIntegrationsUpdate registers a handler for 'GUILD_INTEGRATIONS_UPDATE' events.
@@ -518,7 +672,7 @@ func (d *dispatcher) OnGuildIntegrationsUpdate(h func(GuildIntegrationsUpdateEve
 		hm = &guildIntegrationsUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildMemberAdd registers a handler for 'GUILD_MEMBER_ADD' events.
@@ -531,7 +685,7 @@ func (d *dispatcher) OnGuildMemberAdd(h func(GuildMemberAddEvent)) {
 		hm = &guildMemberAddHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildMemberRemove registers a handler for 'GUILD_MEMBER_REMOVE' events.
@@ -544,7 +698,7 @@ func (d *dispatcher) OnGuildMemberRemove(h func(GuildMemberRemoveEvent)) {
 		hm = &guildMemberRemoveHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildMemberUpdate registers a handler for 'GUILD_MEMBER_UPDATE' events.
@@ -557,7 +711,27 @@ func (d *dispatcher) OnGuildMemberUpdate(h func(GuildMemberUpdateEvent)) {
 		hm = &guildMemberUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
+}
+
+// OnMemberPassedScreening registers a handler called when a member passes the guild's Membership Screening.
+//
+// It's derived from 'GUILD_MEMBER_UPDATE' events, firing when a member's pending state changes
+// from true to false. Use it to greet new members once they can actually talk, instead of on join.
+//
+// Note:
+//   - Requires the GatewayIntentGuildMembers intent and CacheFlagMembers,
+//     since the previous pending state is read from the members cache.
+func (d *dispatcher) OnMemberPassedScreening(h func(MemberPassedScreeningEvent)) {
+	const key = "GUILD_MEMBER_UPDATE"
+	d.logger.WithField("event", key).Debug("handler registered")
+
+	hm, ok := d.handlersManagers[key]
+	if !ok {
+		hm = &guildMemberUpdateHandlers{logger: d.logger}
+		d.handlersManagers[key] = hm
+	}
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildMembersChunk registers a handler for 'GUILD_MEMBERS_CHUNK' events.
@@ -570,7 +744,7 @@ func (d *dispatcher) OnGuildMembersChunk(h func(GuildMembersChunkEvent)) {
 		hm = &guildMembersChunkHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildRoleCreate registers a handler for 'GUILD_ROLE_CREATE' events.
@@ -583,7 +757,7 @@ func (d *dispatcher) OnGuildRoleCreate(h func(GuildRoleCreateEvent)) {
 		hm = &guildRoleCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildRoleUpdate registers a handler for 'GUILD_ROLE_UPDATE' events.
@@ -596,7 +770,7 @@ func (d *dispatcher) OnGuildRoleUpdate(h func(GuildRoleUpdateEvent)) {
 		hm = &guildRoleUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildRoleDelete registers a handler for 'GUILD_ROLE_DELETE' events.
@@ -609,7 +783,7 @@ func (d *dispatcher) OnGuildRoleDelete(h func(GuildRoleDeleteEvent)) {
 		hm = &guildRoleDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildScheduledEventCreate registers a handler for 'GUILD_SCHEDULED_EVENT_CREATE' events.
@@ -622,7 +796,7 @@ func (d *dispatcher) OnGuildScheduledEventCreate(h func(GuildScheduledEventCreat
 		hm = &guildScheduledEventCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildScheduledEventUpdate registers a handler for 'GUILD_SCHEDULED_EVENT_UPDATE' events.
@@ -635,7 +809,7 @@ func (d *dispatcher) OnGuildScheduledEventUpdate(h func(GuildScheduledEventUpdat
 		hm = &guildScheduledEventUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildScheduledEventDelete registers a handler for 'GUILD_SCHEDULED_EVENT_DELETE' events.
@@ -648,7 +822,7 @@ func (d *dispatcher) OnGuildScheduledEventDelete(h func(GuildScheduledEventDelet
 		hm = &guildScheduledEventDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildScheduledEventUserAdd registers a handler for 'GUILD_SCHEDULED_EVENT_USER_ADD' events.
@@ -661,7 +835,7 @@ func (d *dispatcher) OnGuildScheduledEventUserAdd(h func(GuildScheduledEventUser
 		hm = &guildScheduledEventUserAddHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildScheduledEventUserRemove registers a handler for 'GUILD_SCHEDULED_EVENT_USER_REMOVE' events.
@@ -674,7 +848,7 @@ func (d *dispatcher) OnGuildScheduledEventUserRemove(h func(GuildScheduledEventU
 		hm = &guildScheduledEventUserRemoveHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildSoundboardSoundCreate registers a handler for 'GUILD_SOUNDBOARD_SOUND_CREATE' events.
@@ -687,7 +861,7 @@ func (d *dispatcher) OnGuildSoundboardSoundCreate(h func(GuildSoundboardSoundCre
 		hm = &guildSoundboardSoundCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildSoundboardSoundUpdate registers a handler for 'GUILD_SOUNDBOARD_SOUND_UPDATE' events.
@@ -700,7 +874,7 @@ func (d *dispatcher) OnGuildSoundboardSoundUpdate(h func(GuildSoundboardSoundUpd
 		hm = &guildSoundboardSoundUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildSoundboardSoundDelete registers a handler for 'GUILD_SOUNDBOARD_SOUND_DELETE' events.
@@ -713,7 +887,7 @@ func (d *dispatcher) OnGuildSoundboardSoundDelete(h func(GuildSoundboardSoundDel
 		hm = &guildSoundboardSoundDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnGuildSoundboardSoundsUpdate registers a handler for 'GUILD_SOUNDBOARD_SOUNDS_UPDATE' events.
@@ -726,7 +900,7 @@ func (d *dispatcher) OnGuildSoundboardSoundsUpdate(h func(GuildSoundboardSoundsU
 		hm = &guildSoundboardSoundsUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnSoundboardSounds registers a handler for 'SOUNDBOARD_SOUNDS' events.
@@ -739,7 +913,7 @@ func (d *dispatcher) OnSoundboardSounds(h func(SoundboardSoundsEvent)) {
 		hm = &soundboardSoundsHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnIntegrationCreate registers a handler for 'INTEGRATION_CREATE' events.
@@ -752,7 +926,7 @@ func (d *dispatcher) OnIntegrationCreate(h func(IntegrationCreateEvent)) {
 		hm = &integrationCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnIntegrationUpdate registers a handler for 'INTEGRATION_UPDATE' events.
@@ -765,7 +939,7 @@ func (d *dispatcher) OnIntegrationUpdate(h func(IntegrationUpdateEvent)) {
 		hm = &integrationUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnIntegrationDelete registers a handler for 'INTEGRATION_DELETE' events.
@@ -778,7 +952,7 @@ func (d *dispatcher) OnIntegrationDelete(h func(IntegrationDeleteEvent)) {
 		hm = &integrationDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnInviteCreate registers a handler for 'INVITE_CREATE' events.
@@ -791,7 +965,7 @@ func (d *dispatcher) OnInviteCreate(h func(InviteCreateEvent)) {
 		hm = &inviteCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnInviteDelete registers a handler for 'INVITE_DELETE' events.
@@ -804,7 +978,7 @@ func (d *dispatcher) OnInviteDelete(h func(InviteDeleteEvent)) {
 		hm = &inviteDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessageDeleteBulk registers a handler for 'MESSAGE_DELETE_BULK' events.
@@ -817,7 +991,7 @@ func (d *dispatcher) OnMessageDeleteBulk(h func(MessageDeleteBulkEvent)) {
 		hm = &messageDeleteBulkHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessageReactionAdd registers a handler for 'MESSAGE_REACTION_ADD' events.
@@ -830,7 +1004,7 @@ func (d *dispatcher) OnMessageReactionAdd(h func(MessageReactionAddEvent)) {
 		hm = &messageReactionAddHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessageReactionRemove registers a handler for 'MESSAGE_REACTION_REMOVE' events.
@@ -843,7 +1017,7 @@ func (d *dispatcher) OnMessageReactionRemove(h func(MessageReactionRemoveEvent))
 		hm = &messageReactionRemoveHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessageReactionRemoveAll registers a handler for 'MESSAGE_REACTION_REMOVE_ALL' events.
@@ -856,7 +1030,7 @@ func (d *dispatcher) OnMessageReactionRemoveAll(h func(MessageReactionRemoveAllE
 		hm = &messageReactionRemoveAllHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessageReactionRemoveEmoji registers a handler for 'MESSAGE_REACTION_REMOVE_EMOJI' events.
@@ -869,7 +1043,7 @@ func (d *dispatcher) OnMessageReactionRemoveEmoji(h func(MessageReactionRemoveEm
 		hm = &messageReactionRemoveEmojiHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnPresenceUpdate registers a handler for 'PRESENCE_UPDATE' events.
@@ -882,7 +1056,7 @@ func (d *dispatcher) OnPresenceUpdate(h func(PresenceUpdateEvent)) {
 		hm = &presenceUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnStageInstanceCreate registers a handler for 'STAGE_INSTANCE_CREATE' events.
@@ -895,7 +1069,7 @@ func (d *dispatcher) OnStageInstanceCreate(h func(StageInstanceCreateEvent)) {
 		hm = &stageInstanceCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnStageInstanceUpdate registers a handler for 'STAGE_INSTANCE_UPDATE' events.
@@ -908,7 +1082,7 @@ func (d *dispatcher) OnStageInstanceUpdate(h func(StageInstanceUpdateEvent)) {
 		hm = &stageInstanceUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnStageInstanceDelete registers a handler for 'STAGE_INSTANCE_DELETE' events.
@@ -921,7 +1095,7 @@ func (d *dispatcher) OnStageInstanceDelete(h func(StageInstanceDeleteEvent)) {
 		hm = &stageInstanceDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnSubscriptionCreate registers a handler for 'SUBSCRIPTION_CREATE' events.
@@ -934,7 +1108,7 @@ func (d *dispatcher) OnSubscriptionCreate(h func(SubscriptionCreateEvent)) {
 		hm = &subscriptionCreateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnSubscriptionUpdate registers a handler for 'SUBSCRIPTION_UPDATE' events.
@@ -947,7 +1121,7 @@ func (d *dispatcher) OnSubscriptionUpdate(h func(SubscriptionUpdateEvent)) {
 		hm = &subscriptionUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnSubscriptionDelete registers a handler for 'SUBSCRIPTION_DELETE' events.
@@ -960,7 +1134,7 @@ func (d *dispatcher) OnSubscriptionDelete(h func(SubscriptionDeleteEvent)) {
 		hm = &subscriptionDeleteHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnTypingStart registers a handler for 'TYPING_START' events.
@@ -973,7 +1147,7 @@ func (d *dispatcher) OnTypingStart(h func(TypingStartEvent)) {
 		hm = &typingStartHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnUserUpdate registers a handler for 'USER_UPDATE' events.
@@ -986,7 +1160,7 @@ func (d *dispatcher) OnUserUpdate(h func(UserUpdateEvent)) {
 		hm = &userUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnVoiceChannelEffectSend registers a handler for 'VOICE_CHANNEL_EFFECT_SEND' events.
@@ -999,7 +1173,7 @@ func (d *dispatcher) OnVoiceChannelEffectSend(h func(VoiceChannelEffectSendEvent
 		hm = &voiceChannelEffectSendHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnVoiceServerUpdate registers a handler for 'VOICE_SERVER_UPDATE' events.
@@ -1012,7 +1186,7 @@ func (d *dispatcher) OnVoiceServerUpdate(h func(VoiceServerUpdateEvent)) {
 		hm = &voiceServerUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnWebhooksUpdate registers a handler for 'WEBHOOKS_UPDATE' events.
@@ -1025,7 +1199,7 @@ func (d *dispatcher) OnWebhooksUpdate(h func(WebhooksUpdateEvent)) {
 		hm = &webhooksUpdateHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessagePollVoteAdd registers a handler for 'MESSAGE_POLL_VOTE_ADD' events.
@@ -1038,7 +1212,7 @@ func (d *dispatcher) OnMessagePollVoteAdd(h func(MessagePollVoteAddEvent)) {
 		hm = &messagePollVoteAddHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
 
 // OnMessagePollVoteRemove registers a handler for 'MESSAGE_POLL_VOTE_REMOVE' events.
@@ -1051,5 +1225,5 @@ func (d *dispatcher) OnMessagePollVoteRemove(h func(MessagePollVoteRemoveEvent))
 		hm = &messagePollVoteRemoveHandlers{logger: d.logger}
 		d.handlersManagers[key] = hm
 	}
-	hm.addHandler(h)
+	d.addHandler(key, hm, h)
 }
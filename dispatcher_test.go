@@ -0,0 +1,183 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marouanesouiri/stdx/xlog"
+)
+
+func TestRunHandlersTimeout(t *testing.T) {
+	var logs bytes.Buffer
+	logger := xlog.NewTextLogger(&logs, xlog.LogLevelWarnLevel)
+	client := &Client{ctx: context.Background(), Logger: logger, handlerTimeout: 20 * time.Millisecond}
+
+	release := make(chan struct{})
+	defer close(release)
+
+	ranSecond := false
+	handlers := []func(MessageCreateEvent){
+		func(MessageCreateEvent) { <-release },
+		func(MessageCreateEvent) { ranSecond = true },
+	}
+
+	start := time.Now()
+	runHandlers(client, logger, false, handlers, MessageCreateEvent{})
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected dispatch to move on after the timeout, took %s", elapsed)
+	}
+	if !ranSecond {
+		t.Errorf("expected the second handler to run after the first one timed out")
+	}
+	if !strings.Contains(logs.String(), "event handler exceeded timeout") {
+		t.Errorf("expected a timeout warning, got logs: %q", logs.String())
+	}
+}
+
+func TestRunHandlersWithinTimeout(t *testing.T) {
+	var logs bytes.Buffer
+	logger := xlog.NewTextLogger(&logs, xlog.LogLevelWarnLevel)
+	client := &Client{ctx: context.Background(), Logger: logger, handlerTimeout: time.Second}
+
+	calls := 0
+	handler := func(MessageCreateEvent) { calls++ }
+	runHandlers(client, logger, false, []func(MessageCreateEvent){handler, handler}, MessageCreateEvent{})
+
+	if calls != 2 {
+		t.Errorf("expected 2 handler calls, got %d", calls)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("expected no warning, got logs: %q", logs.String())
+	}
+}
+
+func TestOnMemberPassedScreening(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	var passed []MemberPassedScreeningEvent
+	client.OnMemberPassedScreening(func(evt MemberPassedScreeningEvent) {
+		passed = append(passed, evt)
+	})
+
+	var joined GuildMemberAddEvent
+	client.OnGuildMemberAdd(func(evt GuildMemberAddEvent) { joined = evt })
+
+	client.handlersManagers["GUILD_MEMBER_ADD"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id": "1", "user": {"id": "2"}, "pending": true}`))
+
+	if !joined.IsPending() || joined.Member.ID != 2 {
+		t.Fatalf("expected a pending member with id 2, got %+v", joined.Member)
+	}
+
+	update := client.handlersManagers["GUILD_MEMBER_UPDATE"]
+	update.handleEvent(client, false, 0, []byte(`{"guild_id": "1", "user": {"id": "2"}, "pending": true, "nick": "bob"}`))
+	if len(passed) != 0 {
+		t.Fatalf("expected no screening event while still pending")
+	}
+
+	update.handleEvent(client, false, 0, []byte(`{"guild_id": "1", "user": {"id": "2"}, "pending": false}`))
+	if len(passed) != 1 {
+		t.Fatalf("expected 1 screening event, got %d", len(passed))
+	}
+	if passed[0].Member.ID != 2 || passed[0].Member.GuildID != 1 {
+		t.Errorf("unexpected member in screening event %+v", passed[0].Member)
+	}
+
+	update.handleEvent(client, false, 0, []byte(`{"guild_id": "1", "user": {"id": "2"}, "pending": false}`))
+	if len(passed) != 1 {
+		t.Errorf("expected no new screening event once passed, got %d", len(passed))
+	}
+}
+
+func TestGuildMemberRemoveCachedMember(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	var removed []GuildMemberRemoveEvent
+	client.OnGuildMemberRemove(func(evt GuildMemberRemoveEvent) { removed = append(removed, evt) })
+
+	client.PutMember(Member{ID: 2, GuildID: 1, Nickname: "bob", RoleIDs: []Snowflake{10, 11}})
+
+	remove := client.handlersManagers["GUILD_MEMBER_REMOVE"]
+	remove.handleEvent(client, false, 0, []byte(`{"guild_id": "1", "user": {"id": "2", "username": "bobby"}}`))
+
+	if len(removed) != 1 {
+		t.Fatalf("expected 1 remove event, got %d", len(removed))
+	}
+	member, err := removed[0].CachedMember().GetErr()
+	if err != nil {
+		t.Fatal("expected the cached member to be attached")
+	}
+	if member.Nickname != "bob" || len(member.RoleIDs) != 2 || member.User.Username != "bobby" {
+		t.Errorf("unexpected cached member %+v", member)
+	}
+	if client.GetMember(1, 2).IsPresent() {
+		t.Error("expected the member to be evicted from cache")
+	}
+
+	remove.handleEvent(client, false, 0, []byte(`{"guild_id": "1", "user": {"id": "3"}}`))
+	if removed[1].CachedMember().IsPresent() {
+		t.Error("expected no cached member for an uncached user")
+	}
+}
+
+func TestDispatchUnhandledEvent(t *testing.T) {
+	var logs bytes.Buffer
+	logger := xlog.NewTextLogger(&logs, xlog.LogLevelDebugLevel)
+	client := &Client{ctx: context.Background(), Logger: logger}
+	client.dispatcher = newDispatcher(logger, client, HandlerExecutionSync)
+
+	received := make(chan UnhandledEvent, 3)
+	client.OnUnhandledEvent(func(evt UnhandledEvent) { received <- evt })
+
+	for range 3 {
+		client.dispatch(2, "MADE_UP_EVENT", []byte(`{"foo":"bar"}`))
+	}
+	for range 3 {
+		select {
+		case evt := <-received:
+			if evt.Name != "MADE_UP_EVENT" || evt.ShardID != 2 || string(evt.Data) != `{"foo":"bar"}` {
+				t.Errorf("unhandled event = %+v", evt)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("OnUnhandledEvent handler was not called")
+		}
+	}
+
+	counts := client.UnhandledEventCounts()
+	if counts["MADE_UP_EVENT"] != 3 || len(counts) != 1 {
+		t.Errorf("UnhandledEventCounts() = %v", counts)
+	}
+	if n := strings.Count(logs.String(), "received event without handlers"); n != 1 {
+		t.Errorf("logged %d times, want once per event name", n)
+	}
+
+	// events with handlers are not counted.
+	handled := make(chan struct{})
+	client.OnMessageCreate(func(MessageCreateEvent) { close(handled) })
+	client.CacheManager = NewInMemoryCacheManager(CacheFlagsAll)
+	client.dispatch(0, "MESSAGE_CREATE", []byte(`{"id":"1"}`))
+	<-handled
+	if _, ok := client.UnhandledEventCounts()["MESSAGE_CREATE"]; ok {
+		t.Error("MESSAGE_CREATE was counted as unhandled")
+	}
+	if len(received) != 0 {
+		t.Error("OnUnhandledEvent handler was called for a handled event")
+	}
+}
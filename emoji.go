@@ -39,9 +39,22 @@ type Emoji struct {
 	//   - May be empty in deleted emojis.
 	Name string `json:"name,omitempty"`
 
+	// GuildID is the id of the guild this emoji is in.
+	//
+	// Optional:
+	//   - Will be 0 for application emojis and unicode emojis.
+	GuildID Snowflake `json:"guild_id,omitempty"`
+
 	// Roles is a list of role IDs allowed to use this emoji.
 	Roles []Snowflake `json:"roles,omitempty"`
 
+	// User is the user that created this emoji.
+	//
+	// Optional:
+	//   - Only present when fetching guild emojis with the PermissionManageGuildExpressions
+	//     or PermissionCreateGuildExpressions permission, nil otherwise.
+	User *User `json:"user,omitempty"`
+
 	// RequireColons indicates whether the emoji must be wrapped in colons to be used.
 	RequireColons bool `json:"require_colons,omitempty"`
 
@@ -55,11 +68,21 @@ type Emoji struct {
 	Available bool `json:"available,omitempty"`
 }
 
+// IsCustom reports whether the emoji is a custom (guild or application) emoji.
+func (e *Emoji) IsCustom() bool {
+	return e.ID != 0
+}
+
+// IsUnicode reports whether the emoji is a standard unicode emoji.
+func (e *Emoji) IsUnicode() bool {
+	return e.ID == 0 && e.Name != ""
+}
+
 // Mention returns a Discord mention string for the emoji.
 //
 // Example output: "<:sliming:123456789012345678>"
 func (e *Emoji) Mention() string {
-	if e.ID == 0 { // (no id == unicode emoji)
+	if !e.IsCustom() {
 		if e.RequireColons {
 			return ":" + e.Name + ":"
 		}
@@ -83,7 +106,12 @@ func (e *Emoji) String() string {
 // Supports:
 //   - Custom emojis: <:name:id> or <a:name:id>
 //   - Unicode emojis: just the string
+//
+// FormatEmoji does the opposite.
 func ParseEmoji(mention string) (Emoji, error) {
+	if mention == "" {
+		return Emoji{}, errors.New("empty emoji")
+	}
 	if !strings.HasPrefix(mention, "<") || !strings.HasSuffix(mention, ">") {
 		return Emoji{Name: mention}, nil
 	}
@@ -101,6 +129,9 @@ func ParseEmoji(mention string) (Emoji, error) {
 
 	animated := parts[0] == "a"
 	name := parts[1]
+	if name == "" {
+		return Emoji{}, errors.New("missing emoji name")
+	}
 	id, err := ParseSnowflake(parts[2])
 	if err != nil {
 		return Emoji{}, err
@@ -114,6 +145,17 @@ func ParseEmoji(mention string) (Emoji, error) {
 	}, nil
 }
 
+// FormatEmoji formats an emoji the way it is written in message content,
+// "<:name:id>" or "<a:name:id>" for custom emojis and the raw unicode for the others.
+//
+// The result can be parsed back with ParseEmoji.
+func FormatEmoji(e Emoji) string {
+	if !e.IsCustom() {
+		return e.Name
+	}
+	return e.Mention()
+}
+
 // CreatedAt returns the time when this emojis is created at.
 func (e *Emoji) CreatedAt() time.Time {
 	if e.ID == 0 {
@@ -122,19 +164,29 @@ func (e *Emoji) CreatedAt() time.Time {
 	return e.ID.Timestamp()
 }
 
-// URL returns the URL to the emoji's image.
+// URL returns the URL to the emoji's image, GIF for animated emojis and PNG otherwise.
+//
+// Returns an empty string for unicode emojis, which have no image hosted by Discord.
 func (e *Emoji) URL() string {
+	if !e.IsCustom() {
+		return ""
+	}
 	format := ImageFormatPNG
 	if e.Animated {
 		format = ImageFormatGIF
 	}
-	return EmojiURL(e.ID, format, ImageSizeDefault)
+	return emojiURL(e.ID, e.Animated, format, ImageSizeDefault)
 }
 
 // URLWith returns the URL to the emoji's image.
 // allowing explicit specification of image format and size.
+//
+// Returns an empty string for unicode emojis.
 func (e *Emoji) URLWith(format ImageFormat, size ImageSize) string {
-	return EmojiURL(e.ID, format, size)
+	if !e.IsCustom() {
+		return ""
+	}
+	return emojiURL(e.ID, e.Animated, format, size)
 }
 
 // PartialEmoji represents a partial emoji object used in a Discord poll, typically within a PollMedia object for poll answers,
@@ -162,12 +214,12 @@ type PartialEmoji struct {
 	Animated bool `json:"animated"`
 }
 
-// ListGuildEmojis retrieves a list of emoji objects for the given guild.
+// FetchGuildEmojis retrieves a list of emoji objects for the given guild.
 //
 // Includes user fields if the bot has the PermissionCreateGuildExpressions or PermissionManageGuildExpressions permission.
 //
 // Reference: https://discord.com/developers/docs/resources/emoji#list-guild-emojis
-func (r *requester) ListGuildEmojis(guildID Snowflake) result.Result[[]Emoji] {
+func (r *requester) FetchGuildEmojis(guildID Snowflake) result.Result[[]Emoji] {
 	res := r.DoRequest(Request{
 		Method: "GET",
 		URL:    "/guilds/" + guildID.String() + "/emojis",
@@ -187,9 +239,19 @@ func (r *requester) ListGuildEmojis(guildID Snowflake) result.Result[[]Emoji] {
 		}).Error("failed parsing response")
 		return result.Err[[]Emoji](err)
 	}
+	for i := range emojis {
+		emojis[i].GuildID = guildID
+	}
 	return result.Ok(emojis)
 }
 
+// ListGuildEmojis retrieves a list of emoji objects for the given guild.
+//
+// Deprecated: Use FetchGuildEmojis, which also sets the GuildID of the returned emojis.
+func (r *requester) ListGuildEmojis(guildID Snowflake) result.Result[[]Emoji] {
+	return r.FetchGuildEmojis(guildID)
+}
+
 // FetchGuildEmoji retrieves an emoji object for the given guild and emoji IDs.
 //
 // Includes the user field if the bot has the PermissionManageGuildExpressions permission,
@@ -216,6 +278,7 @@ func (r *requester) FetchGuildEmoji(guildID, emojiID Snowflake) result.Result[Em
 		}).Error("failed parsing response")
 		return result.Err[Emoji](err)
 	}
+	emoji.GuildID = guildID
 	return result.Ok(emoji)
 }
 
@@ -268,6 +331,7 @@ func (r *requester) CreateGuildEmoji(guildID Snowflake, opts CreateGuildEmojiOpt
 		}).Error("failed parsing response")
 		return result.Err[Emoji](err)
 	}
+	emoji.GuildID = guildID
 	return result.Ok(emoji)
 }
 
@@ -314,6 +378,7 @@ func (r *requester) ModifyGuildEmoji(guildID, emojiID Snowflake, opts ModifyGuil
 		}).Error("failed parsing response")
 		return result.Err[Emoji](err)
 	}
+	emoji.GuildID = guildID
 	return result.Ok(emoji)
 }
 
@@ -336,17 +401,19 @@ func (r *requester) DeleteGuildEmoji(guildID, emojiID Snowflake, reason string)
 	return result.OkVoid()
 }
 
+// applicationEmojisResponse is the response of the list application emojis endpoint,
+// which wraps the emojis in an "items" object.
 type applicationEmojisResponse struct {
 	Items []Emoji `json:"items"`
 }
 
-// ListApplicationEmojis retrieves all emojis for an application.
+// FetchApplicationEmojis retrieves all emojis for an application.
 //
 // Includes a user object for the team member that uploaded the emoji from the app's settings,
 // or for the bot user if uploaded using the API.
 //
 // Reference: https://discord.com/developers/docs/resources/emoji#list-application-emojis
-func (r *requester) ListApplicationEmojis(applicationID Snowflake) result.Result[[]Emoji] {
+func (r *requester) FetchApplicationEmojis(applicationID Snowflake) result.Result[[]Emoji] {
 	res := r.DoRequest(Request{
 		Method: "GET",
 		URL:    "/applications/" + applicationID.String() + "/emojis",
@@ -369,6 +436,13 @@ func (r *requester) ListApplicationEmojis(applicationID Snowflake) result.Result
 	return result.Ok(response.Items)
 }
 
+// ListApplicationEmojis retrieves all emojis for an application.
+//
+// Deprecated: Use FetchApplicationEmojis.
+func (r *requester) ListApplicationEmojis(applicationID Snowflake) result.Result[[]Emoji] {
+	return r.FetchApplicationEmojis(applicationID)
+}
+
 // FetchApplicationEmoji retrieves a specific emoji from an application.
 //
 // Includes the user field.
@@ -399,6 +473,8 @@ func (r *requester) FetchApplicationEmoji(applicationID, emojiID Snowflake) resu
 
 // CreateApplicationEmojiOptions contains parameters for creating a new application emoji.
 //
+// Unlike guild emojis, application emojis can't be restricted to roles.
+//
 // Reference: https://discord.com/developers/docs/resources/emoji#create-application-emoji-json-params
 type CreateApplicationEmojiOptions struct {
 	// Name is the name of the emoji.
@@ -0,0 +1,241 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+const testEmojiImage = "data:image/png;base64,iVBORw0KGgo="
+
+func TestCreateGuildEmoji(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/guilds/1/emojis" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		if got := req.Header.Get(headerReason); got != "new emoji" {
+			t.Errorf("reason = %q, want new emoji", got)
+		}
+
+		var body struct {
+			Name  string      `json:"name"`
+			Image string      `json:"image"`
+			Roles []Snowflake `json:"roles"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.Name != "blob" || body.Image != testEmojiImage || len(body.Roles) != 1 || body.Roles[0] != 5 {
+			t.Errorf("body = %+v", body)
+		}
+
+		io.WriteString(w, `{"id":"100","name":"blob","roles":["5"]}`)
+	})
+
+	res := r.CreateGuildEmoji(1, CreateGuildEmojiOptions{
+		Name:   "blob",
+		Image:  testEmojiImage,
+		Roles:  []Snowflake{5},
+		Reason: "new emoji",
+	})
+	if res.IsErr() {
+		t.Fatalf("CreateGuildEmoji: %v", res.Err())
+	}
+	if emoji := res.Value(); emoji.ID != 100 || emoji.GuildID != 1 {
+		t.Errorf("emoji = %+v, want ID 100 in guild 1", emoji)
+	}
+}
+
+func TestFetchGuildEmojisSetsGuildID(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, `[{"id":"100","name":"a"},{"id":"101","name":"b"}]`)
+	})
+
+	res := r.FetchGuildEmojis(1)
+	if res.IsErr() {
+		t.Fatalf("FetchGuildEmojis: %v", res.Err())
+	}
+	for _, emoji := range res.Value() {
+		if emoji.GuildID != 1 {
+			t.Errorf("emoji %d has GuildID %d, want 1", emoji.ID, emoji.GuildID)
+		}
+	}
+}
+
+func TestDeleteGuildEmoji(t *testing.T) {
+	called := false
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		if req.Method != http.MethodDelete || req.URL.Path != "/guilds/1/emojis/100" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if res := r.DeleteGuildEmoji(1, 100, ""); res.IsErr() {
+		t.Fatalf("DeleteGuildEmoji: %v", res.Err())
+	}
+	if !called {
+		t.Error("expected a request")
+	}
+}
+
+func TestCreateApplicationEmoji(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/applications/7/emojis" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["name"] != "wave" || body["image"] != testEmojiImage {
+			t.Errorf("body = %v", body)
+		}
+		if _, ok := body["roles"]; ok {
+			t.Error("application emojis should not send roles")
+		}
+
+		io.WriteString(w, `{"id":"200","name":"wave"}`)
+	})
+
+	res := r.CreateApplicationEmoji(7, CreateApplicationEmojiOptions{Name: "wave", Image: testEmojiImage})
+	if res.IsErr() {
+		t.Fatalf("CreateApplicationEmoji: %v", res.Err())
+	}
+	if emoji := res.Value(); emoji.ID != 200 || emoji.GuildID != 0 {
+		t.Errorf("emoji = %+v", emoji)
+	}
+}
+
+func TestFetchApplicationEmojis(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/applications/7/emojis" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		io.WriteString(w, `{"items":[{"id":"200","name":"wave"},{"id":"201","name":"dance","animated":true}]}`)
+	})
+
+	res := r.FetchApplicationEmojis(7)
+	if res.IsErr() {
+		t.Fatalf("FetchApplicationEmojis: %v", res.Err())
+	}
+	emojis := res.Value()
+	if len(emojis) != 2 || emojis[0].Name != "wave" || !emojis[1].Animated {
+		t.Errorf("emojis = %+v", emojis)
+	}
+}
+
+func TestEmojiKind(t *testing.T) {
+	custom := Emoji{ID: 100, Name: "blob"}
+	unicode := Emoji{Name: "👍"}
+	if !custom.IsCustom() || custom.IsUnicode() {
+		t.Errorf("custom emoji: IsCustom=%v IsUnicode=%v", custom.IsCustom(), custom.IsUnicode())
+	}
+	if unicode.IsCustom() || !unicode.IsUnicode() {
+		t.Errorf("unicode emoji: IsCustom=%v IsUnicode=%v", unicode.IsCustom(), unicode.IsUnicode())
+	}
+}
+
+func TestEmojiMention(t *testing.T) {
+	tests := []struct {
+		emoji Emoji
+		want  string
+	}{
+		{Emoji{Name: "👍"}, "👍"},
+		{Emoji{ID: 100, Name: "blob"}, "<:blob:100>"},
+		{Emoji{ID: 100, Name: "dance", Animated: true}, "<a:dance:100>"},
+	}
+	for _, tt := range tests {
+		if got := tt.emoji.Mention(); got != tt.want {
+			t.Errorf("Mention() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestEmojiURL(t *testing.T) {
+	animated := Emoji{ID: 100, Name: "dance", Animated: true}
+	if got, want := animated.URL(), ImageBaseURL+"emojis/100.gif"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+	if got, want := animated.URLWith(ImageFormatWebP, ImageSize64), ImageBaseURL+"emojis/100.webp?size=64&animated=true"; got != want {
+		t.Errorf("URLWith() = %q, want %q", got, want)
+	}
+
+	static := Emoji{ID: 101, Name: "blob"}
+	if got, want := static.URLWith(ImageFormatGIF, ImageSizeDefault), ImageBaseURL+"emojis/101.png"; got != want {
+		t.Errorf("static URLWith(GIF) = %q, want %q", got, want)
+	}
+
+	unicode := Emoji{Name: "👍"}
+	if got := unicode.URL(); got != "" {
+		t.Errorf("unicode URL() = %q, want empty", got)
+	}
+}
+
+func TestEmojiUnmarshalUser(t *testing.T) {
+	var emoji Emoji
+	if err := json.Unmarshal([]byte(`{"id":"100","name":"blob","user":{"id":"5","username":"bob"}}`), &emoji); err != nil {
+		t.Fatal(err)
+	}
+	if emoji.User == nil || emoji.User.ID != 5 {
+		t.Errorf("User = %+v", emoji.User)
+	}
+}
+
+func TestParseEmojiRoundTrip(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Emoji
+	}{
+		{"<a:dance:100>", Emoji{ID: 100, Name: "dance", Animated: true, RequireColons: true}},
+		{"<:blob:200>", Emoji{ID: 200, Name: "blob", RequireColons: true}},
+		{"👍", Emoji{Name: "👍"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseEmoji(tt.input)
+		if err != nil {
+			t.Fatalf("ParseEmoji(%q): %v", tt.input, err)
+		}
+		if got.ID != tt.want.ID || got.Name != tt.want.Name || got.Animated != tt.want.Animated || got.RequireColons != tt.want.RequireColons {
+			t.Errorf("ParseEmoji(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+		if formatted := FormatEmoji(got); formatted != tt.input {
+			t.Errorf("FormatEmoji(ParseEmoji(%q)) = %q", tt.input, formatted)
+		}
+	}
+}
+
+func TestParseEmojiInvalid(t *testing.T) {
+	for _, input := range []string{"", "<:blob>", "<b:blob:100>", "<::100>", "<:blob:abc>"} {
+		if _, err := ParseEmoji(input); err == nil {
+			t.Errorf("ParseEmoji(%q) succeeded, want error", input)
+		}
+	}
+}
+
+func TestNewDefaultReactionEmoji(t *testing.T) {
+	custom := NewDefaultReactionEmoji(Emoji{ID: 100, Name: "blob"})
+	if !custom.IsCustom() || custom.EmojiID != 100 || custom.EmojiName != "" {
+		t.Errorf("custom = %+v", custom)
+	}
+	unicode := NewDefaultReactionEmoji(Emoji{Name: "👍"})
+	if unicode.IsCustom() || unicode.EmojiName != "👍" {
+		t.Errorf("unicode = %+v", unicode)
+	}
+}
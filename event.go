@@ -13,12 +13,17 @@
 
 package dwaz
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
 
 // ReadyCreateEvent Shard is ready
 type ReadyEvent struct {
 	Client  *Client
-	ShardID int // shard that dispatched this event
+	ShardID int  // shard that dispatched this event
+	User    User // the bot user
 	Guilds  []Guild
 }
 
@@ -77,9 +82,19 @@ type VoiceStateUpdateEvent struct {
 	NewState GatewayVoiceState
 }
 
+// UnhandledEvent Gateway event without handlers, see OnUnhandledEvent
+type UnhandledEvent struct {
+	Client  *Client
+	ShardID int             // shard that dispatched this event
+	Name    string          // name of the event, like "MESSAGE_CREATE"
+	Data    json.RawMessage // raw data of the event
+}
+
 // ApplicationCommandPermissionsUpdateEvent Application command permission was updated
 type ApplicationCommandPermissionsUpdateEvent struct {
-	// TODO: complete this struct
+	Client      *Client
+	ShardID     int // shard that dispatched this event
+	Permissions GuildApplicationCommandPermissions
 }
 
 // AutoModerationRuleCreateEvent Auto Moderation rule was created
@@ -104,17 +119,58 @@ type AutoModerationActionExecutionEvent struct {
 
 // ChannelCreateEvent New guild channel created
 type ChannelCreateEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	Channel GuildChannel
+}
+
+var _ json.Unmarshaler = (*ChannelCreateEvent)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler for ChannelCreateEvent.
+func (c *ChannelCreateEvent) UnmarshalJSON(buf []byte) error {
+	channel, err := unmarshalGuildChannel(buf)
+	if err == nil {
+		c.Channel = channel
+	}
+	return err
 }
 
 // ChannelUpdateEvent Channel was updated
 type ChannelUpdateEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	// OldChannel is the cached channel before the update, nil if it wasn't cached.
+	OldChannel GuildChannel
+	NewChannel GuildChannel
+}
+
+var _ json.Unmarshaler = (*ChannelUpdateEvent)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler for ChannelUpdateEvent.
+func (c *ChannelUpdateEvent) UnmarshalJSON(buf []byte) error {
+	channel, err := unmarshalGuildChannel(buf)
+	if err == nil {
+		c.NewChannel = channel
+	}
+	return err
 }
 
 // ChannelDeleteEvent Channel was deleted
 type ChannelDeleteEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	Channel GuildChannel
+}
+
+var _ json.Unmarshaler = (*ChannelDeleteEvent)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler for ChannelDeleteEvent.
+func (c *ChannelDeleteEvent) UnmarshalJSON(buf []byte) error {
+	channel, err := unmarshalGuildChannel(buf)
+	if err == nil {
+		c.Channel = channel
+	}
+	return err
 }
 
 // ChannelPinsUpdateEvent Message was pinned or unpinned
@@ -124,7 +180,12 @@ type ChannelPinsUpdateEvent struct {
 
 // ThreadCreateEvent Thread created
 type ThreadCreateEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	Thread  ThreadChannel
+	// NewlyCreated is true when the thread was just created,
+	// false when the bot was added to an existing private thread.
+	NewlyCreated bool
 }
 
 // ThreadUpdateEvent Thread was updated
@@ -134,17 +195,36 @@ type ThreadUpdateEvent struct {
 
 // ThreadDeleteEvent Thread was deleted
 type ThreadDeleteEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	// Thread is the deleted thread. Only its ID, GuildID, ParentID and Type are set
+	// unless the thread was cached.
+	Thread ThreadChannel
 }
 
 // ThreadListSyncEvent Sent when gaining access to a channel, contains all active threads
 type ThreadListSyncEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	// GuildID is the id of the guild of the synced threads.
+	GuildID Snowflake
+	// ChannelIDs is the ids of the parent channels whose threads are synced,
+	// it may contain channels without active threads. Empty if the whole guild is synced.
+	ChannelIDs []Snowflake
+	// Threads is every active thread of the synced channels that the current user can access.
+	Threads []ThreadChannel
+	// Members is the thread members of the current user for the synced threads it joined.
+	Members []ThreadMember
 }
 
 // ThreadMemberUpdateEvent Thread member for the current user was updated
 type ThreadMemberUpdateEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	// GuildID is the id of the guild of the thread.
+	GuildID Snowflake
+	// Member is the updated thread member of the current user, see ThreadMember.Flags for its notification settings.
+	Member ThreadMember
 }
 
 // ThreadMembersUpdateEvent Some user(s) were added to or removed from a thread
@@ -174,12 +254,26 @@ type GuildUpdateEvent struct {
 
 // GuildDeleteEvent Guild became unavailable, or user left/was removed from a guild
 type GuildDeleteEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+
+	// GuildID is the id of the guild.
+	GuildID Snowflake `json:"id"`
+
+	// Unavailable is true if the guild became unavailable due to an outage,
+	// false if the user left or was removed from the guild.
+	Unavailable bool `json:"unavailable"`
 }
 
 // GuildAuditLogEntryCreateEvent A guild audit log entry was created
 type GuildAuditLogEntryCreateEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+
+	// GuildID is the id of the guild where the entry was created.
+	GuildID Snowflake `json:"guild_id"`
+
+	AuditLogEntry
 }
 
 // GuildBanAddEvent User was banned from a guild
@@ -209,37 +303,115 @@ type GuildIntegrationsUpdateEvent struct {
 
 // GuildMemberAddEvent New user joined a guild
 type GuildMemberAddEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	Member  FullMember
+}
+
+// IsPending returns true if the member hasn't passed the guild's Membership Screening yet.
+//
+// Use OnMemberPassedScreening to know when a pending member passes it.
+func (e *GuildMemberAddEvent) IsPending() bool {
+	return e.Member.Pending
 }
 
 // GuildMemberRemoveEvent User was removed from a guild
 type GuildMemberRemoveEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+
+	// GuildID is the id of the guild the user was removed from.
+	GuildID Snowflake `json:"guild_id"`
+
+	// User is the user who was removed.
+	User User `json:"user"`
+
+	cachedMember optional.Option[FullMember]
+}
+
+// CachedMember returns the last known state of the removed member, read from the cache
+// before it was evicted.
+//
+// Optional:
+//   - Will be None if the member was not cached, for example when CacheFlagMembers is disabled.
+func (e GuildMemberRemoveEvent) CachedMember() optional.Option[FullMember] {
+	return e.cachedMember
 }
 
 // GuildMemberUpdateEvent Guild member was updated
 type GuildMemberUpdateEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	Member  FullMember
+
+	// OldMember is the state of the member before the update, read from the cache.
+	//
+	// Optional:
+	//   - Will be None if the member was not cached, for example when CacheFlagMembers is disabled.
+	OldMember optional.Option[FullMember]
+}
+
+// MemberPassedScreeningEvent Guild member passed the guild's Membership Screening
+//
+// This is not a Discord gateway event, it's fired when a GUILD_MEMBER_UPDATE changes
+// the member's pending state from true to false.
+type MemberPassedScreeningEvent struct {
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	Member  FullMember
 }
 
 // GuildMembersChunkEvent Response to Request Guild Members
 type GuildMembersChunkEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+
+	// GuildID is the id of the guild the members belong to.
+	GuildID Snowflake `json:"guild_id"`
+
+	// Members is the list of guild members in this chunk.
+	Members []FullMember `json:"members"`
+
+	// ChunkIndex is the index of this chunk, starting at 0.
+	ChunkIndex int `json:"chunk_index"`
+
+	// ChunkCount is the total number of chunks for the request.
+	ChunkCount int `json:"chunk_count"`
+
+	// NotFound is the list of requested user IDs that were not found in the guild.
+	NotFound []Snowflake `json:"not_found"`
+
+	// Nonce is the nonce used in the Request Guild Members payload.
+	Nonce string `json:"nonce"`
+}
+
+// IsLast reports whether this is the last chunk of the request.
+func (e GuildMembersChunkEvent) IsLast() bool {
+	return e.ChunkIndex == e.ChunkCount-1
 }
 
 // GuildRoleCreateEvent Guild role was created
 type GuildRoleCreateEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int  // shard that dispatched this event
+	Role    Role // the created role, with its GuildID set
 }
 
 // GuildRoleUpdateEvent Guild role was updated
 type GuildRoleUpdateEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	// OldRole is the cached role before the update. Only its ID and GuildID are set if it wasn't cached.
+	OldRole Role
+	NewRole Role
 }
 
 // GuildRoleDeleteEvent Guild role was deleted
 type GuildRoleDeleteEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+	// Role is the deleted role. Only its ID and GuildID are set if it wasn't cached.
+	Role Role
 }
 
 // GuildScheduledEventCreateEvent Guild scheduled event was created
@@ -324,22 +496,95 @@ type MessageDeleteBulkEvent struct {
 
 // MessageReactionAddEvent User reacted to a message
 type MessageReactionAddEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+
+	// UserID is the id of the user who reacted.
+	UserID Snowflake `json:"user_id"`
+
+	// ChannelID is the id of the channel of the message.
+	ChannelID Snowflake `json:"channel_id"`
+
+	// MessageID is the id of the message.
+	MessageID Snowflake `json:"message_id"`
+
+	// GuildID is the id of the guild of the message, 0 in DMs.
+	GuildID Snowflake `json:"guild_id"`
+
+	// MessageAuthorID is the id of the user who authored the message.
+	MessageAuthorID Snowflake `json:"message_author_id"`
+
+	// Emoji is the emoji used to react.
+	Emoji Emoji `json:"emoji"`
+
+	// Burst is whether this is a super reaction.
+	Burst bool `json:"burst"`
+
+	// BurstColors contains the HEX colors used for the super reaction.
+	BurstColors []string `json:"burst_colors"`
+
+	// Type is the type of the reaction.
+	Type ReactionType `json:"type"`
 }
 
 // MessageReactionRemoveEvent User removed a reaction from a message
 type MessageReactionRemoveEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+
+	// UserID is the id of the user whose reaction was removed.
+	UserID Snowflake `json:"user_id"`
+
+	// ChannelID is the id of the channel of the message.
+	ChannelID Snowflake `json:"channel_id"`
+
+	// MessageID is the id of the message.
+	MessageID Snowflake `json:"message_id"`
+
+	// GuildID is the id of the guild of the message, 0 in DMs.
+	GuildID Snowflake `json:"guild_id"`
+
+	// Emoji is the emoji of the removed reaction.
+	Emoji Emoji `json:"emoji"`
+
+	// Burst is whether this was a super reaction.
+	Burst bool `json:"burst"`
+
+	// Type is the type of the reaction.
+	Type ReactionType `json:"type"`
 }
 
 // MessageReactionRemoveAllEvent All reactions were explicitly removed from a message
 type MessageReactionRemoveAllEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+
+	// ChannelID is the id of the channel of the message.
+	ChannelID Snowflake `json:"channel_id"`
+
+	// MessageID is the id of the message.
+	MessageID Snowflake `json:"message_id"`
+
+	// GuildID is the id of the guild of the message, 0 in DMs.
+	GuildID Snowflake `json:"guild_id"`
 }
 
 // MessageReactionRemoveEmojiEvent All reactions for a given emoji were explicitly removed from a message
 type MessageReactionRemoveEmojiEvent struct {
-	// TODO: complete this struct
+	Client  *Client
+	ShardID int // shard that dispatched this event
+
+	// ChannelID is the id of the channel of the message.
+	ChannelID Snowflake `json:"channel_id"`
+
+	// MessageID is the id of the message.
+	MessageID Snowflake `json:"message_id"`
+
+	// GuildID is the id of the guild of the message, 0 in DMs.
+	GuildID Snowflake `json:"guild_id"`
+
+	// Emoji is the emoji whose reactions were removed.
+	Emoji Emoji `json:"emoji"`
 }
 
 // PresenceUpdateEvent User was updated
@@ -15,7 +15,9 @@ package dwaz
 
 import (
 	"encoding/json"
+	"slices"
 
+	"github.com/marouanesouiri/stdx/optional"
 	"github.com/marouanesouiri/stdx/xlog"
 )
 
@@ -37,19 +39,13 @@ func (h *readyHandlers) handleEvent(client *Client, runAsync bool, shardID int,
 		return
 	}
 
+	client.selfID.Store(uint64(evt.User.ID))
+	client.PutUser(evt.User)
 	for i := range len(evt.Guilds) {
 		client.PutGuild(evt.Guilds[i])
 	}
 
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 // addHandler registers a new READY handler function.
@@ -92,6 +88,7 @@ func (h *guildCreateHandlers) handleEvent(client *Client, runAsync bool, shardID
 		for i := range len(evt.Guild.Roles) {
 			client.PutRole(evt.Guild.Roles[i])
 		}
+		client.markGuildRolesComplete(evt.Guild.ID)
 	}
 	if flags.Has(CacheFlagVoiceStates) {
 		for i := range len(evt.Guild.VoiceStates) {
@@ -108,16 +105,11 @@ func (h *guildCreateHandlers) handleEvent(client *Client, runAsync bool, shardID
 			client.PutUser(evt.Guild.Members[i].User)
 		}
 	}
-
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+	if client.chunkGuildsOnStartup {
+		client.chunkGuild(evt.Guild)
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 // addHandler registers a new GUILD_CREATE handler function.
@@ -150,15 +142,7 @@ func (h *messageCreateHandlers) handleEvent(client *Client, runAsync bool, shard
 		client.PutMessage(evt.Message)
 	}
 
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 // addHandler registers a new MESSAGE_CREATE handler function.
@@ -191,15 +175,7 @@ func (h *messageDeleteHandlers) handleEvent(client *Client, runAsync bool, shard
 	}
 	client.DelMessage(evt.Message.ID)
 
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 // addHandler registers a new MESSAGE_DELETE handler function.
@@ -242,15 +218,7 @@ func (h *messageUpdateHandlers) handleEvent(client *Client, runAsync bool, shard
 		client.PutMessage(evt.NewMessage)
 	}
 
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 // addHandler registers a new MESSAGE_UPDATE handler function.
@@ -277,16 +245,11 @@ func (h *interactionCreateHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("interactionCreateHandlers: Failed parsing event data")
 		return
 	}
-
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+	if i, ok := evt.Interaction.(interface{ bind(*Client) }); ok {
+		i.bind(client)
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 // addHandler registers a new INTERACTION_CREATE handler function.
@@ -327,15 +290,7 @@ func (h *voiceStateUpdateHandlers) handleEvent(client *Client, runAsync bool, sh
 		client.PutVoiceState(evt.NewState.VoiceState)
 	}
 
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 // addHandler registers a new VOICE_STATE_UPDATE handler function.
@@ -355,20 +310,12 @@ type applicationCommandPermissionsUpdateHandlers struct {
 }
 
 func (h *applicationCommandPermissionsUpdateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt ApplicationCommandPermissionsUpdateEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := ApplicationCommandPermissionsUpdateEvent{Client: client, ShardID: shardID}
+	if err := json.Unmarshal(data, &evt.Permissions); err != nil {
 		h.logger.Error("applicationCommandPermissionsUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *applicationCommandPermissionsUpdateHandlers) addHandler(handler any) {
@@ -390,15 +337,7 @@ func (h *autoModerationRuleCreateHandlers) handleEvent(client *Client, runAsync
 		h.logger.Error("autoModerationRuleCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *autoModerationRuleCreateHandlers) addHandler(handler any) {
@@ -416,15 +355,7 @@ func (h *autoModerationRuleUpdateHandlers) handleEvent(client *Client, runAsync
 		h.logger.Error("autoModerationRuleUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *autoModerationRuleUpdateHandlers) addHandler(handler any) {
@@ -442,15 +373,7 @@ func (h *autoModerationRuleDeleteHandlers) handleEvent(client *Client, runAsync
 		h.logger.Error("autoModerationRuleDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *autoModerationRuleDeleteHandlers) addHandler(handler any) {
@@ -468,15 +391,7 @@ func (h *autoModerationActionExecutionHandlers) handleEvent(client *Client, runA
 		h.logger.Error("autoModerationActionExecutionHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *autoModerationActionExecutionHandlers) addHandler(handler any) {
@@ -493,20 +408,17 @@ type channelCreateHandlers struct {
 }
 
 func (h *channelCreateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt ChannelCreateEvent
+	evt := ChannelCreateEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("channelCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+
+	if client.Flags().Has(CacheFlagChannels) {
+		client.PutChannel(evt.Channel)
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *channelCreateHandlers) addHandler(handler any) {
@@ -519,20 +431,22 @@ type channelUpdateHandlers struct {
 }
 
 func (h *channelUpdateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt ChannelUpdateEvent
+	evt := ChannelUpdateEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("channelUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
+
+	if oldChannelOpt := client.GetChannel(evt.NewChannel.GetID()); oldChannelOpt.IsPresent() {
+		if oldChannel, ok := oldChannelOpt.Get().(GuildChannel); ok {
+			evt.OldChannel = oldChannel
 		}
 	}
+	if client.Flags().Has(CacheFlagChannels) {
+		client.PutChannel(evt.NewChannel)
+	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *channelUpdateHandlers) addHandler(handler any) {
@@ -544,21 +458,37 @@ type channelDeleteHandlers struct {
 	handlers []func(ChannelDeleteEvent)
 }
 
+// handleEvent parses the CHANNEL_DELETE event data, evicts the channel from the cache
+// and calls each registered handler.
+//
+// Cached threads of the channel are evicted too, as Discord deletes them with their parent without
+// sending THREAD_DELETE. Deleting a category moves its cached channels out of it, like Discord does.
 func (h *channelDeleteHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt ChannelDeleteEvent
+	evt := ChannelDeleteEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("channelDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
+
+	if client.Flags().Has(CacheFlagChannels) {
+		channelID := evt.Channel.GetID()
+		client.DelChannel(channelID)
+		if channelsOpt := client.GetGuildChannels(evt.Channel.GetGuildID()); channelsOpt.IsPresent() {
+			for _, channel := range channelsOpt.Get() {
+				categorized, ok := channel.(CategorizedChannel)
+				if !ok || categorized.GetParentID() != channelID {
+					continue
+				}
+				if _, isThread := channel.(*ThreadChannel); isThread {
+					client.DelChannel(channel.GetID())
+				} else {
+					client.PutChannel(withoutParent(channel))
+				}
+			}
 		}
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *channelDeleteHandlers) addHandler(handler any) {
@@ -576,15 +506,7 @@ func (h *channelPinsUpdateHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("channelPinsUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *channelPinsUpdateHandlers) addHandler(handler any) {
@@ -601,20 +523,25 @@ type threadCreateHandlers struct {
 }
 
 func (h *threadCreateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt ThreadCreateEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := ThreadCreateEvent{Client: client, ShardID: shardID}
+	var payload struct {
+		NewlyCreated bool `json:"newly_created"`
+	}
+	if err := json.Unmarshal(data, &evt.Thread); err != nil {
 		h.logger.Error("threadCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		h.logger.Error("threadCreateHandlers: Failed parsing event data")
+		return
 	}
+	evt.NewlyCreated = payload.NewlyCreated
+
+	if client.Flags().Has(CacheFlagChannels) {
+		client.PutChannel(&evt.Thread)
+	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *threadCreateHandlers) addHandler(handler any) {
@@ -632,15 +559,7 @@ func (h *threadUpdateHandlers) handleEvent(client *Client, runAsync bool, shardI
 		h.logger.Error("threadUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *threadUpdateHandlers) addHandler(handler any) {
@@ -652,21 +571,27 @@ type threadDeleteHandlers struct {
 	handlers []func(ThreadDeleteEvent)
 }
 
+// handleEvent parses the THREAD_DELETE event data, evicts the thread from the cache
+// and calls each registered handler.
+//
+// The event carries the cached thread when available, otherwise only the fields sent by Discord.
 func (h *threadDeleteHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt ThreadDeleteEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := ThreadDeleteEvent{Client: client, ShardID: shardID}
+	if err := json.Unmarshal(data, &evt.Thread); err != nil {
 		h.logger.Error("threadDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
+
+	if channelOpt := client.GetChannel(evt.Thread.ID); channelOpt.IsPresent() {
+		if thread, ok := channelOpt.Get().(*ThreadChannel); ok {
+			evt.Thread = *thread
 		}
 	}
+	if client.Flags().Has(CacheFlagChannels) {
+		client.DelChannel(evt.Thread.ID)
+	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *threadDeleteHandlers) addHandler(handler any) {
@@ -679,46 +604,80 @@ type threadListSyncHandlers struct {
 }
 
 func (h *threadListSyncHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt ThreadListSyncEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	var payload struct {
+		GuildID    Snowflake       `json:"guild_id"`
+		ChannelIDs []Snowflake     `json:"channel_ids"`
+		Threads    []ThreadChannel `json:"threads"`
+		Members    []ThreadMember  `json:"members"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
 		h.logger.Error("threadListSyncHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+	evt := ThreadListSyncEvent{
+		Client:     client,
+		ShardID:    shardID,
+		GuildID:    payload.GuildID,
+		ChannelIDs: payload.ChannelIDs,
+		Threads:    payload.Threads,
+		Members:    payload.Members,
+	}
+
+	if client.Flags().Has(CacheFlagChannels) {
+		syncThreadList(client, evt)
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *threadListSyncHandlers) addHandler(handler any) {
 	h.handlers = append(h.handlers, handler.(func(ThreadListSyncEvent)))
 }
 
+// syncThreadList replaces the cached threads of the synced channels by the threads of evt.
+func syncThreadList(client *Client, evt ThreadListSyncEvent) {
+	synced := make(map[Snowflake]struct{}, len(evt.ChannelIDs))
+	for _, channelID := range evt.ChannelIDs {
+		synced[channelID] = struct{}{}
+	}
+	if channelsOpt := client.GetGuildChannels(evt.GuildID); channelsOpt.IsPresent() {
+		for _, channel := range channelsOpt.Get() {
+			thread, ok := channel.(*ThreadChannel)
+			if !ok {
+				continue
+			}
+			if _, ok := synced[thread.ParentID]; ok || len(synced) == 0 {
+				client.DelChannel(thread.ID)
+			}
+		}
+	}
+	for i := range evt.Threads {
+		thread := evt.Threads[i]
+		thread.GuildID = evt.GuildID
+		client.PutChannel(&thread)
+	}
+}
+
 type threadMemberUpdateHandlers struct {
 	logger   xlog.Logger
 	handlers []func(ThreadMemberUpdateEvent)
 }
 
 func (h *threadMemberUpdateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt ThreadMemberUpdateEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := ThreadMemberUpdateEvent{Client: client, ShardID: shardID}
+	var payload struct {
+		GuildID Snowflake `json:"guild_id"`
+	}
+	if err := json.Unmarshal(data, &evt.Member); err != nil {
 		h.logger.Error("threadMemberUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		h.logger.Error("threadMemberUpdateHandlers: Failed parsing event data")
+		return
 	}
+	evt.GuildID = payload.GuildID
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *threadMemberUpdateHandlers) addHandler(handler any) {
@@ -736,15 +695,7 @@ func (h *threadMembersUpdateHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("threadMembersUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *threadMembersUpdateHandlers) addHandler(handler any) {
@@ -766,15 +717,7 @@ func (h *entitlementCreateHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("entitlementCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *entitlementCreateHandlers) addHandler(handler any) {
@@ -792,15 +735,7 @@ func (h *entitlementUpdateHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("entitlementUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *entitlementUpdateHandlers) addHandler(handler any) {
@@ -818,15 +753,7 @@ func (h *entitlementDeleteHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("entitlementDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *entitlementDeleteHandlers) addHandler(handler any) {
@@ -848,41 +775,48 @@ func (h *guildUpdateHandlers) handleEvent(client *Client, runAsync bool, shardID
 		h.logger.Error("guildUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildUpdateHandlers) addHandler(handler any) {
 	h.handlers = append(h.handlers, handler.(func(GuildUpdateEvent)))
 }
 
+// guildDeleteHandlers manages all registered handlers for GUILD_DELETE events.
 type guildDeleteHandlers struct {
 	logger   xlog.Logger
 	handlers []func(GuildDeleteEvent)
 }
 
+// handleEvent parses the GUILD_DELETE event data, updates the cache and calls each registered handler.
+//
+// If the guild only became unavailable its cached entities are kept as the last-known state
+// and the guild is marked unavailable, otherwise everything related to the guild is evicted.
 func (h *guildDeleteHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildDeleteEvent
+	evt := GuildDeleteEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("guildDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
+
+	if evt.Unavailable {
+		if guildOpt := client.GetGuild(evt.GuildID); guildOpt.IsPresent() {
+			guild := guildOpt.Get()
+			guild.Unavailable = true
+			client.PutGuild(guild)
 		}
 	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
+		client.DelGuild(evt.GuildID)
+		client.DelGuildChannels(evt.GuildID)
+		client.DelGuildMembers(evt.GuildID)
+		if rolesOpt := client.GetGuildRoles(evt.GuildID); rolesOpt.IsPresent() {
+			for roleID := range rolesOpt.Get() {
+				client.DelRole(evt.GuildID, roleID)
+			}
 		}
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildDeleteHandlers) addHandler(handler any) {
@@ -895,20 +829,12 @@ type guildAuditLogEntryCreateHandlers struct {
 }
 
 func (h *guildAuditLogEntryCreateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildAuditLogEntryCreateEvent
+	evt := GuildAuditLogEntryCreateEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("guildAuditLogEntryCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildAuditLogEntryCreateHandlers) addHandler(handler any) {
@@ -926,15 +852,7 @@ func (h *guildBanAddHandlers) handleEvent(client *Client, runAsync bool, shardID
 		h.logger.Error("guildBanAddHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildBanAddHandlers) addHandler(handler any) {
@@ -952,15 +870,7 @@ func (h *guildBanRemoveHandlers) handleEvent(client *Client, runAsync bool, shar
 		h.logger.Error("guildBanRemoveHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildBanRemoveHandlers) addHandler(handler any) {
@@ -978,15 +888,7 @@ func (h *guildEmojisUpdateHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("guildEmojisUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildEmojisUpdateHandlers) addHandler(handler any) {
@@ -1004,15 +906,7 @@ func (h *guildStickersUpdateHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("guildStickersUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildStickersUpdateHandlers) addHandler(handler any) {
@@ -1030,13 +924,7 @@ func (h *guildIntegrationsUpdateHandlers) handleEvent(client *Client, runAsync b
 		h.logger.Error("guildIntegrationsUpdateHandlers: Failed parsing event data")
 		return
 	}
-	for _, handler := range h.handlers {
-		if runAsync {
-			go handler(evt)
-		} else {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildIntegrationsUpdateHandlers) addHandler(handler any) {
@@ -1049,20 +937,40 @@ type guildMemberAddHandlers struct {
 }
 
 func (h *guildMemberAddHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildMemberAddEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := GuildMemberAddEvent{Client: client, ShardID: shardID}
+	if err := json.Unmarshal(data, &evt.Member); err != nil {
 		h.logger.Error("guildMemberAddHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+
+	flags := client.Flags()
+	if flags.Has(CacheFlagMembers) {
+		client.PutMember(evt.Member.Member)
 	}
+	if flags.Has(CacheFlagUsers) {
+		client.PutUser(evt.Member.User)
+	}
+	addCachedMemberCount(client, evt.Member.GuildID, 1)
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
+}
+
+// addCachedMemberCount adds delta to the member count of the guild if it is cached with a known count.
+//
+// Guilds cached from the REST API have no member count, they are left as is.
+func addCachedMemberCount(client *Client, guildID Snowflake, delta int) {
+	defer client.lockCacheUpdate(guildID)()
+
+	guildOpt := client.GetGuild(guildID)
+	if !guildOpt.IsPresent() {
+		return
+	}
+	guild := guildOpt.Get()
+	if guild.MemberCount <= 0 {
+		return
+	}
+	guild.MemberCount = max(guild.MemberCount+delta, 0)
+	client.PutGuild(guild)
 }
 
 func (h *guildMemberAddHandlers) addHandler(handler any) {
@@ -1075,50 +983,82 @@ type guildMemberRemoveHandlers struct {
 }
 
 func (h *guildMemberRemoveHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildMemberRemoveEvent
+	evt := GuildMemberRemoveEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("guildMemberRemoveHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+
+	if member, err := client.GetMember(evt.GuildID, evt.User.ID).GetErr(); err == nil {
+		evt.cachedMember = optional.Some(FullMember{Member: member, User: evt.User})
+		client.DelMember(evt.GuildID, evt.User.ID)
+	}
+	// The user is kept, it may still share other guilds with the bot.
+	if client.Flags().Has(CacheFlagUsers) && client.HasUser(evt.User.ID) {
+		client.PutUser(evt.User)
 	}
+	addCachedMemberCount(client, evt.GuildID, -1)
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildMemberRemoveHandlers) addHandler(handler any) {
 	h.handlers = append(h.handlers, handler.(func(GuildMemberRemoveEvent)))
 }
 
+// guildMemberUpdateHandlers manages all registered handlers for GUILD_MEMBER_UPDATE events,
+// including the MemberPassedScreening handlers derived from it.
 type guildMemberUpdateHandlers struct {
-	logger   xlog.Logger
-	handlers []func(GuildMemberUpdateEvent)
+	logger                  xlog.Logger
+	handlers                []func(GuildMemberUpdateEvent)
+	passedScreeningHandlers []func(MemberPassedScreeningEvent)
 }
 
+// handleEvent parses the GUILD_MEMBER_UPDATE event data, fires MemberPassedScreening
+// if the cached member was pending and calls each registered handler.
 func (h *guildMemberUpdateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildMemberUpdateEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := GuildMemberUpdateEvent{Client: client, ShardID: shardID}
+	if err := json.Unmarshal(data, &evt.Member); err != nil {
 		h.logger.Error("guildMemberUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
+
+	passedScreening := false
+	if oldMemberOpt := client.GetMember(evt.Member.GuildID, evt.Member.ID); oldMemberOpt.IsPresent() {
+		oldMember := FullMember{Member: oldMemberOpt.Get(), User: evt.Member.User}
+		if userOpt := client.GetUser(evt.Member.ID); userOpt.IsPresent() {
+			oldMember.User = userOpt.Get()
 		}
+		evt.OldMember = optional.Some(oldMember)
+		passedScreening = oldMember.Pending && !evt.Member.Pending
+	}
+
+	flags := client.Flags()
+	if flags.Has(CacheFlagMembers) {
+		client.PutMember(evt.Member.Member)
+	}
+	if flags.Has(CacheFlagUsers) {
+		client.PutUser(evt.Member.User)
+	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
+
+	if passedScreening {
+		screeningEvt := MemberPassedScreeningEvent{Client: client, ShardID: shardID, Member: evt.Member}
+		runHandlers(client, h.logger, runAsync, h.passedScreeningHandlers, screeningEvt)
 	}
 }
 
+// addHandler registers a new GUILD_MEMBER_UPDATE or MemberPassedScreening handler function.
+//
+// This method is not thread-safe.
 func (h *guildMemberUpdateHandlers) addHandler(handler any) {
-	h.handlers = append(h.handlers, handler.(func(GuildMemberUpdateEvent)))
+	switch handler := handler.(type) {
+	case func(GuildMemberUpdateEvent):
+		h.handlers = append(h.handlers, handler)
+	case func(MemberPassedScreeningEvent):
+		h.passedScreeningHandlers = append(h.passedScreeningHandlers, handler)
+	}
 }
 
 type guildMembersChunkHandlers struct {
@@ -1127,20 +1067,24 @@ type guildMembersChunkHandlers struct {
 }
 
 func (h *guildMembersChunkHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildMembersChunkEvent
+	evt := GuildMembersChunkEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("guildMembersChunkHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
+
+	flags := client.Flags()
+	for i := range evt.Members {
+		evt.Members[i].GuildID = evt.GuildID
+		if flags.Has(CacheFlagMembers) {
+			client.PutMember(evt.Members[i].Member)
 		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
+		if flags.Has(CacheFlagUsers) {
+			client.PutUser(evt.Members[i].User)
 		}
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildMembersChunkHandlers) addHandler(handler any) {
@@ -1152,21 +1096,27 @@ type guildRoleCreateHandlers struct {
 	handlers []func(GuildRoleCreateEvent)
 }
 
+// guildRolePayload is the payload of the GUILD_ROLE_CREATE and GUILD_ROLE_UPDATE events.
+type guildRolePayload struct {
+	GuildID Snowflake `json:"guild_id"`
+	Role    Role      `json:"role"`
+}
+
 func (h *guildRoleCreateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildRoleCreateEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := GuildRoleCreateEvent{Client: client, ShardID: shardID}
+	var payload guildRolePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
 		h.logger.Error("guildRoleCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+	evt.Role = payload.Role
+	evt.Role.GuildID = payload.GuildID
+
+	if client.Flags().Has(CacheFlagRoles) {
+		client.PutRole(evt.Role)
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildRoleCreateHandlers) addHandler(handler any) {
@@ -1179,20 +1129,27 @@ type guildRoleUpdateHandlers struct {
 }
 
 func (h *guildRoleUpdateHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildRoleUpdateEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := GuildRoleUpdateEvent{Client: client, ShardID: shardID}
+	var payload guildRolePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
 		h.logger.Error("guildRoleUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
+	evt.NewRole = payload.Role
+	evt.NewRole.GuildID = payload.GuildID
+
+	if oldRole, ok := client.GetRoles(evt.NewRole.ID)[evt.NewRole.ID]; ok {
+		evt.OldRole = oldRole
 	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+		evt.OldRole.ID = evt.NewRole.ID
+		evt.OldRole.GuildID = evt.NewRole.GuildID
+	}
+
+	if client.Flags().Has(CacheFlagRoles) {
+		client.PutRole(evt.NewRole)
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildRoleUpdateHandlers) addHandler(handler any) {
@@ -1205,20 +1162,27 @@ type guildRoleDeleteHandlers struct {
 }
 
 func (h *guildRoleDeleteHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt GuildRoleDeleteEvent
-	if err := json.Unmarshal(data, &evt); err != nil {
+	evt := GuildRoleDeleteEvent{Client: client, ShardID: shardID}
+	var payload struct {
+		GuildID Snowflake `json:"guild_id"`
+		RoleID  Snowflake `json:"role_id"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
 		h.logger.Error("guildRoleDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
+
+	if role, ok := client.GetRoles(payload.RoleID)[payload.RoleID]; ok {
+		evt.Role = role
 	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
+		evt.Role.ID = payload.RoleID
+		evt.Role.GuildID = payload.GuildID
+	}
+	if client.Flags().Has(CacheFlagRoles) {
+		client.DelRole(payload.GuildID, payload.RoleID)
 	}
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildRoleDeleteHandlers) addHandler(handler any) {
@@ -1236,15 +1200,7 @@ func (h *guildScheduledEventCreateHandlers) handleEvent(client *Client, runAsync
 		h.logger.Error("guildScheduledEventCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildScheduledEventCreateHandlers) addHandler(handler any) {
@@ -1262,15 +1218,7 @@ func (h *guildScheduledEventUpdateHandlers) handleEvent(client *Client, runAsync
 		h.logger.Error("guildScheduledEventUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildScheduledEventUpdateHandlers) addHandler(handler any) {
@@ -1288,15 +1236,7 @@ func (h *guildScheduledEventDeleteHandlers) handleEvent(client *Client, runAsync
 		h.logger.Error("guildScheduledEventDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildScheduledEventDeleteHandlers) addHandler(handler any) {
@@ -1314,15 +1254,7 @@ func (h *guildScheduledEventUserAddHandlers) handleEvent(client *Client, runAsyn
 		h.logger.Error("guildScheduledEventUserAddHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildScheduledEventUserAddHandlers) addHandler(handler any) {
@@ -1340,15 +1272,7 @@ func (h *guildScheduledEventUserRemoveHandlers) handleEvent(client *Client, runA
 		h.logger.Error("guildScheduledEventUserRemoveHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildScheduledEventUserRemoveHandlers) addHandler(handler any) {
@@ -1366,15 +1290,7 @@ func (h *guildSoundboardSoundCreateHandlers) handleEvent(client *Client, runAsyn
 		h.logger.Error("guildSoundboardSoundCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildSoundboardSoundCreateHandlers) addHandler(handler any) {
@@ -1392,15 +1308,7 @@ func (h *guildSoundboardSoundUpdateHandlers) handleEvent(client *Client, runAsyn
 		h.logger.Error("guildSoundboardSoundUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildSoundboardSoundUpdateHandlers) addHandler(handler any) {
@@ -1418,15 +1326,7 @@ func (h *guildSoundboardSoundDeleteHandlers) handleEvent(client *Client, runAsyn
 		h.logger.Error("guildSoundboardSoundDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildSoundboardSoundDeleteHandlers) addHandler(handler any) {
@@ -1444,15 +1344,7 @@ func (h *guildSoundboardSoundsUpdateHandlers) handleEvent(client *Client, runAsy
 		h.logger.Error("guildSoundboardSoundsUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *guildSoundboardSoundsUpdateHandlers) addHandler(handler any) {
@@ -1470,15 +1362,7 @@ func (h *soundboardSoundsHandlers) handleEvent(client *Client, runAsync bool, sh
 		h.logger.Error("soundboardSoundsHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *soundboardSoundsHandlers) addHandler(handler any) {
@@ -1500,15 +1384,7 @@ func (h *integrationCreateHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("integrationCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *integrationCreateHandlers) addHandler(handler any) {
@@ -1526,15 +1402,7 @@ func (h *integrationUpdateHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("integrationUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *integrationUpdateHandlers) addHandler(handler any) {
@@ -1552,15 +1420,7 @@ func (h *integrationDeleteHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("integrationDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *integrationDeleteHandlers) addHandler(handler any) {
@@ -1582,15 +1442,7 @@ func (h *inviteCreateHandlers) handleEvent(client *Client, runAsync bool, shardI
 		h.logger.Error("inviteCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *inviteCreateHandlers) addHandler(handler any) {
@@ -1608,15 +1460,7 @@ func (h *inviteDeleteHandlers) handleEvent(client *Client, runAsync bool, shardI
 		h.logger.Error("inviteDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *inviteDeleteHandlers) addHandler(handler any) {
@@ -1638,41 +1482,49 @@ func (h *messageDeleteBulkHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("messageDeleteBulkHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *messageDeleteBulkHandlers) addHandler(handler any) {
 	h.handlers = append(h.handlers, handler.(func(MessageDeleteBulkEvent)))
 }
 
+// updateCachedMessage applies update to a copy of the cached message and puts it back in the cache,
+// serialized with the other updates of the message.
+//
+// Does nothing if CacheFlagMessages is disabled or the message is not cached.
+func updateCachedMessage(client *Client, messageID Snowflake, update func(message *Message)) {
+	if !client.Flags().Has(CacheFlagMessages) {
+		return
+	}
+	defer client.lockCacheUpdate(messageID)()
+
+	message, err := client.GetMessage(messageID).GetErr()
+	if err != nil {
+		return
+	}
+	message.Reactions = slices.Clone(message.Reactions)
+	update(&message)
+	client.PutMessage(message)
+}
+
 type messageReactionAddHandlers struct {
 	logger   xlog.Logger
 	handlers []func(MessageReactionAddEvent)
 }
 
 func (h *messageReactionAddHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt MessageReactionAddEvent
+	evt := MessageReactionAddEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("messageReactionAddHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+
+	updateCachedMessage(client, evt.MessageID, func(message *Message) {
+		message.addReaction(evt.Emoji, evt.Burst, evt.UserID == client.SelfID())
+	})
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *messageReactionAddHandlers) addHandler(handler any) {
@@ -1685,20 +1537,17 @@ type messageReactionRemoveHandlers struct {
 }
 
 func (h *messageReactionRemoveHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt MessageReactionRemoveEvent
+	evt := MessageReactionRemoveEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("messageReactionRemoveHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+
+	updateCachedMessage(client, evt.MessageID, func(message *Message) {
+		message.removeReaction(evt.Emoji, evt.Burst, evt.UserID == client.SelfID())
+	})
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *messageReactionRemoveHandlers) addHandler(handler any) {
@@ -1711,20 +1560,17 @@ type messageReactionRemoveAllHandlers struct {
 }
 
 func (h *messageReactionRemoveAllHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt MessageReactionRemoveAllEvent
+	evt := MessageReactionRemoveAllEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("messageReactionRemoveAllHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+
+	updateCachedMessage(client, evt.MessageID, func(message *Message) {
+		message.Reactions = nil
+	})
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *messageReactionRemoveAllHandlers) addHandler(handler any) {
@@ -1737,20 +1583,17 @@ type messageReactionRemoveEmojiHandlers struct {
 }
 
 func (h *messageReactionRemoveEmojiHandlers) handleEvent(client *Client, runAsync bool, shardID int, data []byte) {
-	var evt MessageReactionRemoveEmojiEvent
+	evt := MessageReactionRemoveEmojiEvent{Client: client, ShardID: shardID}
 	if err := json.Unmarshal(data, &evt); err != nil {
 		h.logger.Error("messageReactionRemoveEmojiHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+
+	updateCachedMessage(client, evt.MessageID, func(message *Message) {
+		message.removeReactionEmoji(evt.Emoji)
+	})
+
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *messageReactionRemoveEmojiHandlers) addHandler(handler any) {
@@ -1768,15 +1611,7 @@ func (h *messagePollVoteAddHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("messagePollVoteAddHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *messagePollVoteAddHandlers) addHandler(handler any) {
@@ -1794,15 +1629,7 @@ func (h *messagePollVoteRemoveHandlers) handleEvent(client *Client, runAsync boo
 		h.logger.Error("messagePollVoteRemoveHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *messagePollVoteRemoveHandlers) addHandler(handler any) {
@@ -1824,15 +1651,7 @@ func (h *presenceUpdateHandlers) handleEvent(client *Client, runAsync bool, shar
 		h.logger.Error("presenceUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *presenceUpdateHandlers) addHandler(handler any) {
@@ -1854,15 +1673,7 @@ func (h *stageInstanceCreateHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("stageInstanceCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *stageInstanceCreateHandlers) addHandler(handler any) {
@@ -1880,15 +1691,7 @@ func (h *stageInstanceUpdateHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("stageInstanceUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *stageInstanceUpdateHandlers) addHandler(handler any) {
@@ -1906,15 +1709,7 @@ func (h *stageInstanceDeleteHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("stageInstanceDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *stageInstanceDeleteHandlers) addHandler(handler any) {
@@ -1936,15 +1731,7 @@ func (h *subscriptionCreateHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("subscriptionCreateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *subscriptionCreateHandlers) addHandler(handler any) {
@@ -1962,15 +1749,7 @@ func (h *subscriptionUpdateHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("subscriptionUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *subscriptionUpdateHandlers) addHandler(handler any) {
@@ -1988,15 +1767,7 @@ func (h *subscriptionDeleteHandlers) handleEvent(client *Client, runAsync bool,
 		h.logger.Error("subscriptionDeleteHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *subscriptionDeleteHandlers) addHandler(handler any) {
@@ -2018,15 +1789,7 @@ func (h *typingStartHandlers) handleEvent(client *Client, runAsync bool, shardID
 		h.logger.Error("typingStartHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *typingStartHandlers) addHandler(handler any) {
@@ -2048,15 +1811,7 @@ func (h *userUpdateHandlers) handleEvent(client *Client, runAsync bool, shardID
 		h.logger.Error("userUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *userUpdateHandlers) addHandler(handler any) {
@@ -2078,15 +1833,7 @@ func (h *voiceChannelEffectSendHandlers) handleEvent(client *Client, runAsync bo
 		h.logger.Error("voiceChannelEffectSendHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *voiceChannelEffectSendHandlers) addHandler(handler any) {
@@ -2104,15 +1851,7 @@ func (h *voiceServerUpdateHandlers) handleEvent(client *Client, runAsync bool, s
 		h.logger.Error("voiceServerUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *voiceServerUpdateHandlers) addHandler(handler any) {
@@ -2134,15 +1873,7 @@ func (h *webhooksUpdateHandlers) handleEvent(client *Client, runAsync bool, shar
 		h.logger.Error("webhooksUpdateHandlers: Failed parsing event data")
 		return
 	}
-	if runAsync {
-		for _, handler := range h.handlers {
-			go handler(evt)
-		}
-	} else {
-		for _, handler := range h.handlers {
-			handler(evt)
-		}
-	}
+	runHandlers(client, h.logger, runAsync, h.handlers, evt)
 }
 
 func (h *webhooksUpdateHandlers) addHandler(handler any) {
@@ -24,9 +24,12 @@ import (
 	_ "image/png"
 	"io"
 	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -109,3 +112,113 @@ func NewImageFile(path string) (Base64Image, error) {
 	encoded := base64.StdEncoding.EncodeToString(data)
 	return fmt.Sprintf("data:%s;base64,%s", mimeType, encoded), nil
 }
+
+// File represents a file to upload as a message attachment.
+type File struct {
+	// Name is the filename of the attachment, including its extension.
+	Name string
+
+	// Reader is the source of the file content.
+	Reader io.Reader
+
+	// Description is the optional description of the attachment (max 1024 characters).
+	Description string
+}
+
+// attachmentPayload describes an uploaded or kept attachment in a message payload.
+//
+// For new files, ID is the index of the matching "files[n]" form field.
+type attachmentPayload struct {
+	ID          Snowflake `json:"id"`
+	Filename    string    `json:"filename,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+
+// newAttachmentPayloads returns the attachment descriptors for files, in upload order.
+func newAttachmentPayloads(files []File) []attachmentPayload {
+	attachments := make([]attachmentPayload, len(files))
+	for i, file := range files {
+		attachments[i] = attachmentPayload{
+			ID:          Snowflake(i),
+			Filename:    file.Name,
+			Description: file.Description,
+		}
+	}
+	return attachments
+}
+
+// encodeMultipart builds a multipart/form-data body with payload as "payload_json"
+// followed by files as "files[n]" fields.
+//
+// Returns the body and its Content-Type header value.
+func encodeMultipart(payload []byte, files []File) ([]byte, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="payload_json"`)
+	header.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(payload); err != nil {
+		return nil, "", err
+	}
+
+	for i, file := range files {
+		if file.Reader == nil {
+			return nil, "", fmt.Errorf("file %q has no reader", file.Name)
+		}
+		part, err := writer.CreateFormFile("files["+strconv.Itoa(i)+"]", file.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return nil, "", fmt.Errorf("failed to write file %q: %w", file.Name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// multipartField is a plain text field of a multipart/form-data body.
+type multipartField struct {
+	name  string
+	value string
+}
+
+// encodeMultipartForm builds a multipart/form-data body with plain text fields, in order,
+// followed by file as the fileField field.
+//
+// Returns the body and its Content-Type header value.
+func encodeMultipartForm(fields []multipartField, fileField string, file File) ([]byte, string, error) {
+	if file.Reader == nil {
+		return nil, "", fmt.Errorf("file %q has no reader", file.Name)
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for _, field := range fields {
+		if err := writer.WriteField(field.name, field.value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	part, err := writer.CreateFormFile(fileField, file.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(part, file.Reader); err != nil {
+		return nil, "", fmt.Errorf("failed to write file %q: %w", file.Name, err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
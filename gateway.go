@@ -123,6 +123,138 @@ const (
 	GatewayIntentDirectMessagePolls GatewayIntent = 1 << 25
 )
 
+const (
+	// GatewayIntentsPrivileged are the intents that must be enabled in the Developer Portal,
+	// and approved by Discord for verified bots.
+	GatewayIntentsPrivileged = GatewayIntentGuildMembers | GatewayIntentGuildPresences | GatewayIntentMessageContent
+
+	// GatewayIntentsAll are all the intents.
+	GatewayIntentsAll = GatewayIntentGuilds | GatewayIntentGuildMembers | GatewayIntentGuildModeration |
+		GatewayIntentGuildExpressions | GatewayIntentGuildIntegrations | GatewayIntentGuildWebhooks |
+		GatewayIntentGuildInvites | GatewayIntentGuildVoiceStates | GatewayIntentGuildPresences |
+		GatewayIntentGuildMessages | GatewayIntentGuildMessageReactions | GatewayIntentGuildMessageTyping |
+		GatewayIntentDirectMessages | GatewayIntentDirectMessageReactions | GatewayIntentDirectMessageTyping |
+		GatewayIntentMessageContent | GatewayIntentGuildScheduledEvents |
+		GatewayIntentAutoModerationConfiguration | GatewayIntentAutoModerationExecution |
+		GatewayIntentGuildMessagePolls | GatewayIntentDirectMessagePolls
+
+	// GatewayIntentsAllWithoutPrivileged are all the intents that don't need to be enabled in the Developer Portal.
+	GatewayIntentsAllWithoutPrivileged = GatewayIntentsAll &^ GatewayIntentsPrivileged
+
+	// GatewayIntentsDefault are the intents of a client created without WithIntents.
+	GatewayIntentsDefault = GatewayIntentGuilds | GatewayIntentGuildMessages | GatewayIntentGuildMembers
+)
+
+// Has returns true if all given intents are set.
+func (i GatewayIntent) Has(intents ...GatewayIntent) bool {
+	return BitFieldHas(i, intents...)
+}
+
+// Add sets all given intents.
+func (i *GatewayIntent) Add(intents ...GatewayIntent) {
+	*i = BitFieldAdd(*i, intents...)
+}
+
+// Remove clears all given intents.
+func (i *GatewayIntent) Remove(intents ...GatewayIntent) {
+	*i = BitFieldRemove(*i, intents...)
+}
+
+// eventIntents maps the gateway events to the intents that enable them.
+var eventIntents = map[string]GatewayIntent{
+	"GUILD_CREATE":                      GatewayIntentGuilds,
+	"GUILD_UPDATE":                      GatewayIntentGuilds,
+	"GUILD_DELETE":                      GatewayIntentGuilds,
+	"GUILD_ROLE_CREATE":                 GatewayIntentGuilds,
+	"GUILD_ROLE_UPDATE":                 GatewayIntentGuilds,
+	"GUILD_ROLE_DELETE":                 GatewayIntentGuilds,
+	"CHANNEL_CREATE":                    GatewayIntentGuilds,
+	"CHANNEL_UPDATE":                    GatewayIntentGuilds,
+	"CHANNEL_DELETE":                    GatewayIntentGuilds,
+	"CHANNEL_PINS_UPDATE":               GatewayIntentGuilds | GatewayIntentDirectMessages,
+	"THREAD_CREATE":                     GatewayIntentGuilds,
+	"THREAD_UPDATE":                     GatewayIntentGuilds,
+	"THREAD_DELETE":                     GatewayIntentGuilds,
+	"THREAD_LIST_SYNC":                  GatewayIntentGuilds,
+	"THREAD_MEMBER_UPDATE":              GatewayIntentGuilds,
+	"THREAD_MEMBERS_UPDATE":             GatewayIntentGuilds | GatewayIntentGuildMembers,
+	"STAGE_INSTANCE_CREATE":             GatewayIntentGuilds,
+	"STAGE_INSTANCE_UPDATE":             GatewayIntentGuilds,
+	"STAGE_INSTANCE_DELETE":             GatewayIntentGuilds,
+	"GUILD_MEMBER_ADD":                  GatewayIntentGuildMembers,
+	"GUILD_MEMBER_UPDATE":               GatewayIntentGuildMembers,
+	"GUILD_MEMBER_REMOVE":               GatewayIntentGuildMembers,
+	"GUILD_AUDIT_LOG_ENTRY_CREATE":      GatewayIntentGuildModeration,
+	"GUILD_BAN_ADD":                     GatewayIntentGuildModeration,
+	"GUILD_BAN_REMOVE":                  GatewayIntentGuildModeration,
+	"GUILD_EMOJIS_UPDATE":               GatewayIntentGuildExpressions,
+	"GUILD_STICKERS_UPDATE":             GatewayIntentGuildExpressions,
+	"GUILD_SOUNDBOARD_SOUND_CREATE":     GatewayIntentGuildExpressions,
+	"GUILD_SOUNDBOARD_SOUND_UPDATE":     GatewayIntentGuildExpressions,
+	"GUILD_SOUNDBOARD_SOUND_DELETE":     GatewayIntentGuildExpressions,
+	"GUILD_SOUNDBOARD_SOUNDS_UPDATE":    GatewayIntentGuildExpressions,
+	"GUILD_INTEGRATIONS_UPDATE":         GatewayIntentGuildIntegrations,
+	"INTEGRATION_CREATE":                GatewayIntentGuildIntegrations,
+	"INTEGRATION_UPDATE":                GatewayIntentGuildIntegrations,
+	"INTEGRATION_DELETE":                GatewayIntentGuildIntegrations,
+	"WEBHOOKS_UPDATE":                   GatewayIntentGuildWebhooks,
+	"INVITE_CREATE":                     GatewayIntentGuildInvites,
+	"INVITE_DELETE":                     GatewayIntentGuildInvites,
+	"VOICE_CHANNEL_EFFECT_SEND":         GatewayIntentGuildVoiceStates,
+	"VOICE_STATE_UPDATE":                GatewayIntentGuildVoiceStates,
+	"PRESENCE_UPDATE":                   GatewayIntentGuildPresences,
+	"MESSAGE_CREATE":                    GatewayIntentGuildMessages | GatewayIntentDirectMessages,
+	"MESSAGE_UPDATE":                    GatewayIntentGuildMessages | GatewayIntentDirectMessages,
+	"MESSAGE_DELETE":                    GatewayIntentGuildMessages | GatewayIntentDirectMessages,
+	"MESSAGE_DELETE_BULK":               GatewayIntentGuildMessages,
+	"MESSAGE_REACTION_ADD":              GatewayIntentGuildMessageReactions | GatewayIntentDirectMessageReactions,
+	"MESSAGE_REACTION_REMOVE":           GatewayIntentGuildMessageReactions | GatewayIntentDirectMessageReactions,
+	"MESSAGE_REACTION_REMOVE_ALL":       GatewayIntentGuildMessageReactions | GatewayIntentDirectMessageReactions,
+	"MESSAGE_REACTION_REMOVE_EMOJI":     GatewayIntentGuildMessageReactions | GatewayIntentDirectMessageReactions,
+	"TYPING_START":                      GatewayIntentGuildMessageTyping | GatewayIntentDirectMessageTyping,
+	"GUILD_SCHEDULED_EVENT_CREATE":      GatewayIntentGuildScheduledEvents,
+	"GUILD_SCHEDULED_EVENT_UPDATE":      GatewayIntentGuildScheduledEvents,
+	"GUILD_SCHEDULED_EVENT_DELETE":      GatewayIntentGuildScheduledEvents,
+	"GUILD_SCHEDULED_EVENT_USER_ADD":    GatewayIntentGuildScheduledEvents,
+	"GUILD_SCHEDULED_EVENT_USER_REMOVE": GatewayIntentGuildScheduledEvents,
+	"AUTO_MODERATION_RULE_CREATE":       GatewayIntentAutoModerationConfiguration,
+	"AUTO_MODERATION_RULE_UPDATE":       GatewayIntentAutoModerationConfiguration,
+	"AUTO_MODERATION_RULE_DELETE":       GatewayIntentAutoModerationConfiguration,
+	"AUTO_MODERATION_ACTION_EXECUTION":  GatewayIntentAutoModerationExecution,
+	"MESSAGE_POLL_VOTE_ADD":             GatewayIntentGuildMessagePolls | GatewayIntentDirectMessagePolls,
+	"MESSAGE_POLL_VOTE_REMOVE":          GatewayIntentGuildMessagePolls | GatewayIntentDirectMessagePolls,
+}
+
+// IntentWarning reports a registered event handler that the configured intents prevent from working as expected.
+type IntentWarning struct {
+	// Event is the gateway event name of the handler, e.g. "GUILD_MEMBER_ADD".
+	Event string
+	// Missing are the intents of which at least one is needed.
+	Missing GatewayIntent
+	// Reason describes the consequence of the missing intents.
+	Reason string
+}
+
+// String returns a human readable description of the warning.
+func (w IntentWarning) String() string {
+	return w.Event + " handler: " + w.Reason
+}
+
+// RequiredIntentsFor returns the intents that enable the given gateway event, e.g. "MESSAGE_CREATE".
+//
+// Any one of the returned intents is enough, for example GatewayIntentGuildMessages enables
+// MESSAGE_CREATE for guild messages and GatewayIntentDirectMessages for direct messages.
+// Returns 0 for events sent regardless of intents, like READY or INTERACTION_CREATE.
+//
+// Example:
+//
+//	if required := dwaz.RequiredIntentsFor("MESSAGE_CREATE"); required != 0 && intents&required == 0 {
+//		log.Println("MESSAGE_CREATE handlers will never run")
+//	}
+func RequiredIntentsFor(eventName string) GatewayIntent {
+	return eventIntents[eventName]
+}
+
 // gatewayOpcode represents the operation codes used in Discord Gateway WebSocket frames.
 //
 // Each opcode defines a specific action or message type in the client-server communication.
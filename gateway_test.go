@@ -0,0 +1,117 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import "testing"
+
+func TestGatewayIntentsPresets(t *testing.T) {
+	if GatewayIntentsAllWithoutPrivileged&GatewayIntentsPrivileged != 0 {
+		t.Fatalf("GatewayIntentsAllWithoutPrivileged contains privileged intents: %b", GatewayIntentsAllWithoutPrivileged&GatewayIntentsPrivileged)
+	}
+	if GatewayIntentsAllWithoutPrivileged|GatewayIntentsPrivileged != GatewayIntentsAll {
+		t.Fatal("GatewayIntentsAllWithoutPrivileged and GatewayIntentsPrivileged don't make up GatewayIntentsAll")
+	}
+	for i := range 26 {
+		intent := GatewayIntent(1 << i)
+		if i == 11 || i == 17 || i == 18 || i == 19 || i == 22 || i == 23 {
+			continue // unused bits
+		}
+		if !GatewayIntentsAll.Has(intent) {
+			t.Errorf("GatewayIntentsAll is missing intent 1<<%d", i)
+		}
+	}
+	if !GatewayIntentsPrivileged.Has(GatewayIntentGuildMembers, GatewayIntentGuildPresences, GatewayIntentMessageContent) {
+		t.Error("GatewayIntentsPrivileged is missing a privileged intent")
+	}
+}
+
+func TestGatewayIntentAddRemove(t *testing.T) {
+	var intents GatewayIntent
+	intents.Add(GatewayIntentGuilds, GatewayIntentGuildMessages)
+	if !intents.Has(GatewayIntentGuilds, GatewayIntentGuildMessages) {
+		t.Fatalf("Add did not set intents: %b", intents)
+	}
+	intents.Remove(GatewayIntentGuilds)
+	if intents.Has(GatewayIntentGuilds) || !intents.Has(GatewayIntentGuildMessages) {
+		t.Fatalf("Remove cleared the wrong intents: %b", intents)
+	}
+}
+
+func TestRequiredIntentsFor(t *testing.T) {
+	tests := map[string]GatewayIntent{
+		"GUILD_CREATE":             GatewayIntentGuilds,
+		"GUILD_MEMBER_ADD":         GatewayIntentGuildMembers,
+		"PRESENCE_UPDATE":          GatewayIntentGuildPresences,
+		"MESSAGE_CREATE":           GatewayIntentGuildMessages | GatewayIntentDirectMessages,
+		"MESSAGE_REACTION_ADD":     GatewayIntentGuildMessageReactions | GatewayIntentDirectMessageReactions,
+		"TYPING_START":             GatewayIntentGuildMessageTyping | GatewayIntentDirectMessageTyping,
+		"GUILD_BAN_ADD":            GatewayIntentGuildModeration,
+		"VOICE_STATE_UPDATE":       GatewayIntentGuildVoiceStates,
+		"MESSAGE_POLL_VOTE_ADD":    GatewayIntentGuildMessagePolls | GatewayIntentDirectMessagePolls,
+		"READY":                    0,
+		"INTERACTION_CREATE":       0,
+		"NOT_A_REAL_GATEWAY_EVENT": 0,
+	}
+	for event, want := range tests {
+		if got := RequiredIntentsFor(event); got != want {
+			t.Errorf("RequiredIntentsFor(%q) = %b, want %b", event, got, want)
+		}
+	}
+	for event, intents := range eventIntents {
+		if intents&^GatewayIntentsAll != 0 {
+			t.Errorf("%s maps to unknown intents %b", event, intents&^GatewayIntentsAll)
+		}
+	}
+}
+
+func TestVerifyIntents(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.intents = GatewayIntentGuilds | GatewayIntentGuildMessages
+	client.OnGuildMemberAdd(func(GuildMemberAddEvent) {})
+	client.OnGuildUpdate(func(GuildUpdateEvent) {})
+	client.OnInteractionCreate(func(InteractionCreateEvent) {})
+
+	warnings := client.VerifyIntents()
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", warnings)
+	}
+	if w := warnings[0]; w.Event != "GUILD_MEMBER_ADD" || w.Missing != GatewayIntentGuildMembers {
+		t.Fatalf("unexpected warning: %+v", w)
+	}
+
+	client.intents.Add(GatewayIntentGuildMembers)
+	if warnings := client.VerifyIntents(); len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+}
+
+func TestVerifyIntentsIgnoresCacheOnlyHandlers(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.intents = 0 // GUILD_MEMBER_ADD and others have handlers managers for caching only
+
+	if warnings := client.VerifyIntents(); len(warnings) != 0 {
+		t.Fatalf("expected no warnings without registered handlers, got %v", warnings)
+	}
+}
+
+func TestVerifyIntentsMessageContent(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.intents = GatewayIntentGuildMessages
+	client.OnMessageCreate(func(MessageCreateEvent) {})
+
+	warnings := client.VerifyIntents()
+	if len(warnings) != 1 || warnings[0].Event != "MESSAGE_CREATE" || warnings[0].Missing != GatewayIntentMessageContent {
+		t.Fatalf("expected a MessageContent warning, got %v", warnings)
+	}
+}
@@ -15,10 +15,16 @@ package dwaz
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"maps"
+	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -319,6 +325,13 @@ type Guild struct {
 	// Unavailable is whether this guild is available or not.
 	Unavailable bool `json:"unavailable"`
 
+	// MemberCount is the total number of members in this guild.
+	//
+	// Optional:
+	//  - Only sent in GUILD_CREATE, 0 for guilds fetched over REST.
+	//  - Kept up to date in the cache on GUILD_MEMBER_ADD and GUILD_MEMBER_REMOVE.
+	MemberCount int `json:"member_count,omitempty"`
+
 	// Name is the guild's name.
 	Name string `json:"name"`
 
@@ -469,6 +482,62 @@ func (g *Guild) CreatedAt() time.Time {
 	return g.ID.Timestamp()
 }
 
+// EveryoneRoleID returns the ID of the guild's @everyone role, which is always the guild ID.
+func (g *Guild) EveryoneRoleID() Snowflake {
+	return g.ID
+}
+
+// HasFeature reports whether the guild has the given feature enabled.
+func (g *Guild) HasFeature(feature GuildFeature) bool {
+	return slices.Contains(g.Features, feature)
+}
+
+// AfkChannel returns the ID of the guild's afk channel, None if not set.
+func (g *Guild) AfkChannel() optional.Option[Snowflake] {
+	return g.AfkChannelID.Option()
+}
+
+// WidgetChannel returns the ID of the channel the widget generates invites to, None if not set.
+func (g *Guild) WidgetChannel() optional.Option[Snowflake] {
+	return g.WidgetChannelID.Option()
+}
+
+// SystemChannel returns the ID of the guild's system channel, None if not set.
+func (g *Guild) SystemChannel() optional.Option[Snowflake] {
+	return g.SystemChannelID.Option()
+}
+
+// RulesChannel returns the ID of the guild's rules channel, None if not set.
+func (g *Guild) RulesChannel() optional.Option[Snowflake] {
+	return g.RulesChannelID.Option()
+}
+
+// PublicUpdatesChannel returns the ID of the channel receiving notices from Discord, None if not set.
+func (g *Guild) PublicUpdatesChannel() optional.Option[Snowflake] {
+	return g.PublicUpdatesChannelID.Option()
+}
+
+// SafetyAlertsChannel returns the ID of the channel receiving safety alerts from Discord, None if not set.
+func (g *Guild) SafetyAlertsChannel() optional.Option[Snowflake] {
+	return g.SafetyAlertsChannelID.Option()
+}
+
+// VanityInviteURL returns the vanity invite link of the guild, e.g. https://discord.gg/discord-developers.
+//
+// Returns None if the guild has no vanity url code or lacks the GuildFeatureVanityURL feature.
+func (g *Guild) VanityInviteURL() optional.Option[string] {
+	return vanityInviteURL(g.VanityURLCode, g.Features)
+}
+
+// vanityInviteURL returns the invite link of a vanity url code, None if code is empty or
+// the GuildFeatureVanityURL feature is missing.
+func vanityInviteURL(code string, features []GuildFeature) optional.Option[string] {
+	if code == "" || !slices.Contains(features, GuildFeatureVanityURL) {
+		return optional.None[string]()
+	}
+	return optional.Some("https://discord.gg/" + code)
+}
+
 // IconURL returns the URL to the guild's icon image.
 //
 // If the guild has a custom icon set, it returns the URL to that icon, otherwise empty string.
@@ -903,9 +972,6 @@ type GatewayGuild struct {
 	// Large if true this is considered a large guild.
 	Large bool `json:"large"`
 
-	// MemberCount is the total number of members in this guild.
-	MemberCount int `json:"member_count"`
-
 	// VoiceStates is the states of members currently in voice channels; lacks the GuildID key.
 	VoiceStates []VoiceState `json:"voice_states"`
 
@@ -932,7 +998,6 @@ func (g *GatewayGuild) UnmarshalJSON(buf []byte) error {
 	type tempGuild struct {
 		RestGuild
 		Large            bool              `json:"large"`
-		MemberCount      int               `json:"member_count"`
 		VoiceStates      []VoiceState      `json:"voice_states"`
 		Members          []FullMember      `json:"members"`
 		Channels         []json.RawMessage `json:"channels"`
@@ -948,38 +1013,56 @@ func (g *GatewayGuild) UnmarshalJSON(buf []byte) error {
 
 	g.RestGuild = temp.RestGuild
 	g.Large = temp.Large
-	g.MemberCount = temp.MemberCount
 	g.VoiceStates = temp.VoiceStates
 	g.Members = temp.Members
 	g.Threads = temp.Threads
 	g.StageInstances = temp.StageInstances
 	g.SoundboardSounds = temp.SoundboardSounds
 
-	for i := range len(g.Roles) {
+	// Objects nested in GUILD_CREATE omit their guild_id, every one that has a GuildID gets the guild's.
+	for i := range g.Roles {
 		g.Roles[i].GuildID = g.ID
 	}
-	for i := range len(g.Members) {
+	for i := range g.Emojis {
+		g.Emojis[i].GuildID = g.ID
+	}
+	for i := range g.Stickers {
+		g.Stickers[i].GuildID = g.ID
+	}
+	for i := range g.Members {
 		g.Members[i].GuildID = g.ID
 	}
-	for i := range len(g.VoiceStates) {
+	for i := range g.VoiceStates {
 		g.VoiceStates[i].GuildID = g.ID
 	}
+	for i := range g.Threads {
+		g.Threads[i].GuildID = g.ID
+	}
+	for i := range g.StageInstances {
+		g.StageInstances[i].GuildID = g.ID
+	}
+	for i := range g.SoundboardSounds {
+		g.SoundboardSounds[i].GuildID = g.ID
+	}
 
 	if temp.Channels != nil {
 		g.Channels = make([]GuildChannel, 0, len(temp.Channels))
-		for i := range len(temp.Channels) {
-			if len(temp.Channels[i]) == 0 || bytes.Equal(temp.Channels[i], []byte("null")) {
+		for _, raw := range temp.Channels {
+			if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
 				continue
 			}
-			channel, err := UnmarshalChannel(temp.Channels[i])
+			channel, err := UnmarshalChannel(raw)
 			if err != nil {
 				return err
 			}
-			if guildCh, ok := channel.(GuildChannel); ok {
-				g.Channels = append(g.Channels, guildCh)
-			} else {
+			guildCh, ok := channel.(GuildChannel)
+			if !ok {
 				return errors.New("cannot unmarshal non-GuildChannel into GuildChannel")
 			}
+			if ch, ok := guildCh.(interface{ setGuildID(Snowflake) }); ok {
+				ch.setGuildID(g.ID)
+			}
+			g.Channels = append(g.Channels, guildCh)
 		}
 	}
 
@@ -1013,6 +1096,19 @@ type PartialGuild struct {
 
 	// Features is the enabled guild features.
 	Features []GuildFeature `json:"features"`
+
+	// VanityURLCode is the vanity url code for the guild.
+	//
+	// Optional:
+	//  - May be empty string if no vanity url code is set, or if not sent (e.g. in interactions).
+	VanityURLCode string `json:"vanity_url_code"`
+}
+
+// VanityInviteURL returns the vanity invite link of the guild, e.g. https://discord.gg/discord-developers.
+//
+// Returns None if the guild has no vanity url code or lacks the GuildFeatureVanityURL feature.
+func (g *PartialGuild) VanityInviteURL() optional.Option[string] {
+	return vanityInviteURL(g.VanityURLCode, g.Features)
 }
 
 // IconURL returns the URL to the guild's icon image.
@@ -1045,6 +1141,100 @@ func (g *PartialGuild) BannerURL() string {
 	return ""
 }
 
+// CreateGuildOptions contains parameters for creating a guild.
+//
+// Note:
+//   - Channels and Roles may use placeholder integer IDs (starting from 1 for roles, where 0 is @everyone)
+//     so they can reference each other (e.g. ParentID, AfkChannelID, SystemChannelID or permission overwrites).
+//
+// Usage example:
+//
+//	opts := dwaz.CreateGuildOptions{
+//		Name: "My Guild",
+//		Channels: []dwaz.CreateGuildChannelOptions{
+//			{ID: 1, CreateChannelOptions: dwaz.CreateChannelOptions{Name: "Text Channels", Type: dwaz.ChannelTypeGuildCategory}},
+//			{CreateChannelOptions: dwaz.CreateChannelOptions{Name: "general", ParentID: 1}},
+//		},
+//	}
+//
+// Reference: https://discord.com/developers/docs/resources/guild#create-guild
+type CreateGuildOptions struct {
+	// Name is the name of the guild (2-100 characters).
+	Name string `json:"name"`
+
+	// Icon is the base64 128x128 image for the guild icon.
+	Icon Base64Image `json:"icon,omitempty"`
+
+	// VerificationLevel is the guild's verification level.
+	VerificationLevel optional.Option[VerificationLevel] `json:"verification_level,omitzero"`
+
+	// DefaultMessageNotifications is the default message notification level.
+	DefaultMessageNotifications optional.Option[MessageNotificationsLevel] `json:"default_message_notifications,omitzero"`
+
+	// ExplicitContentFilter is the explicit content filter level.
+	ExplicitContentFilter optional.Option[ExplicitContentFilterLevel] `json:"explicit_content_filter,omitzero"`
+
+	// Roles are the new guild roles.
+	//
+	// Note:
+	//   - The first role of the slice is used to configure the @everyone role.
+	Roles []Role `json:"roles,omitempty"`
+
+	// Channels are the new guild's channels.
+	Channels []CreateGuildChannelOptions `json:"channels,omitempty"`
+
+	// AfkChannelID is the placeholder id of the afk channel.
+	AfkChannelID Snowflake `json:"afk_channel_id,omitempty"`
+
+	// AfkTimeout is the afk timeout in seconds.
+	AfkTimeout AfkTimeout `json:"afk_timeout,omitempty"`
+
+	// SystemChannelID is the placeholder id of the channel where guild notices are posted.
+	SystemChannelID Snowflake `json:"system_channel_id,omitempty"`
+
+	// SystemChannelFlags are the system channel flags.
+	SystemChannelFlags SystemChannelFlags `json:"system_channel_flags,omitempty"`
+}
+
+// CreateGuildChannelOptions is a channel created along with the guild by CreateGuild.
+type CreateGuildChannelOptions struct {
+	// ID is the placeholder ID of the channel, referenced by the ParentID of the channels nested
+	// under this category and by the AfkChannelID and SystemChannelID of CreateGuildOptions.
+	//
+	// Optional:
+	//   - Only needed for channels referenced by other fields.
+	ID Snowflake `json:"id,omitempty"`
+
+	CreateChannelOptions
+}
+
+// CreateGuild creates a new guild with the bot as its owner.
+//
+// Note:
+//   - This endpoint can be used only by bots in less than 10 guilds.
+//
+// Reference: https://discord.com/developers/docs/resources/guild#create-guild
+func (r *requester) CreateGuild(opts CreateGuildOptions) result.Result[RestGuild] {
+	reqBody, _ := json.Marshal(opts)
+	res := r.DoRequest(Request{Method: "POST", URL: "/guilds", Body: reqBody})
+	if res.IsErr() {
+		return result.Err[RestGuild](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var guild RestGuild
+	if err := json.NewDecoder(body).Decode(&guild); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/guilds",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[RestGuild](err)
+	}
+	return result.Ok(guild)
+}
+
 // FetchGuildOptions contains parameters for fetching a guild.
 type FetchGuildOptions struct {
 	// When 'true', will return approximate member and presence counts for the guild
@@ -1055,9 +1245,16 @@ type FetchGuildOptions struct {
 //
 // Reference: https://discord.com/developers/docs/resources/guild#get-guild
 func (r *requester) FetchGuild(guildID Snowflake, opts FetchGuildOptions) result.Result[RestGuild] {
+	return r.FetchGuildCtx(context.Background(), guildID, opts)
+}
+
+// FetchGuildCtx is like FetchGuild but aborts when ctx is canceled, returning ctx.Err().
+//
+// Reference: https://discord.com/developers/docs/resources/guild#get-guild
+func (r *requester) FetchGuildCtx(ctx context.Context, guildID Snowflake, opts FetchGuildOptions) result.Result[RestGuild] {
 	endpoint := "/guilds/" + guildID.String() + "?with_counts=" + strconv.FormatBool(opts.WithCounts)
 
-	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	res := r.DoRequestCtx(ctx, Request{Method: "GET", URL: endpoint})
 	if res.IsErr() {
 		return result.Err[RestGuild](res.Err())
 	}
@@ -1076,6 +1273,34 @@ func (r *requester) FetchGuild(guildID Snowflake, opts FetchGuildOptions) result
 	return result.Ok(guild)
 }
 
+// Guild returns a guild from the cache, falling back to FetchGuild on a cache miss.
+//
+// The fetched guild is put in the cache if CacheFlagGuilds is enabled, and its roles if CacheFlagRoles is.
+// Use GetGuild to look up the cache only.
+func (c *Client) Guild(guildID Snowflake) result.Result[Guild] {
+	if guild := c.GetGuild(guildID); guild.IsPresent() {
+		return result.Ok(guild.Get())
+	}
+	res := c.FetchGuild(guildID, FetchGuildOptions{})
+	if res.IsErr() {
+		return result.Err[Guild](res.Err())
+	}
+
+	guild := res.Value()
+	flags := c.Flags()
+	if flags.Has(CacheFlagGuilds) {
+		c.PutGuild(guild.Guild)
+	}
+	if flags.Has(CacheFlagRoles) {
+		for i := range guild.Roles {
+			guild.Roles[i].GuildID = guildID
+		}
+		c.PutRoles(guild.Roles...)
+		c.markGuildRolesComplete(guildID)
+	}
+	return result.Ok(guild.Guild)
+}
+
 // FetchGuildPreview retrieves a guild preview by its ID.
 //
 // Reference: https://discord.com/developers/docs/resources/guild#get-guild-preview
@@ -1101,6 +1326,33 @@ func (r *requester) FetchGuildPreview(guildID Snowflake) result.Result[GuildPrev
 	return result.Ok(preview)
 }
 
+// MemberCountViaPreview returns the approximate member count of a guild from FetchGuildPreview.
+//
+// An exact count is only available through the gateway, see MemberCount, or by requesting every member
+// with the privileged GatewayIntentGuildMembers intent.
+func (c *Client) MemberCountViaPreview(guildID Snowflake) result.Result[int] {
+	res := c.FetchGuildPreview(guildID)
+	if res.IsErr() {
+		return result.Err[int](res.Err())
+	}
+	return result.Ok(res.Value().ApproximateMemberCount)
+}
+
+// MemberCount returns the member count of a guild, reporting whether it's exact.
+//
+// The count is exact when the guild is cached with its gateway member count, kept up to date on
+// member joins and leaves, otherwise it falls back to the approximate count of MemberCountViaPreview.
+func (c *Client) MemberCount(guildID Snowflake) (n int, exact bool, err error) {
+	if guild := c.GetGuild(guildID); guild.IsPresent() && guild.Get().MemberCount > 0 {
+		return guild.Get().MemberCount, true, nil
+	}
+	res := c.MemberCountViaPreview(guildID)
+	if res.IsErr() {
+		return 0, false, res.Err()
+	}
+	return res.Value(), false, nil
+}
+
 type AfkTimeout int
 
 const (
@@ -1206,6 +1458,32 @@ func (r *requester) FetchGuildChannels(guildID Snowflake) result.Result[[]GuildC
 	return result.Ok(channels)
 }
 
+// GuildChannelsOrCached returns the channels of a guild, preferring the cache over REST.
+//
+// The cache is used when the guild's channels are cached and the guild wasn't removed
+// (left or kicked), even if the guild is currently unavailable due to an outage.
+// On a cache miss the channels are fetched with FetchGuildChannels and written to the cache.
+//
+// Note:
+//   - Cached channels are the last-known state, during an outage they may be stale.
+//     Use FetchGuildChannels directly when you need up-to-date data.
+//   - The order of the returned channels is not guaranteed.
+func (c *Client) GuildChannelsOrCached(guildID Snowflake) result.Result[[]GuildChannel] {
+	if c.HasGuild(guildID) {
+		if cached := c.GetGuildChannels(guildID); cached.IsPresent() {
+			return result.Ok(slices.Collect(maps.Values(cached.Get())))
+		}
+	}
+
+	res := c.FetchGuildChannels(guildID)
+	if res.IsOk() && c.Flags().Has(CacheFlagChannels) {
+		for _, channel := range res.Value() {
+			c.PutChannel(channel)
+		}
+	}
+	return res
+}
+
 // CreateChannelOptions defines the configuration for creating a new Discord guild channel.
 //
 // Note:
@@ -1334,6 +1612,12 @@ type CreateChannelOptions struct {
 	// Applies to Channels of Type: Text, Announcement, Forum, Media.
 	DefaultThreadRateLimitPerUser int `json:"default_thread_rate_limit_per_user,omitzero"`
 
+	// NormalizeName runs Name through NormalizeChannelName before the request, so the name
+	// returned by Discord is known in advance.
+	//
+	// Applies to Channels of Type: Text, Announcement, Forum, Media.
+	NormalizeName bool `json:"-"`
+
 	// Reason specifies the audit log reason for creating the channel.
 	Reason string `json:"-"`
 }
@@ -1344,6 +1628,9 @@ type CreateChannelOptions struct {
 //
 // Reference: https://discord.com/developers/docs/resources/guild#create-guild-channel
 func (r *requester) CreateChannel(guildID Snowflake, opts CreateChannelOptions) result.Result[GuildChannel] {
+	if opts.NormalizeName && opts.normalizesName() {
+		opts.Name = NormalizeChannelName(opts.Name)
+	}
 	reqBody, _ := json.Marshal(opts)
 	res := r.DoRequest(Request{
 		Method: "POST",
@@ -1377,6 +1664,177 @@ func (r *requester) CreateChannel(guildID Snowflake, opts CreateChannelOptions)
 	return result.Err[GuildChannel](errors.New("created channel is not a guild channel"))
 }
 
+// Validate checks that the options only set fields applicable to the channel type.
+//
+// Discord silently ignores most fields that don't apply to the created channel type,
+// Validate reports them instead so misconfigurations are caught early.
+func (o *CreateChannelOptions) Validate() error {
+	name := o.Name
+	if o.NormalizeName && o.normalizesName() {
+		name = NormalizeChannelName(name)
+	}
+	if err := ValidateChannelName(name); err != nil {
+		return fmt.Errorf("CreateChannelOptions: %w", err)
+	}
+	if o.RateLimitPerUser < 0 || o.RateLimitPerUser > 21600 {
+		return fmt.Errorf("CreateChannelOptions: rate limit per user must be 0-21600 seconds, got %d", o.RateLimitPerUser)
+	}
+
+	typ := o.Type
+	in := func(types ...ChannelType) bool { return slices.Contains(types, typ) }
+	textual := in(ChannelTypeGuildText, ChannelTypeGuildAnnouncement, ChannelTypeGuildForum, ChannelTypeGuildMedia)
+	audio := in(ChannelTypeGuildVoice, ChannelTypeGuildStageVoice)
+	forum := in(ChannelTypeGuildForum, ChannelTypeGuildMedia)
+
+	switch {
+	case o.Topic != "" && !textual:
+		return fmt.Errorf("CreateChannelOptions: topic does not apply to channel type %d", typ)
+	case (o.Bitrate != 0 || o.UserLimit != 0 || o.RTCRegion != "" || o.VideoQualityMode != 0) && !audio:
+		return fmt.Errorf("CreateChannelOptions: voice settings do not apply to channel type %d", typ)
+	case o.ParentID != 0 && typ == ChannelTypeGuildCategory:
+		return errors.New("CreateChannelOptions: a category can't have a parent category")
+	case o.Nsfw && !in(ChannelTypeGuildText, ChannelTypeGuildVoice, ChannelTypeGuildAnnouncement, ChannelTypeGuildStageVoice, ChannelTypeGuildForum):
+		return fmt.Errorf("CreateChannelOptions: nsfw does not apply to channel type %d", typ)
+	case (len(o.AvailableTags) > 0 || o.DefaultReactionEmoji.IsPresent() || o.DefaultSortOrder.IsPresent()) && !forum:
+		return fmt.Errorf("CreateChannelOptions: forum settings do not apply to channel type %d", typ)
+	case o.DefaultForumLayout != 0 && typ != ChannelTypeGuildForum:
+		return fmt.Errorf("CreateChannelOptions: default forum layout does not apply to channel type %d", typ)
+	}
+	return nil
+}
+
+// normalizesName reports whether Discord normalizes the name of the created channel type.
+func (o *CreateChannelOptions) normalizesName() bool {
+	switch o.Type {
+	case ChannelTypeGuildText, ChannelTypeGuildAnnouncement, ChannelTypeGuildForum, ChannelTypeGuildMedia:
+		return true
+	}
+	return false
+}
+
+// CloneChannelOptions contains parameters for cloning a guild channel.
+type CloneChannelOptions struct {
+	// Name is the name of the clone, the source channel name is used if empty.
+	Name string
+
+	// ParentID is the category to create the clone in, the source channel category is used if 0.
+	ParentID Snowflake
+
+	// Reason specifies the audit log reason for creating the channel.
+	Reason string
+}
+
+// CloneChannel creates a copy of a guild channel in the same guild, with its type, topic,
+// permission overwrites, slowmode, nsfw, voice settings and forum tags.
+//
+// The source channel is read from the cache, or fetched if it's not cached.
+// Threads can't be cloned.
+//
+// Requires the PermissionManageChannels permission.
+func (c *Client) CloneChannel(channelID Snowflake, opts CloneChannelOptions) result.Result[GuildChannel] {
+	var source Channel
+	if cached := c.GetChannel(channelID); cached.IsPresent() {
+		source = cached.Get()
+	} else {
+		res := c.FetchChannel(channelID)
+		if res.IsErr() {
+			return result.Err[GuildChannel](res.Err())
+		}
+		source = res.Value()
+	}
+
+	createOpts, ok := cloneChannelOptions(source)
+	if !ok {
+		return result.Err[GuildChannel](fmt.Errorf("CloneChannel: channel %s of type %d can't be cloned", channelID, source.GetType()))
+	}
+	if opts.Name != "" {
+		createOpts.Name = opts.Name
+	}
+	if opts.ParentID != 0 {
+		createOpts.ParentID = opts.ParentID
+	}
+	createOpts.Reason = opts.Reason
+
+	if err := createOpts.Validate(); err != nil {
+		return result.Err[GuildChannel](err)
+	}
+	return c.CreateChannel(source.(GuildChannel).GetGuildID(), createOpts)
+}
+
+// cloneChannelOptions returns the options to create a copy of channel, ok is false for channels that can't be cloned.
+func cloneChannelOptions(channel Channel) (opts CreateChannelOptions, ok bool) {
+	fromGuildChannel := func(c *GuildChannelFields) {
+		opts.Name = c.Name
+		opts.Type = c.Type
+		opts.PermissionOverwrites = slices.Clone(c.PermissionOverwrites)
+	}
+	fromMessageChannel := func(c *GuildMessageChannelFields) {
+		opts.RateLimitPerUser = c.RateLimitPerUser.Seconds()
+	}
+	fromAudioChannel := func(c *AudioChannelFields) {
+		opts.Bitrate = Bitrate(c.Bitrate)
+		opts.UserLimit = c.UserLimit
+		opts.RTCRegion = c.RtcRegion
+	}
+
+	switch c := channel.(type) {
+	case *CategoryChannel:
+		fromGuildChannel(&c.GuildChannelFields)
+	case *TextChannel:
+		fromGuildChannel(&c.GuildChannelFields)
+		fromMessageChannel(&c.GuildMessageChannelFields)
+		opts.ParentID = c.ParentID
+		opts.Nsfw = c.Nsfw
+		opts.Topic = c.Topic
+	case *AnnouncementChannel:
+		fromGuildChannel(&c.GuildChannelFields)
+		fromMessageChannel(&c.GuildMessageChannelFields)
+		opts.ParentID = c.ParentID
+		opts.Nsfw = c.Nsfw
+		opts.Topic = c.Topic
+	case *VoiceChannel:
+		fromGuildChannel(&c.GuildChannelFields)
+		fromMessageChannel(&c.GuildMessageChannelFields)
+		fromAudioChannel(&c.AudioChannelFields)
+		opts.ParentID = c.ParentID
+		opts.Nsfw = c.Nsfw
+	case *StageVoiceChannel:
+		fromGuildChannel(&c.GuildChannelFields)
+		fromMessageChannel(&c.GuildMessageChannelFields)
+		fromAudioChannel(&c.AudioChannelFields)
+		opts.ParentID = c.ParentID
+		opts.Nsfw = c.Nsfw
+	case *ForumChannel:
+		cloneForumChannelOptions(&opts, c)
+	case *MediaChannel:
+		cloneForumChannelOptions(&opts, &c.ForumChannel)
+	default:
+		return opts, false
+	}
+	return opts, true
+}
+
+// cloneForumChannelOptions sets the options to create a copy of a forum or media channel.
+func cloneForumChannelOptions(opts *CreateChannelOptions, c *ForumChannel) {
+	opts.Name = c.Name
+	opts.Type = c.Type
+	opts.PermissionOverwrites = slices.Clone(c.PermissionOverwrites)
+	opts.RateLimitPerUser = c.RateLimitPerUser.Seconds()
+	opts.ParentID = c.ParentID
+	opts.Topic = c.Topic
+	opts.AvailableTags = slices.Clone(c.AvailableTags)
+	if c.DefaultReactionEmoji != (DefaultReactionEmoji{}) {
+		opts.DefaultReactionEmoji = optional.Some(c.DefaultReactionEmoji)
+	}
+	if c.DefaultSortOrder != 0 {
+		opts.DefaultSortOrder = optional.Some(c.DefaultSortOrder)
+	}
+	if c.Type == ChannelTypeGuildForum {
+		opts.Nsfw = c.Nsfw
+		opts.DefaultForumLayout = c.DefaultForumLayout
+	}
+}
+
 type ChannelPosition struct {
 	// Channel id
 	ID Snowflake `json:"id"`
@@ -1431,6 +1889,37 @@ type ActiveThreadsResponse struct {
 	Members []ThreadMember `json:"members"`
 }
 
+// ByParent returns the threads whose parent is the given channel, in the order of Threads.
+func (r ActiveThreadsResponse) ByParent(channelID Snowflake) []ThreadChannel {
+	var threads []ThreadChannel
+	for _, thread := range r.Threads {
+		if thread.ParentID == channelID {
+			threads = append(threads, thread)
+		}
+	}
+	return threads
+}
+
+// JoinedBy returns the threads joined by the given user, in the order of Threads.
+//
+// Note:
+//   - Members only lists the current user, so JoinedBy returns nothing for other users.
+func (r ActiveThreadsResponse) JoinedBy(userID Snowflake) []ThreadChannel {
+	joined := make(map[Snowflake]struct{})
+	for _, member := range r.Members {
+		if member.UserID == userID {
+			joined[member.ThreadID] = struct{}{}
+		}
+	}
+	var threads []ThreadChannel
+	for _, thread := range r.Threads {
+		if _, ok := joined[thread.ID]; ok {
+			threads = append(threads, thread)
+		}
+	}
+	return threads
+}
+
 // ListActiveGuildThreads returns all active threads in the guild.
 //
 // Reference: https://discord.com/developers/docs/resources/guild#list-active-guild-threads
@@ -1480,6 +1969,25 @@ func (r *requester) FetchMember(guildID, userID Snowflake) result.Result[FullMem
 	return result.Ok(member)
 }
 
+// Member returns a member of a guild and its user from the cache, falling back to FetchMember
+// when either of them isn't cached.
+//
+// The fetched member is put in the cache if CacheFlagMembers is enabled, and its user if CacheFlagUsers is.
+// Use GetMember to look up the cache only.
+func (c *Client) Member(guildID, userID Snowflake) result.Result[FullMember] {
+	if member := c.GetMember(guildID, userID); member.IsPresent() {
+		if user := c.GetUser(userID); user.IsPresent() {
+			return result.Ok(FullMember{Member: member.Get(), User: user.Get()})
+		}
+	}
+	res := c.FetchMember(guildID, userID)
+	if res.IsErr() {
+		return res
+	}
+	c.cacheFullMember(res.Value())
+	return res
+}
+
 // ListMembersOptions contains parameters for paginating through guild members.
 type ListMembersOptions struct {
 	// Limit is the maximum number of members to return (1-1000).
@@ -1501,11 +2009,21 @@ func (r *requester) ListMembers(guildID Snowflake) result.Result[[]FullMember] {
 	return r.ListMembersWithOptions(guildID, ListMembersOptions{})
 }
 
+// ListMembersCtx is like ListMembers but aborts when ctx is canceled, returning ctx.Err().
+func (r *requester) ListMembersCtx(ctx context.Context, guildID Snowflake) result.Result[[]FullMember] {
+	return r.ListMembersWithOptionsCtx(ctx, guildID, ListMembersOptions{})
+}
+
 // ListMembers retrieves a paginated list of members in a guild.
 //
 //	Note:
 //	 - This endpoint is restricted according to whether the GUILD_MEMBERS Privileged Intent is enabled for your application.
 func (r *requester) ListMembersWithOptions(guildID Snowflake, opts ListMembersOptions) result.Result[[]FullMember] {
+	return r.ListMembersWithOptionsCtx(context.Background(), guildID, opts)
+}
+
+// ListMembersWithOptionsCtx is like ListMembersWithOptions but aborts when ctx is canceled, returning ctx.Err().
+func (r *requester) ListMembersWithOptionsCtx(ctx context.Context, guildID Snowflake, opts ListMembersOptions) result.Result[[]FullMember] {
 	endpoint := "/guilds/" + guildID.String() + "/members"
 
 	params := url.Values{}
@@ -1519,7 +2037,7 @@ func (r *requester) ListMembersWithOptions(guildID Snowflake, opts ListMembersOp
 		endpoint += "?" + params.Encode()
 	}
 
-	body := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	body := r.DoRequestCtx(ctx, Request{Method: "GET", URL: endpoint})
 	if body.IsErr() {
 		return result.Err[[]FullMember](body.Err())
 	}
@@ -1656,10 +2174,12 @@ func (r *requester) AddMember(guildID, userID Snowflake, opts AddMemberOptions)
 
 // ModifyMemberOptions contains parameters for modifying a guild member.
 //
-// All fields are optional. Only provide the fields you want to change.
+// All fields are optional. Only provide the fields you want to change,
+// see MarshalPartial for how optional fields are encoded.
 type ModifyMemberOptions struct {
 	// Nickname sets the member's guild nickname.
-	// Set to empty string to remove the nickname.
+	// Set to optional.Some("") to remove the nickname, leaving it as
+	// optional.None keeps the current one.
 	//
 	// Requires the PermissionManageNicknames permission.
 	Nickname optional.Option[string] `json:"nick,omitzero"`
@@ -1707,7 +2227,10 @@ type ModifyMemberOptions struct {
 
 // ModifyMember updates a member's properties in a guild.
 func (r *requester) ModifyMember(guildID, userID Snowflake, opts ModifyMemberOptions) result.Result[FullMember] {
-	reqBody, _ := json.Marshal(opts)
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[FullMember](err)
+	}
 	endpoint := "/guilds/" + guildID.String() + "/members/" + userID.String()
 
 	body := r.DoRequest(Request{
@@ -1734,6 +2257,43 @@ func (r *requester) ModifyMember(guildID, userID Snowflake, opts ModifyMemberOpt
 	return result.Ok(member)
 }
 
+// MaxTimeoutDuration is the maximum duration a member can be timed out for.
+const MaxTimeoutDuration = 28 * 24 * time.Hour
+
+// TimeoutMember times out a member until the provided time, preventing them from
+// sending messages, reacting, joining voice channels and more.
+//
+// Returns an error without sending the request if until is more than MaxTimeoutDuration in the future.
+//
+// Requires the PermissionModerateMembers permission.
+//
+// Usage example:
+//
+//	res := client.TimeoutMember(guildID, userID, time.Now().Add(10*time.Minute), "spamming")
+func (r *requester) TimeoutMember(guildID, userID Snowflake, until time.Time, reason string) result.Result[FullMember] {
+	if until.After(time.Now().Add(MaxTimeoutDuration)) {
+		return result.Err[FullMember](errors.New("TimeoutMember: timeout cannot be more than 28 days in the future"))
+	}
+	return r.ModifyMember(guildID, userID, ModifyMemberOptions{
+		CommunicationDisabledUntil: optional.Some(until),
+		Reason:                     reason,
+	})
+}
+
+// RemoveTimeout removes the timeout of a member.
+//
+// Requires the PermissionModerateMembers permission.
+//
+// Usage example:
+//
+//	res := client.RemoveTimeout(guildID, userID, "appeal accepted")
+func (r *requester) RemoveTimeout(guildID, userID Snowflake, reason string) result.Result[FullMember] {
+	return r.ModifyMember(guildID, userID, ModifyMemberOptions{
+		CommunicationDisabledUntil: optional.Nil[time.Time](),
+		Reason:                     reason,
+	})
+}
+
 // ModifyCurrentMemberOptions contains parameters for modifying your bot's member profile.
 //
 // This allows you to update your bot's nickname, avatar, banner, and bio in a specific guild.
@@ -1785,6 +2345,112 @@ func (r *requester) ModifyCurrentMember(guildID Snowflake, opts ModifyCurrentMem
 	return result.Ok(member)
 }
 
+// ModifyMember updates a member's properties in a guild.
+//
+// The returned member is written to the cache, so reads made before the
+// 'GUILD_MEMBER_UPDATE' event arrives already see the change.
+func (c *Client) ModifyMember(guildID, userID Snowflake, opts ModifyMemberOptions) result.Result[FullMember] {
+	res := c.requester.ModifyMember(guildID, userID, opts)
+	if res.IsOk() {
+		c.cacheFullMember(res.Value())
+	}
+	return res
+}
+
+// ModifyCurrentMember updates your bot's member properties in a guild.
+//
+// The returned member is written to the cache, like with Client.ModifyMember.
+func (c *Client) ModifyCurrentMember(guildID Snowflake, opts ModifyCurrentMemberOptions) result.Result[FullMember] {
+	res := c.requester.ModifyCurrentMember(guildID, opts)
+	if res.IsOk() {
+		c.cacheFullMember(res.Value())
+	}
+	return res
+}
+
+// SetMemberRoles replaces all roles of a member with the provided role IDs in a single request.
+//
+// The returned member is written to the cache, like with Client.ModifyMember.
+func (c *Client) SetMemberRoles(guildID, userID Snowflake, roleIDs []Snowflake, reason string) result.Result[FullMember] {
+	res := c.requester.SetMemberRoles(guildID, userID, roleIDs, reason)
+	if res.IsOk() {
+		c.cacheFullMember(res.Value())
+	}
+	return res
+}
+
+// TimeoutMember times out a member until the provided time.
+//
+// The returned member is written to the cache, like with Client.ModifyMember.
+func (c *Client) TimeoutMember(guildID, userID Snowflake, until time.Time, reason string) result.Result[FullMember] {
+	res := c.requester.TimeoutMember(guildID, userID, until, reason)
+	if res.IsOk() {
+		c.cacheFullMember(res.Value())
+	}
+	return res
+}
+
+// RemoveTimeout removes the timeout of a member.
+//
+// The returned member is written to the cache, like with Client.ModifyMember.
+func (c *Client) RemoveTimeout(guildID, userID Snowflake, reason string) result.Result[FullMember] {
+	res := c.requester.RemoveTimeout(guildID, userID, reason)
+	if res.IsOk() {
+		c.cacheFullMember(res.Value())
+	}
+	return res
+}
+
+// AddMemberRole assigns a role to a member in a guild.
+//
+// The role is added to the cached member, if any, so later reads like AddMemberRoles don't
+// send a role list missing it before the 'GUILD_MEMBER_UPDATE' event arrives.
+func (c *Client) AddMemberRole(guildID, userID, roleID Snowflake, opts AddMemberRoleOptions) result.Void {
+	res := c.requester.AddMemberRole(guildID, userID, roleID, opts)
+	if res.IsOk() {
+		c.updateCachedMemberRoles(guildID, userID, func(roles []Snowflake) []Snowflake {
+			return addRoleIDs(roles, []Snowflake{roleID})
+		})
+	}
+	return res
+}
+
+// RemoveMemberRole unassigns a role from a member in a guild.
+//
+// The role is removed from the cached member, if any, like with Client.AddMemberRole.
+func (c *Client) RemoveMemberRole(guildID, userID, roleID Snowflake, opts RemoveMemberRoleOptions) result.Void {
+	res := c.requester.RemoveMemberRole(guildID, userID, roleID, opts)
+	if res.IsOk() {
+		c.updateCachedMemberRoles(guildID, userID, func(roles []Snowflake) []Snowflake {
+			return removeRoleIDs(roles, []Snowflake{roleID})
+		})
+	}
+	return res
+}
+
+// updateCachedMemberRoles replaces the roles of a cached member with the result of update.
+func (c *Client) updateCachedMemberRoles(guildID, userID Snowflake, update func([]Snowflake) []Snowflake) {
+	if !c.Flags().Has(CacheFlagMembers) {
+		return
+	}
+	if member := c.GetMember(guildID, userID); member.IsPresent() {
+		m := member.Get()
+		m.RoleIDs = update(m.RoleIDs)
+		c.PutMember(m)
+	}
+}
+
+// cacheFullMember writes a member returned by the API through to the cache.
+func (c *Client) cacheFullMember(member FullMember) {
+	flags := c.Flags()
+	if flags.Has(CacheFlagMembers) {
+		c.PutMember(member.Member)
+	}
+	if flags.Has(CacheFlagUsers) {
+		c.PutUser(member.User)
+	}
+}
+
 // AddMemberRoleOptions contains parameters for adding a role to a member.
 type AddMemberRoleOptions struct {
 	// Reason is the reason shown in the audit log for this action.
@@ -1823,9 +2489,105 @@ func (r *requester) RemoveMemberRole(guildID, userID, roleID Snowflake, opts Rem
 	if res.IsErr() {
 		return result.ErrVoid(res.Err())
 	}
+	res.Value().Close()
 	return result.OkVoid()
 }
 
+// SetMemberRoles replaces all roles of a member with the provided role IDs in a single request.
+//
+// Requires the PermissionManageRoles permission.
+//
+// Usage example:
+//
+//	res := client.SetMemberRoles(guildID, userID, []Snowflake{roleA, roleB}, "verified")
+func (r *requester) SetMemberRoles(guildID, userID Snowflake, roleIDs []Snowflake, reason string) result.Result[FullMember] {
+	if roleIDs == nil {
+		roleIDs = []Snowflake{}
+	}
+	return r.ModifyMember(guildID, userID, ModifyMemberOptions{
+		Roles:  optional.Some(roleIDs),
+		Reason: reason,
+	})
+}
+
+// AddMemberRoles assigns multiple roles to a member using a single request.
+//
+// The member's current roles are read from the cache, falling back to FetchMember,
+// and the new role set is sent with SetMemberRoles.
+//
+// Note:
+//   - This is a read-modify-write operation: roles changed concurrently by someone else
+//     between the read and the write are overwritten. Use AddMemberRole if that matters.
+//
+// Requires the PermissionManageRoles permission.
+func (c *Client) AddMemberRoles(guildID, userID Snowflake, roleIDs []Snowflake, reason string) result.Result[FullMember] {
+	current := c.memberRoleIDs(guildID, userID)
+	if current.IsErr() {
+		return result.Err[FullMember](current.Err())
+	}
+	return c.SetMemberRoles(guildID, userID, addRoleIDs(current.Value(), roleIDs), reason)
+}
+
+// RemoveMemberRoles unassigns multiple roles from a member using a single request.
+//
+// The member's current roles are read from the cache, falling back to FetchMember,
+// and the new role set is sent with SetMemberRoles.
+//
+// Note:
+//   - This is a read-modify-write operation: roles changed concurrently by someone else
+//     between the read and the write are overwritten. Use RemoveMemberRole if that matters.
+//
+// Requires the PermissionManageRoles permission.
+func (c *Client) RemoveMemberRoles(guildID, userID Snowflake, roleIDs []Snowflake, reason string) result.Result[FullMember] {
+	current := c.memberRoleIDs(guildID, userID)
+	if current.IsErr() {
+		return result.Err[FullMember](current.Err())
+	}
+	return c.SetMemberRoles(guildID, userID, removeRoleIDs(current.Value(), roleIDs), reason)
+}
+
+// memberRoleIDs returns the role IDs of a member from the cache, or fetches the member if not cached.
+func (c *Client) memberRoleIDs(guildID, userID Snowflake) result.Result[[]Snowflake] {
+	if member := c.GetMember(guildID, userID); member.IsPresent() {
+		return result.Ok(member.Get().RoleIDs)
+	}
+	res := c.FetchMember(guildID, userID)
+	if res.IsErr() {
+		return result.Err[[]Snowflake](res.Err())
+	}
+	return result.Ok(res.Value().RoleIDs)
+}
+
+// addRoleIDs returns current with the ids of add that aren't already in it appended.
+func addRoleIDs(current, add []Snowflake) []Snowflake {
+	roles := make([]Snowflake, 0, len(current)+len(add))
+	seen := make(map[Snowflake]struct{}, len(current)+len(add))
+	for _, ids := range [2][]Snowflake{current, add} {
+		for _, id := range ids {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				roles = append(roles, id)
+			}
+		}
+	}
+	return roles
+}
+
+// removeRoleIDs returns current without the ids of remove.
+func removeRoleIDs(current, remove []Snowflake) []Snowflake {
+	removed := make(map[Snowflake]struct{}, len(remove))
+	for _, id := range remove {
+		removed[id] = struct{}{}
+	}
+	roles := make([]Snowflake, 0, len(current))
+	for _, id := range current {
+		if _, ok := removed[id]; !ok {
+			roles = append(roles, id)
+		}
+	}
+	return roles
+}
+
 // KickMemberOptions contains parameters for kicking a member from a guild.
 type KickMemberOptions struct {
 	// Reason is the reason shown in the audit log for this action.
@@ -2092,6 +2854,103 @@ func (r *requester) FetchRole(guildID, roleID Snowflake) result.Result[Role] {
 	return result.Ok(role)
 }
 
+// FindRole fetches a role of a guild, returning None instead of an error if the role doesn't exist.
+//
+// The fetched role is put in the cache if CacheFlagRoles is enabled.
+func (c *Client) FindRole(guildID, roleID Snowflake) result.Result[optional.Option[Role]] {
+	res := c.FetchRole(guildID, roleID)
+	if res.IsErr() {
+		var restErr *RestError
+		if errors.As(res.Err(), &restErr) && restErr.StatusCode == http.StatusNotFound {
+			return result.Ok(optional.None[Role]())
+		}
+		return result.Err[optional.Option[Role]](res.Err())
+	}
+
+	role := res.Value()
+	if c.Flags().Has(CacheFlagRoles) {
+		c.PutRole(role)
+	}
+	return result.Ok(optional.Some(role))
+}
+
+// Role returns a role of a guild from the cache, falling back to FindRole on a cache miss.
+//
+// Returns None if the role doesn't exist.
+func (c *Client) Role(guildID, roleID Snowflake) result.Result[optional.Option[Role]] {
+	if role, ok := c.GetRoles(roleID)[roleID]; ok && role.GuildID == guildID {
+		return result.Ok(optional.Some(role))
+	}
+	return c.FindRole(guildID, roleID)
+}
+
+// EveryoneRole returns the @everyone role of a guild from the cache.
+//
+// The @everyone role holds the base permissions of every member of the guild.
+func (c *Client) EveryoneRole(guildID Snowflake) optional.Option[Role] {
+	if role, ok := c.GetRoles(guildID)[guildID]; ok {
+		return optional.Some(role)
+	}
+	return optional.None[Role]()
+}
+
+// EnsureGuildRoles returns every role of a guild, sorted by position.
+//
+// The roles come from the cache when all of them are known to be cached,
+// otherwise they are fetched with FetchRoles and, if CacheFlagRoles is enabled, the cached roles
+// of the guild are replaced by the fetched ones.
+func (c *Client) EnsureGuildRoles(guildID Snowflake) result.Result[[]Role] {
+	if c.hasCompleteGuildRoles(guildID) {
+		if cached, err := c.GetGuildRoles(guildID).GetErr(); err == nil {
+			return result.Ok(sortRoles(slices.Collect(maps.Values(cached))))
+		}
+	}
+
+	res := c.FetchRoles(guildID)
+	if res.IsErr() {
+		return res
+	}
+	roles := res.Value()
+	if c.Flags().Has(CacheFlagRoles) {
+		if cached, err := c.GetGuildRoles(guildID).GetErr(); err == nil {
+			for roleID := range cached {
+				if !slices.ContainsFunc(roles, func(r Role) bool { return r.ID == roleID }) {
+					c.DelRole(guildID, roleID)
+				}
+			}
+		}
+		c.PutRoles(roles...)
+		c.markGuildRolesComplete(guildID)
+	}
+	return result.Ok(sortRoles(roles))
+}
+
+// markGuildRolesComplete records that every role of the guild is cached,
+// if the cache manager supports it.
+func (c *Client) markGuildRolesComplete(guildID Snowflake) {
+	if completer, ok := c.CacheManager.(guildRolesCompleter); ok {
+		completer.markGuildRolesComplete(guildID)
+	}
+}
+
+// hasCompleteGuildRoles reports whether every role of the guild is known to be cached,
+// always false if the cache manager doesn't record it.
+func (c *Client) hasCompleteGuildRoles(guildID Snowflake) bool {
+	completer, ok := c.CacheManager.(guildRolesCompleter)
+	return ok && completer.hasCompleteGuildRoles(guildID)
+}
+
+// sortRoles sorts roles by position, then by ID like the Discord client does for equal positions.
+func sortRoles(roles []Role) []Role {
+	slices.SortFunc(roles, func(a, b Role) int {
+		if a.Position != b.Position {
+			return cmp.Compare(a.Position, b.Position)
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return roles
+}
+
 // FetchRolesMemberCount returns a map of role IDs to the number of members with the role.
 //
 //	Note:
@@ -2408,6 +3267,8 @@ func (r *requester) BeginGuildPrune(guildID Snowflake, opts BeginGuildPruneOptio
 
 // FetchGuildVoiceRegions returns a list of voice region objects for the guild.
 // Unlike the similar /voice route, this returns VIP servers when the guild is VIP-enabled.
+//
+// Reference: https://discord.com/developers/docs/resources/guild#get-guild-voice-regions
 func (r *requester) FetchGuildVoiceRegions(guildID Snowflake) result.Result[[]VoiceRegion] {
 	endpoint := "/guilds/" + guildID.String() + "/regions"
 
@@ -2430,10 +3291,13 @@ func (r *requester) FetchGuildVoiceRegions(guildID Snowflake) result.Result[[]Vo
 	return result.Ok(regions)
 }
 
-// FetchGuildVoiceRegions returns a list of voice region objects for the guild.
-// Unlike the similar /voice route, this returns VIP servers when the guild is VIP-enabled.
+// FetchGuildInvites returns all the invites of the guild, with their metadata.
 //
-// Requires the PermissionManageGuild and PermissionKickMembers permission.
+// Discord returns every invite at once, there is no pagination.
+//
+// Requires the PermissionManageGuild permission.
+//
+// Reference: https://discord.com/developers/docs/resources/guild#get-guild-invites
 func (r *requester) FetchGuildInvites(guildID Snowflake) result.Result[[]FullInvite] {
 	endpoint := "/guilds/" + guildID.String() + "/invites"
 
@@ -2456,6 +3320,51 @@ func (r *requester) FetchGuildInvites(guildID Snowflake) result.Result[[]FullInv
 	return result.Ok(invites)
 }
 
+// GuildInvitesForChannel returns the invites of the guild that lead to the given channel.
+//
+// Requires the PermissionManageGuild permission.
+func (c *Client) GuildInvitesForChannel(guildID, channelID Snowflake) result.Result[[]FullInvite] {
+	res := c.FetchGuildInvites(guildID)
+	if res.IsErr() {
+		return res
+	}
+	return result.Ok(filterInvitesByChannel(res.Value(), channelID))
+}
+
+// FindInvite returns the invite of the guild with the given code, or None if the guild has no such invite.
+//
+// Invite codes are case-sensitive.
+//
+// Requires the PermissionManageGuild permission.
+func (c *Client) FindInvite(guildID Snowflake, code string) result.Result[optional.Option[FullInvite]] {
+	res := c.FetchGuildInvites(guildID)
+	if res.IsErr() {
+		return result.Err[optional.Option[FullInvite]](res.Err())
+	}
+	return result.Ok(findInviteByCode(res.Value(), code))
+}
+
+// filterInvitesByChannel returns the invites leading to channelID, in their original order.
+func filterInvitesByChannel(invites []FullInvite, channelID Snowflake) []FullInvite {
+	filtered := make([]FullInvite, 0, len(invites))
+	for _, invite := range invites {
+		if invite.Channel != nil && invite.Channel.ID == channelID {
+			filtered = append(filtered, invite)
+		}
+	}
+	return filtered
+}
+
+// findInviteByCode returns the invite with the given code, if any.
+func findInviteByCode(invites []FullInvite, code string) optional.Option[FullInvite] {
+	for _, invite := range invites {
+		if invite.Code == code {
+			return optional.Some(invite)
+		}
+	}
+	return optional.None[FullInvite]()
+}
+
 // FetchGuildIntegrations returns a list of integration objects for the guild.
 //
 // Note:
@@ -2506,8 +3415,15 @@ func (r *requester) DeleteGuildIntegration(guildID, integrationID Snowflake, opt
 
 // FetchGuildWidgetSettings returns a guild widget settings object.
 //
+// The result is cached for RequesterConfig.SettingsCacheTTL, modifying it through the library invalidates the cache.
+//
 // Requires the PermissionManageGuild permission.
 func (r *requester) FetchGuildWidgetSettings(guildID Snowflake) result.Result[GuildWidgetSettings] {
+	return cachedFetch(r.widgetSettingsCache, guildID, r.fetchGuildWidgetSettings)
+}
+
+// fetchGuildWidgetSettings fetches the widget settings of the guild without going through the cache.
+func (r *requester) fetchGuildWidgetSettings(guildID Snowflake) result.Result[GuildWidgetSettings] {
 	endpoint := "/guilds/" + guildID.String() + "/widget"
 
 	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
@@ -2540,6 +3456,7 @@ type ModifyGuildWidgetOptions struct {
 
 // ModifyGuildWidget modifies a guild widget settings object for the guild.
 func (r *requester) ModifyGuildWidget(guildID Snowflake, opts ModifyGuildWidgetOptions) result.Result[GuildWidgetSettings] {
+	defer r.widgetSettingsCache.del(guildID)
 	reqBody, _ := json.Marshal(opts)
 	res := r.DoRequest(Request{
 		Method: "PATCH",
@@ -2662,8 +3579,15 @@ func (r *requester) FetchGuildWidgetImage(guildID Snowflake, opts FetchGuildWidg
 
 // FetchGuildWelcomeScreen returns the Welcome Screen object for the guild.
 //
+// The result is cached for RequesterConfig.SettingsCacheTTL, modifying it through the library invalidates the cache.
+//
 // Requires the PermissionManageGuild permission.
 func (r *requester) FetchGuildWelcomeScreen(guildID Snowflake) result.Result[GuildWelcomeScreen] {
+	return cachedFetch(r.welcomeScreenCache, guildID, r.fetchGuildWelcomeScreen)
+}
+
+// fetchGuildWelcomeScreen fetches the welcome screen of the guild without going through the cache.
+func (r *requester) fetchGuildWelcomeScreen(guildID Snowflake) result.Result[GuildWelcomeScreen] {
 	endpoint := "/guilds/" + guildID.String() + "/welcome-screen"
 
 	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
@@ -2700,12 +3624,30 @@ type ModifyGuildWelcomeScreenOptions struct {
 
 	// Reason is the reason shown in the audit log for this action.
 	Reason string `json:"-"`
+
+	// SkipFeatureCheck disables the Community feature check done by Client.ModifyGuildWelcomeScreen
+	// against the cached guild.
+	SkipFeatureCheck bool `json:"-"`
 }
 
+// MaxWelcomeScreenChannels is the maximum number of channels a guild welcome screen can show.
+const MaxWelcomeScreenChannels = 5
+
 // ModifyGuildWelcomeScreen modifies the guild's Welcome Screen.
 //
 // Requires the PermissionManageGuild permission.
+//
+// Note:
+//   - Returns an error without sending the request if more than MaxWelcomeScreenChannels welcome channels are given.
 func (r *requester) ModifyGuildWelcomeScreen(guildID Snowflake, opts ModifyGuildWelcomeScreenOptions) result.Result[GuildWelcomeScreen] {
+	defer r.welcomeScreenCache.del(guildID)
+	if opts.WelcomeChannels.IsPresent() && len(opts.WelcomeChannels.Get()) > MaxWelcomeScreenChannels {
+		return result.Err[GuildWelcomeScreen](fmt.Errorf(
+			"ModifyGuildWelcomeScreen: got %d welcome channels, at most %d are allowed",
+			len(opts.WelcomeChannels.Get()), MaxWelcomeScreenChannels,
+		))
+	}
+
 	reqBody, _ := json.Marshal(opts)
 	endpoint := "/guilds/" + guildID.String() + "/welcome-screen"
 	res := r.DoRequest(Request{
@@ -2732,10 +3674,37 @@ func (r *requester) ModifyGuildWelcomeScreen(guildID Snowflake, opts ModifyGuild
 	return result.Ok(screen)
 }
 
+// ModifyGuildWelcomeScreen modifies the guild's Welcome Screen.
+//
+// If the guild is cached, it must have the GuildFeatureCommunity feature, otherwise a descriptive
+// error is returned instead of Discord's one. Set opts.SkipFeatureCheck to send the request anyway.
+//
+// Requires the PermissionManageGuild permission.
+func (c *Client) ModifyGuildWelcomeScreen(guildID Snowflake, opts ModifyGuildWelcomeScreenOptions) result.Result[GuildWelcomeScreen] {
+	if !opts.SkipFeatureCheck {
+		if guild := c.GetGuild(guildID); guild.IsPresent() {
+			g := guild.Get()
+			if !g.HasFeature(GuildFeatureCommunity) && !g.HasFeature(GuildFeatureWelcomeScreenEnabled) {
+				return result.Err[GuildWelcomeScreen](fmt.Errorf(
+					"ModifyGuildWelcomeScreen: guild %s is not a Community guild, the welcome screen is unavailable", guildID,
+				))
+			}
+		}
+	}
+	return c.requester.ModifyGuildWelcomeScreen(guildID, opts)
+}
+
 // FetchGuildOnboarding returns the Onboarding object for the guild.
 //
+// The result is cached for RequesterConfig.SettingsCacheTTL, modifying it through the library invalidates the cache.
+//
 // Requires the PermissionManageGuild and PermissionManageRoles permissions.
 func (r *requester) FetchGuildOnboarding(guildID Snowflake) result.Result[GuildOnboarding] {
+	return cachedFetch(r.onboardingCache, guildID, r.fetchGuildOnboarding)
+}
+
+// fetchGuildOnboarding fetches the onboarding of the guild without going through the cache.
+func (r *requester) fetchGuildOnboarding(guildID Snowflake) result.Result[GuildOnboarding] {
 	endpoint := "/guilds/" + guildID.String() + "/onboarding"
 
 	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
@@ -2786,6 +3755,7 @@ type ModifyGuildOnboardingOptions struct {
 //
 // Requires the PermissionManageGuild and PermissionManageRoles permissions.
 func (r *requester) ModifyGuildOnboarding(guildID Snowflake, opts ModifyGuildOnboardingOptions) result.Result[GuildOnboarding] {
+	defer r.onboardingCache.del(guildID)
 	reqBody, _ := json.Marshal(opts)
 	endpoint := "/guilds/" + guildID.String() + "/onboarding"
 	res := r.DoRequest(Request{
@@ -13,6 +13,17 @@
 
 package dwaz
 
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
+
 // GuildScheduledEventPrivacyLevel represents Guild Scheduled Event Privacy Levels types.
 //
 // Reference: https://discord.com/developers/docs/resources/invite#invite-object-invite-types
@@ -28,6 +39,23 @@ func (l GuildScheduledEventPrivacyLevel) Is(privacyLevel GuildScheduledEventPriv
 	return l == privacyLevel
 }
 
+// GuildScheduledEventStatus represents the status of a guild scheduled event.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#guild-scheduled-event-object-guild-scheduled-event-status
+type GuildScheduledEventStatus int
+
+const (
+	GuildScheduledEventStatusScheduled GuildScheduledEventStatus = 1
+	GuildScheduledEventStatusActive    GuildScheduledEventStatus = 2
+	GuildScheduledEventStatusCompleted GuildScheduledEventStatus = 3
+	GuildScheduledEventStatusCanceled  GuildScheduledEventStatus = 4
+)
+
+// Is returns true if the guild scheduled event's status matches the provided one.
+func (s GuildScheduledEventStatus) Is(status GuildScheduledEventStatus) bool {
+	return s == status
+}
+
 // GuildScheduledEventEntityType represents invite target types.
 //
 // Reference: https://discord.com/developers/docs/resources/invite#invite-object-invite-target-types
@@ -44,6 +72,17 @@ func (t GuildScheduledEventEntityType) Is(typ GuildScheduledEventEntityType) boo
 	return t == typ
 }
 
+// GuildScheduledEventEntityMetadata contains additional metadata for a guild scheduled event.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#guild-scheduled-event-object-guild-scheduled-event-entity-metadata
+type GuildScheduledEventEntityMetadata struct {
+	// Location is the location of the event (1-100 characters).
+	//
+	// Note:
+	//   - Required for events with EntityType GuildScheduledEventEntityTypeExternal.
+	Location string `json:"location,omitempty"`
+}
+
 // GuildScheduledEvent is a representation of a scheduled event in a guild.
 type GuildScheduledEvent struct {
 	// ID is the id of the scheduled event.
@@ -54,6 +93,431 @@ type GuildScheduledEvent struct {
 
 	// ChannelID is the channel id in which the scheduled event will be hosted, or null if scheduled entity type is EXTERNAL
 	ChannelID Snowflake `json:"channel_id"`
+
+	// CreatorID is the id of the user that created the scheduled event.
+	//
+	// Optional:
+	//   - Will be 0 for events created before October 25th, 2021.
+	CreatorID Snowflake `json:"creator_id"`
+
+	// Name is the name of the scheduled event (1-100 characters).
+	Name string `json:"name"`
+
+	// Description is the description of the scheduled event (1-1000 characters).
+	//
+	// Optional:
+	//   - Will be empty string if not set.
+	Description string `json:"description"`
+
+	// ScheduledStartTime is the time the scheduled event will start.
+	ScheduledStartTime time.Time `json:"scheduled_start_time"`
+
+	// ScheduledEndTime is the time the scheduled event will end.
+	//
+	// Optional:
+	//   - Will be nil if not set, required if EntityType is GuildScheduledEventEntityTypeExternal.
+	ScheduledEndTime *time.Time `json:"scheduled_end_time,omitzero"`
+
+	// PrivacyLevel is the privacy level of the scheduled event.
+	PrivacyLevel GuildScheduledEventPrivacyLevel `json:"privacy_level"`
+
+	// Status is the status of the scheduled event.
+	Status GuildScheduledEventStatus `json:"status"`
+
+	// EntityType is the type of the scheduled event.
+	EntityType GuildScheduledEventEntityType `json:"entity_type"`
+
+	// EntityID is the id of an entity associated with the guild scheduled event.
+	//
+	// Optional:
+	//   - Will be 0 if not set.
+	EntityID Snowflake `json:"entity_id"`
+
+	// EntityMetadata contains additional metadata for the guild scheduled event.
+	//
+	// Optional:
+	//   - Will be nil if not set, required if EntityType is GuildScheduledEventEntityTypeExternal.
+	EntityMetadata *GuildScheduledEventEntityMetadata `json:"entity_metadata,omitempty"`
+
+	// Creator is the user that created the scheduled event.
+	//
+	// Optional:
+	//   - Will be nil for events created before October 25th, 2021.
+	Creator *User `json:"creator,omitempty"`
+
+	// UserCount is the number of users subscribed to the scheduled event.
+	//
+	// Optional:
+	//   - Only present when fetched with WithUserCount.
+	UserCount int `json:"user_count"`
+
+	// Image is the cover image hash of the scheduled event.
+	//
+	// Optional:
+	//   - Will be empty string if not set.
+	Image string `json:"image"`
+}
+
+// CreatedAt returns the time when this scheduled event was created.
+func (e *GuildScheduledEvent) CreatedAt() time.Time {
+	return e.ID.Timestamp()
 }
 
-// TODO: continue guild_sheduled_event.go
+// CoverImageURL returns the URL to the scheduled event's cover image.
+//
+// If the scheduled event has a cover image set, it returns the URL to that image
+// using the provided format and size, otherwise it returns an empty string.
+//
+// Example usage:
+//
+//	url := event.CoverImageURL(ImageFormatWebP, ImageSize1024)
+func (e *GuildScheduledEvent) CoverImageURL(format ImageFormat, size ImageSize) string {
+	if e.Image != "" {
+		return GuildScheduledEventCoverURL(e.ID, e.Image, format, size)
+	}
+	return ""
+}
+
+// URL returns a link to the scheduled event in the Discord client.
+//
+// Example output: "https://discord.com/events/123456789012345678/876543210987654321"
+func (e *GuildScheduledEvent) URL() string {
+	return "https://discord.com/events/" + e.GuildID.String() + "/" + e.ID.String()
+}
+
+/*****************************
+ *   Scheduled Events REST
+ *****************************/
+
+// ListScheduledEvents returns the scheduled events of a guild.
+//
+// If withUserCount is true, the UserCount field of each event is set.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#list-scheduled-events-for-guild
+func (r *requester) ListScheduledEvents(guildID Snowflake, withUserCount bool) result.Result[[]GuildScheduledEvent] {
+	endpoint := "/guilds/" + guildID.String() + "/scheduled-events"
+	if withUserCount {
+		endpoint += "?with_user_count=true"
+	}
+
+	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	if res.IsErr() {
+		return result.Err[[]GuildScheduledEvent](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var events []GuildScheduledEvent
+	if err := json.NewDecoder(body).Decode(&events); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/scheduled-events",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]GuildScheduledEvent](err)
+	}
+	return result.Ok(events)
+}
+
+// FetchScheduledEvent returns a scheduled event of a guild.
+//
+// If withUserCount is true, the UserCount field of the event is set.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#get-guild-scheduled-event
+func (r *requester) FetchScheduledEvent(guildID, eventID Snowflake, withUserCount bool) result.Result[GuildScheduledEvent] {
+	endpoint := "/guilds/" + guildID.String() + "/scheduled-events/" + eventID.String()
+	if withUserCount {
+		endpoint += "?with_user_count=true"
+	}
+
+	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	if res.IsErr() {
+		return result.Err[GuildScheduledEvent](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var event GuildScheduledEvent
+	if err := json.NewDecoder(body).Decode(&event); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/scheduled-events/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[GuildScheduledEvent](err)
+	}
+	return result.Ok(event)
+}
+
+// CreateScheduledEventOptions contains parameters for creating a guild scheduled event.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#create-guild-scheduled-event-json-params
+type CreateScheduledEventOptions struct {
+	// ChannelID is the stage or voice channel the event will be hosted in.
+	//
+	// Note:
+	//   - Must be 0 for events with EntityType GuildScheduledEventEntityTypeExternal.
+	ChannelID Snowflake `json:"channel_id,omitempty"`
+
+	// EntityMetadata contains the location of the event.
+	//
+	// Note:
+	//   - Required for events with EntityType GuildScheduledEventEntityTypeExternal.
+	EntityMetadata *GuildScheduledEventEntityMetadata `json:"entity_metadata,omitempty"`
+
+	// Name is the name of the scheduled event (1-100 characters).
+	Name string `json:"name"`
+
+	// PrivacyLevel is the privacy level of the scheduled event.
+	//
+	// Note:
+	//   - Defaults to GuildScheduledEventPrivacyLevelGuildOnly if not set.
+	PrivacyLevel GuildScheduledEventPrivacyLevel `json:"privacy_level"`
+
+	// ScheduledStartTime is the time the scheduled event will start.
+	ScheduledStartTime time.Time `json:"scheduled_start_time"`
+
+	// ScheduledEndTime is the time the scheduled event will end.
+	//
+	// Note:
+	//   - Required for events with EntityType GuildScheduledEventEntityTypeExternal.
+	ScheduledEndTime *time.Time `json:"scheduled_end_time,omitzero"`
+
+	// Description is the description of the scheduled event (1-1000 characters).
+	Description string `json:"description,omitempty"`
+
+	// EntityType is the type of the scheduled event.
+	EntityType GuildScheduledEventEntityType `json:"entity_type"`
+
+	// Image is the cover image of the scheduled event as a data URI.
+	Image string `json:"image,omitempty"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// CreateScheduledEvent creates a scheduled event in a guild.
+//
+// Requires the PermissionCreateEvents permission.
+//
+// Note:
+//   - External events require EntityMetadata.Location and ScheduledEndTime, an error is
+//     returned without sending the request if they're missing.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#create-guild-scheduled-event
+func (r *requester) CreateScheduledEvent(guildID Snowflake, opts CreateScheduledEventOptions) result.Result[GuildScheduledEvent] {
+	if opts.EntityType.Is(GuildScheduledEventEntityTypeExternal) {
+		if opts.EntityMetadata == nil || opts.EntityMetadata.Location == "" {
+			return result.Err[GuildScheduledEvent](errors.New("CreateScheduledEvent: external events require EntityMetadata.Location"))
+		}
+		if opts.ScheduledEndTime == nil {
+			return result.Err[GuildScheduledEvent](errors.New("CreateScheduledEvent: external events require ScheduledEndTime"))
+		}
+	}
+	if opts.PrivacyLevel == 0 {
+		opts.PrivacyLevel = GuildScheduledEventPrivacyLevelGuildOnly
+	}
+
+	reqBody, err := json.Marshal(opts)
+	if err != nil {
+		return result.Err[GuildScheduledEvent](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/guilds/" + guildID.String() + "/scheduled-events",
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[GuildScheduledEvent](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var event GuildScheduledEvent
+	if err := json.NewDecoder(body).Decode(&event); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/guilds/{id}/scheduled-events",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[GuildScheduledEvent](err)
+	}
+	return result.Ok(event)
+}
+
+// ModifyScheduledEventOptions contains parameters for modifying a guild scheduled event.
+//
+// Note:
+//   - To change an event to an external one, set ChannelID to optional.Nil and
+//     provide EntityMetadata.Location and ScheduledEndTime.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#modify-guild-scheduled-event-json-params
+type ModifyScheduledEventOptions struct {
+	// ChannelID is the stage or voice channel the event will be hosted in.
+	ChannelID optional.Option[Snowflake] `json:"channel_id,omitzero"`
+
+	// EntityMetadata contains the location of the event.
+	EntityMetadata optional.Option[GuildScheduledEventEntityMetadata] `json:"entity_metadata,omitzero"`
+
+	// Name is the name of the scheduled event (1-100 characters).
+	Name optional.Option[string] `json:"name,omitzero"`
+
+	// PrivacyLevel is the privacy level of the scheduled event.
+	PrivacyLevel optional.Option[GuildScheduledEventPrivacyLevel] `json:"privacy_level,omitzero"`
+
+	// ScheduledStartTime is the time the scheduled event will start.
+	ScheduledStartTime optional.Option[time.Time] `json:"scheduled_start_time,omitzero"`
+
+	// ScheduledEndTime is the time the scheduled event will end.
+	ScheduledEndTime optional.Option[time.Time] `json:"scheduled_end_time,omitzero"`
+
+	// Description is the description of the scheduled event (1-1000 characters).
+	Description optional.Option[string] `json:"description,omitzero"`
+
+	// EntityType is the type of the scheduled event.
+	EntityType optional.Option[GuildScheduledEventEntityType] `json:"entity_type,omitzero"`
+
+	// Status is the status of the scheduled event, used to start, end or cancel it.
+	Status optional.Option[GuildScheduledEventStatus] `json:"status,omitzero"`
+
+	// Image is the cover image of the scheduled event as a data URI.
+	Image optional.Option[string] `json:"image,omitzero"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// ModifyScheduledEvent modifies a scheduled event of a guild.
+//
+// Requires the PermissionManageEvents permission, or PermissionCreateEvents for events created by the current user.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#modify-guild-scheduled-event
+func (r *requester) ModifyScheduledEvent(guildID, eventID Snowflake, opts ModifyScheduledEventOptions) result.Result[GuildScheduledEvent] {
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[GuildScheduledEvent](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "PATCH",
+		URL:    "/guilds/" + guildID.String() + "/scheduled-events/" + eventID.String(),
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[GuildScheduledEvent](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var event GuildScheduledEvent
+	if err := json.NewDecoder(body).Decode(&event); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/guilds/{id}/scheduled-events/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[GuildScheduledEvent](err)
+	}
+	return result.Ok(event)
+}
+
+// DeleteScheduledEvent deletes a scheduled event of a guild.
+//
+// Requires the PermissionManageEvents permission, or PermissionCreateEvents for events created by the current user.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#delete-guild-scheduled-event
+func (r *requester) DeleteScheduledEvent(guildID, eventID Snowflake) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/guilds/" + guildID.String() + "/scheduled-events/" + eventID.String(),
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// GuildScheduledEventUser is a user subscribed to a guild scheduled event.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#guild-scheduled-event-user-object
+type GuildScheduledEventUser struct {
+	// GuildScheduledEventID is the ID of the scheduled event the user subscribed to.
+	GuildScheduledEventID Snowflake `json:"guild_scheduled_event_id"`
+
+	// User is the user which subscribed to the event.
+	User User `json:"user"`
+
+	// Member is the guild member of the user.
+	//
+	// Optional:
+	//   - Only present when fetched with WithMember and the user is still in the guild.
+	Member *FullMember `json:"member,omitempty"`
+}
+
+// FetchScheduledEventUsersOptions contains parameters for fetching the users subscribed to a scheduled event.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#get-guild-scheduled-event-users-query-string-params
+type FetchScheduledEventUsersOptions struct {
+	// Limit is the maximum number of users to return (1-100), 0 uses Discord's default of 100.
+	Limit int
+
+	// WithMember includes the guild member of each user.
+	WithMember bool
+
+	// Before returns the users before this user ID.
+	Before Snowflake
+
+	// After returns the users after this user ID.
+	After Snowflake
+}
+
+// FetchScheduledEventUsers returns the users subscribed to a scheduled event, sorted by user ID.
+//
+// Reference: https://discord.com/developers/docs/resources/guild-scheduled-event#get-guild-scheduled-event-users
+func (r *requester) FetchScheduledEventUsers(guildID, eventID Snowflake, opts FetchScheduledEventUsersOptions) result.Result[[]GuildScheduledEventUser] {
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.WithMember {
+		query.Set("with_member", "true")
+	}
+	if !opts.Before.UnSet() {
+		query.Set("before", opts.Before.String())
+	}
+	if !opts.After.UnSet() {
+		query.Set("after", opts.After.String())
+	}
+
+	endpoint := "/guilds/" + guildID.String() + "/scheduled-events/" + eventID.String() + "/users"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	if res.IsErr() {
+		return result.Err[[]GuildScheduledEventUser](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var users []GuildScheduledEventUser
+	if err := json.NewDecoder(body).Decode(&users); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/scheduled-events/{id}/users",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]GuildScheduledEventUser](err)
+	}
+	for i := range users {
+		if users[i].Member != nil {
+			users[i].Member.GuildID = guildID
+		}
+	}
+	return result.Ok(users)
+}
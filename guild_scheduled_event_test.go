@@ -0,0 +1,138 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGuildScheduledEventCoverImageURL(t *testing.T) {
+	event := GuildScheduledEvent{ID: 123456789012345678, GuildID: 876543210987654321}
+
+	if url := event.CoverImageURL(ImageFormatPNG, ImageSize512); url != "" {
+		t.Errorf("expected empty url without image, got %q", url)
+	}
+
+	event.Image = "abcdef"
+	want := ImageBaseURL + "guild-events/123456789012345678/abcdef.png?size=512"
+	if url := event.CoverImageURL(ImageFormatPNG, ImageSize512); url != want {
+		t.Errorf("expected %q, got %q", want, url)
+	}
+
+	event.Image = "a_abcdef"
+	want = ImageBaseURL + "guild-events/123456789012345678/a_abcdef.webp?animated=true"
+	if url := event.CoverImageURL(ImageFormatWebP, ImageSizeDefault); url != want {
+		t.Errorf("expected %q, got %q", want, url)
+	}
+}
+
+func TestGuildScheduledEventURL(t *testing.T) {
+	event := GuildScheduledEvent{ID: 123456789012345678, GuildID: 876543210987654321}
+
+	want := "https://discord.com/events/876543210987654321/123456789012345678"
+	if url := event.URL(); url != want {
+		t.Errorf("expected %q, got %q", want, url)
+	}
+}
+
+func TestCreateExternalScheduledEvent(t *testing.T) {
+	start := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/guilds/1/scheduled-events" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if _, ok := body["channel_id"]; ok {
+			t.Error("channel_id should be omitted for external events")
+		}
+		if metadata, _ := body["entity_metadata"].(map[string]any); metadata["location"] != "Town hall" {
+			t.Errorf("entity_metadata = %v", body["entity_metadata"])
+		}
+		if body["scheduled_end_time"] != "2026-01-02T17:00:00Z" {
+			t.Errorf("scheduled_end_time = %v", body["scheduled_end_time"])
+		}
+		if body["entity_type"] != float64(GuildScheduledEventEntityTypeExternal) ||
+			body["privacy_level"] != float64(GuildScheduledEventPrivacyLevelGuildOnly) {
+			t.Errorf("body = %v", body)
+		}
+		io.WriteString(w, `{"id":"20","guild_id":"1","name":"meetup","entity_type":3,"status":1}`)
+	})
+
+	res := r.CreateScheduledEvent(1, CreateScheduledEventOptions{
+		Name:               "meetup",
+		EntityType:         GuildScheduledEventEntityTypeExternal,
+		EntityMetadata:     &GuildScheduledEventEntityMetadata{Location: "Town hall"},
+		ScheduledStartTime: start,
+		ScheduledEndTime:   &end,
+	})
+	if res.IsErr() {
+		t.Fatalf("CreateScheduledEvent: %v", res.Err())
+	}
+	if event := res.Value(); event.ID != 20 || !event.Status.Is(GuildScheduledEventStatusScheduled) {
+		t.Errorf("event = %+v", event)
+	}
+
+	res = r.CreateScheduledEvent(1, CreateScheduledEventOptions{
+		Name:               "meetup",
+		EntityType:         GuildScheduledEventEntityTypeExternal,
+		EntityMetadata:     &GuildScheduledEventEntityMetadata{Location: "Town hall"},
+		ScheduledStartTime: start,
+	})
+	if res.IsOk() {
+		t.Error("expected an error for an external event without an end time")
+	}
+}
+
+func TestFetchScheduledEventUsers(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/guilds/1/scheduled-events/20/users" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		query := req.URL.Query()
+		if query.Get("limit") != "2" || query.Get("with_member") != "true" || query.Get("after") != "5" {
+			t.Errorf("unexpected query %q", req.URL.RawQuery)
+		}
+		if query.Has("before") {
+			t.Error("before should be omitted when unset")
+		}
+		io.WriteString(w, `[
+			{"guild_scheduled_event_id":"20","user":{"id":"6","username":"a"},"member":{"user":{"id":"6","username":"a"},"nick":"alpha"}},
+			{"guild_scheduled_event_id":"20","user":{"id":"7","username":"b"}}
+		]`)
+	})
+
+	res := r.FetchScheduledEventUsers(1, 20, FetchScheduledEventUsersOptions{Limit: 2, WithMember: true, After: 5})
+	if res.IsErr() {
+		t.Fatalf("FetchScheduledEventUsers: %v", res.Err())
+	}
+	users := res.Value()
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+	if member := users[0].Member; member == nil || member.Nickname != "alpha" || member.GuildID != 1 {
+		t.Errorf("member = %+v", member)
+	}
+	if users[1].Member != nil || users[1].User.ID != 7 {
+		t.Errorf("second user = %+v", users[1])
+	}
+}
@@ -0,0 +1,882 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+func TestCreateGuild(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" || req.URL.Path != "/guilds" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+
+		buf, _ := io.ReadAll(req.Body)
+		var body struct {
+			Name     string `json:"name"`
+			Channels []struct {
+				ID       Snowflake   `json:"id"`
+				Name     string      `json:"name"`
+				Type     ChannelType `json:"type"`
+				ParentID Snowflake   `json:"parent_id"`
+			} `json:"channels"`
+		}
+		if err := json.Unmarshal(buf, &body); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		if body.Name != "My Guild" {
+			t.Errorf("expected name 'My Guild', got %q", body.Name)
+		}
+		if len(body.Channels) != 3 {
+			t.Fatalf("expected 3 channels in body, got %d", len(body.Channels))
+		}
+		if body.Channels[0].ID != 1 || body.Channels[0].Type != ChannelTypeGuildCategory {
+			t.Errorf("unexpected category %+v", body.Channels[0])
+		}
+		if body.Channels[1].Name != "general" || body.Channels[1].ParentID != 1 {
+			t.Errorf("unexpected nested channel %+v", body.Channels[1])
+		}
+		if !strings.Contains(string(buf), `"afk_channel_id":"2"`) {
+			t.Errorf("expected the afk channel placeholder ID in body %s", buf)
+		}
+
+		w.Write([]byte(`{"id": "123456789012345678", "name": "My Guild", "roles": [{"id": "123456789012345678", "name": "@everyone"}]}`))
+	})
+
+	res := r.CreateGuild(CreateGuildOptions{
+		Name: "My Guild",
+		Channels: []CreateGuildChannelOptions{
+			{ID: 1, CreateChannelOptions: CreateChannelOptions{Name: "Text Channels", Type: ChannelTypeGuildCategory}},
+			{CreateChannelOptions: CreateChannelOptions{Name: "general", Type: ChannelTypeGuildText, ParentID: 1}},
+			{ID: 2, CreateChannelOptions: CreateChannelOptions{Name: "AFK", Type: ChannelTypeGuildVoice}},
+		},
+		AfkChannelID: 2,
+	})
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+
+	guild := res.Value()
+	if guild.ID != 123456789012345678 || guild.Name != "My Guild" {
+		t.Errorf("unexpected guild %d %q", guild.ID, guild.Name)
+	}
+	if len(guild.Roles) != 1 || guild.Roles[0].Name != "@everyone" {
+		t.Errorf("expected the @everyone role to be decoded, got %+v", guild.Roles)
+	}
+}
+
+func TestTimeoutMemberLimit(t *testing.T) {
+	requests := 0
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.Write([]byte(`{"user": {"id": "2"}}`))
+	})
+
+	res := r.TimeoutMember(1, 2, time.Now().Add(MaxTimeoutDuration+time.Minute), "")
+	if !res.IsErr() {
+		t.Errorf("expected an error for a timeout longer than 28 days")
+	}
+	if requests != 0 {
+		t.Errorf("expected no request to be sent, got %d", requests)
+	}
+
+	res = r.TimeoutMember(1, 2, time.Now().Add(MaxTimeoutDuration-time.Minute), "")
+	if res.IsErr() {
+		t.Errorf("unexpected error for a timeout within 28 days: %v", res.Err())
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request to be sent, got %d", requests)
+	}
+}
+
+func TestRemoveTimeoutSendsNull(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "PATCH" || req.URL.Path != "/guilds/1/members/2" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		if got := req.Header.Get(headerReason); got != "appeal" {
+			t.Errorf("expected reason 'appeal', got %q", got)
+		}
+
+		buf, _ := io.ReadAll(req.Body)
+		if string(buf) != `{"communication_disabled_until":null}` {
+			t.Errorf("unexpected body %s", buf)
+		}
+		w.Write([]byte(`{"user": {"id": "2"}}`))
+	})
+
+	res := r.RemoveTimeout(1, 2, "appeal")
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if res.Value().GuildID != 1 {
+		t.Errorf("expected guild id to be set on the returned member")
+	}
+}
+
+func TestAddAndRemoveMemberRoles(t *testing.T) {
+	var sentRoles []Snowflake
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "PATCH" || req.URL.Path != "/guilds/1/members/2" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body struct {
+			Roles []Snowflake `json:"roles"`
+		}
+		buf, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(buf, &body); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		sentRoles = body.Roles
+		roles, _ := json.Marshal(body.Roles)
+		w.Write([]byte(`{"user": {"id": "2"}, "roles": ` + string(roles) + `}`))
+	})
+	client.PutMember(Member{ID: 2, GuildID: 1, RoleIDs: []Snowflake{10, 20}})
+
+	if res := client.AddMemberRoles(1, 2, []Snowflake{20, 30, 40}, ""); res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if !slices.Equal(sentRoles, []Snowflake{10, 20, 30, 40}) {
+		t.Errorf("unexpected roles after add: %v", sentRoles)
+	}
+
+	if res := client.RemoveMemberRoles(1, 2, []Snowflake{10, 50}, ""); res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if !slices.Equal(sentRoles, []Snowflake{20, 30, 40}) {
+		t.Errorf("unexpected roles after remove: %v", sentRoles)
+	}
+}
+
+func TestAddMemberRolesFetchesUncachedMember(t *testing.T) {
+	var sentRoles []Snowflake
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case "GET":
+			w.Write([]byte(`{"user": {"id": "2"}, "roles": ["10"]}`))
+		case "PATCH":
+			var body struct {
+				Roles []Snowflake `json:"roles"`
+			}
+			buf, _ := io.ReadAll(req.Body)
+			json.Unmarshal(buf, &body)
+			sentRoles = body.Roles
+			w.Write([]byte(`{"user": {"id": "2"}}`))
+		}
+	})
+
+	if res := client.AddMemberRoles(1, 2, []Snowflake{30}, ""); res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if !slices.Equal(sentRoles, []Snowflake{10, 30}) {
+		t.Errorf("unexpected roles after add: %v", sentRoles)
+	}
+}
+
+func TestConsecutiveAddMemberRolesKeepEarlierRoles(t *testing.T) {
+	var sentRoles []Snowflake
+	fetches := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case "GET":
+			fetches++
+			w.Write([]byte(`{"user": {"id": "2"}, "roles": ["10"]}`))
+		case "PATCH":
+			var body struct {
+				Roles []Snowflake `json:"roles"`
+			}
+			buf, _ := io.ReadAll(req.Body)
+			json.Unmarshal(buf, &body)
+			if body.Roles == nil {
+				// a timeout, pretend someone else changed the roles meanwhile
+				w.Write([]byte(`{"user": {"id": "2"}, "roles": ["99"]}`))
+				return
+			}
+			sentRoles = body.Roles
+			roles, _ := json.Marshal(body.Roles)
+			w.Write([]byte(`{"user": {"id": "2"}, "roles": ` + string(roles) + `}`))
+		}
+	})
+
+	if res := client.AddMemberRoles(1, 2, []Snowflake{20}, ""); res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if res := client.AddMemberRoles(1, 2, []Snowflake{30}, ""); res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if !slices.Equal(sentRoles, []Snowflake{10, 20, 30}) {
+		t.Errorf("second add dropped earlier roles: %v", sentRoles)
+	}
+	if fetches != 1 {
+		t.Errorf("expected the member to be fetched once, got %d", fetches)
+	}
+
+	if res := client.TimeoutMember(1, 2, time.Now().Add(time.Hour), ""); res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if member := client.GetMember(1, 2); !member.IsPresent() || !slices.Equal(member.Get().RoleIDs, []Snowflake{99}) {
+		t.Errorf("TimeoutMember did not write the member through to the cache")
+	}
+}
+
+func TestGuildChannelsOrCachedDuringOutage(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client.PutGuild(Guild{ID: 1})
+	client.PutChannel(&TextChannel{GuildChannelFields: GuildChannelFields{ChannelFields: ChannelFields{ID: 10}, GuildID: 1, Name: "general"}})
+	client.PutChannel(&VoiceChannel{GuildChannelFields: GuildChannelFields{ChannelFields: ChannelFields{ID: 11}, GuildID: 1, Name: "voice"}})
+
+	client.handlersManagers["GUILD_DELETE"].handleEvent(client, false, 0, []byte(`{"id": "1", "unavailable": true}`))
+
+	res := client.GuildChannelsOrCached(1)
+	if res.IsErr() {
+		t.Fatalf("expected cached channels during outage, got error: %v", res.Err())
+	}
+	if len(res.Value()) != 2 {
+		t.Errorf("expected 2 cached channels, got %d", len(res.Value()))
+	}
+	if requests != 0 {
+		t.Errorf("expected no REST request, got %d", requests)
+	}
+	if !client.GetGuild(1).Get().Unavailable {
+		t.Errorf("expected guild to be marked unavailable")
+	}
+}
+
+func TestGuildChannelsOrCachedAfterRemoval(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/guilds/1/channels" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		w.Write([]byte(`[{"id": "12", "type": 0, "guild_id": "1", "name": "fresh"}]`))
+	})
+
+	client.PutGuild(Guild{ID: 1})
+	client.PutChannel(&TextChannel{GuildChannelFields: GuildChannelFields{ChannelFields: ChannelFields{ID: 10}, GuildID: 1, Name: "general"}})
+
+	client.handlersManagers["GUILD_DELETE"].handleEvent(client, false, 0, []byte(`{"id": "1"}`))
+
+	if client.HasGuild(1) || client.HasChannel(10) {
+		t.Fatalf("expected removed guild to be evicted from the cache")
+	}
+
+	res := client.GuildChannelsOrCached(1)
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if len(res.Value()) != 1 || res.Value()[0].GetID() != 12 {
+		t.Errorf("expected fetched channel 12, got %v", res.Value())
+	}
+	if !client.HasChannel(12) {
+		t.Errorf("expected fetched channel to be written to the cache")
+	}
+}
+
+func TestClientRole(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		switch req.URL.Path {
+		case "/guilds/1/roles/10":
+			io.WriteString(w, `{"id":"10","name":"mods"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"code":10011,"message":"Unknown Role"}`)
+		}
+	})
+
+	client.PutRole(Role{ID: 5, GuildID: 1, Name: "cached"})
+	res := client.Role(1, 5)
+	if res.IsErr() {
+		t.Fatalf("Role: %v", res.Err())
+	}
+	if role, err := res.Value().GetErr(); err != nil || role.Name != "cached" {
+		t.Errorf("cache hit = %+v, %v", role, err)
+	}
+	if requests != 0 {
+		t.Errorf("cache hit sent %d requests, want 0", requests)
+	}
+
+	res = client.Role(1, 10)
+	if res.IsErr() {
+		t.Fatalf("Role: %v", res.Err())
+	}
+	role, err := res.Value().GetErr()
+	if err != nil || role.Name != "mods" || role.GuildID != 1 {
+		t.Errorf("fetched role = %+v, %v", role, err)
+	}
+
+	res = client.FindRole(1, 11)
+	if res.IsErr() {
+		t.Fatalf("FindRole on a missing role should not fail, got %v", res.Err())
+	}
+	if res.Value().IsPresent() {
+		t.Error("FindRole on a missing role should return None")
+	}
+}
+
+func TestEnsureGuildRolesLazyFetch(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Method != "GET" || req.URL.Path != "/guilds/1/roles" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		io.WriteString(w, `[{"id":"1","name":"@everyone","position":0},{"id":"20","name":"mods","position":2},{"id":"10","name":"members","position":1}]`)
+	})
+
+	// a role cached from a GUILD_ROLE_CREATE, plus a stale one, doesn't make the set complete.
+	client.PutRole(Role{ID: 10, GuildID: 1, Name: "members"})
+	client.PutRole(Role{ID: 30, GuildID: 1, Name: "deleted"})
+
+	res := client.EnsureGuildRoles(1)
+	if res.IsErr() {
+		t.Fatalf("EnsureGuildRoles: %v", res.Err())
+	}
+	ids := func(roles []Role) []Snowflake {
+		out := make([]Snowflake, len(roles))
+		for i, role := range roles {
+			out[i] = role.ID
+		}
+		return out
+	}
+	if got := ids(res.Value()); !slices.Equal(got, []Snowflake{1, 10, 20}) {
+		t.Errorf("roles = %v, want sorted by position", got)
+	}
+	if requests != 1 {
+		t.Fatalf("sent %d requests, want 1", requests)
+	}
+	if !client.hasCompleteGuildRoles(1) || client.HasRoles(30) || !client.HasRoles(1, 10, 20) {
+		t.Error("fetched roles should replace the cached ones and be marked complete")
+	}
+
+	res = client.EnsureGuildRoles(1)
+	if res.IsErr() || !slices.Equal(ids(res.Value()), []Snowflake{1, 10, 20}) {
+		t.Errorf("cached roles = %v, %v", res.Value(), res.Err())
+	}
+	if requests != 1 {
+		t.Errorf("complete cache sent %d requests, want 1", requests)
+	}
+}
+
+func TestGuildCreateMarksRolesComplete(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	})
+	client.handlersManagers["GUILD_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"1","name":"a","roles":[{"id":"1","name":"@everyone"},{"id":"10","name":"members"}]}`))
+
+	if !client.hasCompleteGuildRoles(1) {
+		t.Fatal("GUILD_CREATE roles should be marked complete")
+	}
+	if res := client.EnsureGuildRoles(1); res.IsErr() || len(res.Value()) != 2 {
+		t.Errorf("EnsureGuildRoles = %v, %v", res.Value(), res.Err())
+	}
+
+	client.DelRoles(1, 10)
+	if client.hasCompleteGuildRoles(1) {
+		t.Error("the marker should be dropped with the guild's last role")
+	}
+}
+
+func TestEnsureGuildRolesCustomCacheManager(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		io.WriteString(w, `[{"id":"1","name":"@everyone","position":0}]`)
+	})
+	// a custom cache manager only implementing CacheManager can't tell if the roles are complete.
+	client.CacheManager = struct{ CacheManager }{NewInMemoryCacheManager(CacheFlagsAll)}
+
+	for range 2 {
+		if res := client.EnsureGuildRoles(1); res.IsErr() || len(res.Value()) != 1 {
+			t.Fatalf("EnsureGuildRoles = %v, %v", res.Value(), res.Err())
+		}
+	}
+	if requests != 2 {
+		t.Errorf("sent %d requests, want the roles fetched every time", requests)
+	}
+}
+
+func TestModifyGuildWelcomeScreenChecks(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		io.WriteString(w, `{"description":"hi","welcome_channels":[]}`)
+	})
+
+	client.PutGuild(Guild{ID: 1, Features: []GuildFeature{GuildFeatureNews}})
+	res := client.ModifyGuildWelcomeScreen(1, ModifyGuildWelcomeScreenOptions{Enabled: optional.Some(true)})
+	if res.IsOk() {
+		t.Fatal("expected an error for a guild without the Community feature")
+	}
+	if requests != 0 {
+		t.Errorf("feature check failure sent %d requests, want 0", requests)
+	}
+
+	res = client.ModifyGuildWelcomeScreen(1, ModifyGuildWelcomeScreenOptions{
+		Enabled:          optional.Some(true),
+		SkipFeatureCheck: true,
+	})
+	if res.IsErr() {
+		t.Fatalf("ModifyGuildWelcomeScreen with SkipFeatureCheck: %v", res.Err())
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+
+	client.PutGuild(Guild{ID: 2, Features: []GuildFeature{GuildFeatureCommunity}})
+	channels := make([]GuildWelcomeChannel, MaxWelcomeScreenChannels+1)
+	res = client.ModifyGuildWelcomeScreen(2, ModifyGuildWelcomeScreenOptions{WelcomeChannels: optional.Some(channels)})
+	if res.IsOk() {
+		t.Fatal("expected an error for too many welcome channels")
+	}
+	if requests != 1 {
+		t.Errorf("too many channels sent a request")
+	}
+}
+
+func TestCloneTextChannel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/guilds/1/channels" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["name"] != "rules-copy" || body["type"] != float64(ChannelTypeGuildText) || body["parent_id"] != "300" {
+			t.Errorf("body = %v", body)
+		}
+		if body["topic"] != "Read me" || body["nsfw"] != true || body["rate_limit_per_user"] != float64(30) {
+			t.Errorf("copied settings = %v", body)
+		}
+		if overwrites, _ := body["permission_overwrites"].([]any); len(overwrites) != 1 {
+			t.Errorf("permission_overwrites = %v", body["permission_overwrites"])
+		}
+		if _, ok := body["bitrate"]; ok {
+			t.Error("voice settings should not be sent for a text channel")
+		}
+		io.WriteString(w, `{"id":"21","type":0,"guild_id":"1","name":"rules-copy","parent_id":"300"}`)
+	})
+
+	var source TextChannel
+	if err := json.Unmarshal([]byte(`{
+		"id": "20", "type": 0, "guild_id": "1", "name": "rules", "parent_id": "200",
+		"topic": "Read me", "nsfw": true, "rate_limit_per_user": 30,
+		"permission_overwrites": [{"id": "1", "type": 0, "allow": "0", "deny": "2048"}]
+	}`), &source); err != nil {
+		t.Fatalf("unmarshal source: %v", err)
+	}
+	client.PutChannel(&source)
+
+	res := client.CloneChannel(20, CloneChannelOptions{Name: "rules-copy", ParentID: 300})
+	if res.IsErr() {
+		t.Fatalf("CloneChannel: %v", res.Err())
+	}
+	if clone := res.Value(); clone.GetID() != 21 || clone.GetName() != "rules-copy" {
+		t.Errorf("clone = %+v", clone)
+	}
+}
+
+func TestCreateChannelOptionsValidate(t *testing.T) {
+	opts := CreateChannelOptions{Name: "general", Type: ChannelTypeGuildText, Bitrate: 64000}
+	if err := opts.Validate(); err == nil {
+		t.Error("expected an error for a bitrate on a text channel")
+	}
+	opts = CreateChannelOptions{Name: "lounge", Type: ChannelTypeGuildVoice, Bitrate: 64000, UserLimit: 10}
+	if err := opts.Validate(); err != nil {
+		t.Errorf("unexpected error for a voice channel: %v", err)
+	}
+}
+
+func TestGuildVanityInviteURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		features []GuildFeature
+		want     optional.Option[string]
+	}{
+		{"set", "dwaz", []GuildFeature{GuildFeatureVanityURL}, optional.Some("https://discord.gg/dwaz")},
+		{"no code", "", []GuildFeature{GuildFeatureVanityURL}, optional.None[string]()},
+		{"no feature", "dwaz", nil, optional.None[string]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guild := Guild{VanityURLCode: tt.code, Features: tt.features}
+			got := guild.VanityInviteURL()
+			if got.IsPresent() != tt.want.IsPresent() || got.OrEmpty() != tt.want.OrEmpty() {
+				t.Errorf("VanityInviteURL() = %v, want %v", got, tt.want)
+			}
+
+			partial := PartialGuild{VanityURLCode: tt.code, Features: tt.features}
+			if got := partial.VanityInviteURL(); got.OrEmpty() != tt.want.OrEmpty() || got.IsPresent() != tt.want.IsPresent() {
+				t.Errorf("PartialGuild.VanityInviteURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+const testGuildInvites = `[
+	{"code":"abc","channel":{"id":"10","type":0,"name":"general"},"uses":3},
+	{"code":"ABC","channel":{"id":"20","type":0,"name":"rules"},"uses":1},
+	{"code":"xyz","channel":{"id":"10","type":0,"name":"general"},"uses":0},
+	{"code":"nochan","uses":0}
+]`
+
+func TestGuildInvitesForChannel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet || req.URL.Path != "/guilds/1/invites" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		io.WriteString(w, testGuildInvites)
+	})
+
+	res := client.GuildInvitesForChannel(1, 10)
+	if res.IsErr() {
+		t.Fatalf("GuildInvitesForChannel: %v", res.Err())
+	}
+	var codes []string
+	for _, invite := range res.Value() {
+		codes = append(codes, invite.Code)
+	}
+	if !slices.Equal(codes, []string{"abc", "xyz"}) {
+		t.Errorf("codes = %v, want [abc xyz]", codes)
+	}
+
+	if res := client.GuildInvitesForChannel(1, 30); res.IsErr() || len(res.Value()) != 0 {
+		t.Errorf("unknown channel = %v, %v", res.Value(), res.Err())
+	}
+}
+
+func TestFindInvite(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, testGuildInvites)
+	})
+
+	res := client.FindInvite(1, "ABC")
+	if res.IsErr() {
+		t.Fatalf("FindInvite: %v", res.Err())
+	}
+	if !res.Value().IsPresent() {
+		t.Fatal("invite ABC not found")
+	}
+	invite := res.Value().Get()
+	if invite.Code != "ABC" || invite.Channel.ID != 20 || invite.Uses != 1 {
+		t.Errorf("invite = %+v", invite)
+	}
+
+	if res := client.FindInvite(1, "missing"); res.IsErr() || res.Value().IsPresent() {
+		t.Errorf("missing invite = %v, %v", res.Value(), res.Err())
+	}
+}
+
+func TestFindInviteError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message":"Missing Permissions","code":50013}`)
+	})
+
+	res := client.FindInvite(1, "abc")
+	var restErr *RestError
+	if !errors.As(res.Err(), &restErr) || restErr.Code != RestErrorCodeMissingPermissions {
+		t.Errorf("error = %v, want missing permissions", res.Err())
+	}
+}
+
+func TestGuildOnboardingCacheInvalidatedByModify(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	var fetches int
+	r := newMockTransportRequester(DefaultRequesterConfig(), clock, func(req *http.Request) (*http.Response, error) {
+		switch req.Method {
+		case http.MethodGet:
+			fetches++
+			if fetches == 1 {
+				return mockResponse(http.StatusOK, `{"guild_id":"1","enabled":false}`), nil
+			}
+			return mockResponse(http.StatusOK, `{"guild_id":"1","enabled":true}`), nil
+		case http.MethodPut:
+			return mockResponse(http.StatusOK, `{"guild_id":"1","enabled":true}`), nil
+		}
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		return mockResponse(http.StatusNotFound, ""), nil
+	})
+
+	for range 3 {
+		if res := r.FetchGuildOnboarding(1); res.IsErr() || res.Value().Enabled {
+			t.Fatalf("FetchGuildOnboarding = %+v, %v", res.Value(), res.Err())
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("fetches = %d, want 1 while cached", fetches)
+	}
+
+	if res := r.ModifyGuildOnboarding(1, ModifyGuildOnboardingOptions{Enabled: optional.Some(true)}); res.IsErr() {
+		t.Fatalf("ModifyGuildOnboarding: %v", res.Err())
+	}
+	res := r.FetchGuildOnboarding(1)
+	if res.IsErr() || !res.Value().Enabled {
+		t.Errorf("FetchGuildOnboarding after modify = %+v, %v", res.Value(), res.Err())
+	}
+	if fetches != 2 {
+		t.Errorf("fetches = %d, want 2 after modify", fetches)
+	}
+}
+
+func TestGuildSettingsCacheTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	var fetches int
+	r := newMockTransportRequester(DefaultRequesterConfig(), clock, func(req *http.Request) (*http.Response, error) {
+		fetches++
+		return mockResponse(http.StatusOK, `{"enabled":true,"channel_id":"5"}`), nil
+	})
+
+	r.FetchGuildWidgetSettings(1)
+	clock.advance(DefaultRequesterConfig().SettingsCacheTTL - time.Second)
+	r.FetchGuildWidgetSettings(1)
+	if fetches != 1 {
+		t.Errorf("fetches = %d before expiry, want 1", fetches)
+	}
+	r.FetchGuildWidgetSettings(2)
+	if fetches != 2 {
+		t.Errorf("fetches = %d, want another guild to be fetched", fetches)
+	}
+
+	clock.advance(time.Second)
+	r.FetchGuildWidgetSettings(1)
+	if fetches != 3 {
+		t.Errorf("fetches = %d after expiry, want 3", fetches)
+	}
+}
+
+func TestGuildSettingsCacheDisabled(t *testing.T) {
+	config := DefaultRequesterConfig()
+	config.SettingsCacheTTL = -1
+	var fetches int
+	r := newMockTransportRequester(config, &fakeClock{}, func(req *http.Request) (*http.Response, error) {
+		fetches++
+		return mockResponse(http.StatusOK, `{"description":"hi"}`), nil
+	})
+
+	r.FetchGuildWelcomeScreen(1)
+	r.FetchGuildWelcomeScreen(1)
+	if fetches != 2 {
+		t.Errorf("fetches = %d, want 2 with the cache disabled", fetches)
+	}
+}
+
+func TestGuildSettingsCacheSkipsErrors(t *testing.T) {
+	var fetches int
+	r := newMockTransportRequester(DefaultRequesterConfig(), &fakeClock{}, func(req *http.Request) (*http.Response, error) {
+		fetches++
+		return mockResponse(http.StatusForbidden, `{"message":"Missing Access","code":50001}`), nil
+	})
+
+	r.FetchGuildOnboarding(1)
+	if res := r.FetchGuildOnboarding(1); !IsForbidden(res.Err()) {
+		t.Errorf("error = %v", res.Err())
+	}
+	if fetches != 2 {
+		t.Errorf("fetches = %d, errors must not be cached", fetches)
+	}
+}
+
+func TestClientGuildReadThrough(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Path != "/guilds/1" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		io.WriteString(w, `{"id":"1","name":"fetched","roles":[{"id":"1","name":"@everyone"}]}`)
+	})
+
+	for range 2 {
+		res := client.Guild(1)
+		if res.IsErr() || res.Value().Name != "fetched" {
+			t.Fatalf("Guild(1) = %+v", res)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected a single request, got %d", requests)
+	}
+	if !client.hasCompleteGuildRoles(1) || !client.EveryoneRole(1).IsPresent() {
+		t.Fatal("expected the fetched roles to be cached")
+	}
+
+	client.PutGuild(Guild{ID: 2, Name: "cached"})
+	if res := client.Guild(2); res.IsErr() || res.Value().Name != "cached" {
+		t.Fatalf("Guild(2) = %+v", res)
+	}
+	if requests != 1 {
+		t.Fatalf("expected a cache hit without request, got %d requests", requests)
+	}
+}
+
+func TestClientMemberReadThrough(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Path != "/guilds/1/members/2" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		io.WriteString(w, `{"nick":"bob","user":{"id":"2","username":"bob"}}`)
+	})
+
+	for range 2 {
+		res := client.Member(1, 2)
+		if res.IsErr() || res.Value().Nickname != "bob" || res.Value().GuildID != 1 || res.Value().User.Username != "bob" {
+			t.Fatalf("Member(1, 2) = %+v", res)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected a single request, got %d", requests)
+	}
+	if !client.GetUser(2).IsPresent() {
+		t.Fatal("expected the member's user to be cached")
+	}
+
+	// a cached member whose user isn't cached is fetched again to return the full member.
+	client.DelUser(2)
+	if res := client.Member(1, 2); res.IsErr() || res.Value().User.ID != 2 {
+		t.Fatalf("Member(1, 2) without a cached user = %+v", res)
+	}
+	if requests != 2 {
+		t.Fatalf("expected the member to be fetched again, got %d requests", requests)
+	}
+}
+
+func TestClientMemberCount(t *testing.T) {
+	previewStatus := http.StatusOK
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Path != "/guilds/2/preview" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		w.WriteHeader(previewStatus)
+		io.WriteString(w, `{"id":"2","approximate_member_count":1234}`)
+	})
+
+	client.PutGuild(Guild{ID: 1, MemberCount: 42})
+	if n, exact, err := client.MemberCount(1); err != nil || n != 42 || !exact {
+		t.Fatalf("MemberCount(1) = %d, %v, %v, want the exact gateway count", n, exact, err)
+	}
+	if requests != 0 {
+		t.Fatalf("expected no request for a cached guild, got %d", requests)
+	}
+
+	// guild cached from REST without a gateway member count
+	client.PutGuild(Guild{ID: 2})
+	if n, exact, err := client.MemberCount(2); err != nil || n != 1234 || exact {
+		t.Fatalf("MemberCount(2) = %d, %v, %v, want the approximate preview count", n, exact, err)
+	}
+
+	// a join must not turn the unknown count of a REST cached guild into an exact count of 1
+	client.handlersManagers["GUILD_MEMBER_ADD"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"2","user":{"id":"5","username":"alice"},"roles":[]}`))
+	if n, exact, err := client.MemberCount(2); err != nil || n != 1234 || exact {
+		t.Fatalf("MemberCount(2) after a join = %d, %v, %v, want the approximate preview count", n, exact, err)
+	}
+
+	previewStatus = http.StatusNotFound
+	if _, _, err := client.MemberCount(2); err == nil {
+		t.Fatal("expected the preview error to be returned")
+	}
+}
+
+func TestActiveThreadsResponseFilters(t *testing.T) {
+	var response ActiveThreadsResponse
+	err := json.Unmarshal([]byte(`{
+		"threads":[
+			{"id":"23","type":11,"guild_id":"1","name":"c","parent_id":"11"},
+			{"id":"22","type":11,"guild_id":"1","name":"b","parent_id":"10"},
+			{"id":"21","type":11,"guild_id":"1","name":"a","parent_id":"10"}
+		],
+		"members":[{"id":"21","user_id":"5"},{"id":"23","user_id":"5"}]
+	}`), &response)
+	if err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+
+	threadIDs := func(threads []ThreadChannel) []Snowflake {
+		var ids []Snowflake
+		for _, thread := range threads {
+			ids = append(ids, thread.ID)
+		}
+		return ids
+	}
+	if got, want := threadIDs(response.ByParent(10)), []Snowflake{22, 21}; !slices.Equal(got, want) {
+		t.Errorf("ByParent(10) = %v, want %v", got, want)
+	}
+	if got := response.ByParent(12); len(got) != 0 {
+		t.Errorf("ByParent(12) = %v, want none", threadIDs(got))
+	}
+	if got, want := threadIDs(response.JoinedBy(5)), []Snowflake{23, 21}; !slices.Equal(got, want) {
+		t.Errorf("JoinedBy(5) = %v, want %v", got, want)
+	}
+	if got := response.JoinedBy(6); len(got) != 0 {
+		t.Errorf("JoinedBy(6) = %v, want none", threadIDs(got))
+	}
+}
+
+func TestGatewayGuildUnmarshalSetsGuildID(t *testing.T) {
+	var guild GatewayGuild
+	err := json.Unmarshal([]byte(`{
+		"id":"1",
+		"roles":[{"id":"1"}],
+		"emojis":[{"id":"2","name":"wave"}],
+		"stickers":[{"id":"3","name":"hi"}],
+		"members":[{"user":{"id":"4"}}],
+		"voice_states":[{"user_id":"4","channel_id":"10"}],
+		"channels":[{"id":"10","type":2,"name":"voice"}],
+		"threads":[{"id":"20","type":11,"name":"thread","parent_id":"11"}],
+		"stage_instances":[{"id":"30","channel_id":"12","topic":"talk"}],
+		"soundboard_sounds":[{"sound_id":"40","name":"boom"}]
+	}`), &guild)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	checks := map[string]Snowflake{
+		"roles":             guild.Roles[0].GuildID,
+		"emojis":            guild.Emojis[0].GuildID,
+		"stickers":          guild.Stickers[0].GuildID,
+		"members":           guild.Members[0].GuildID,
+		"voice_states":      guild.VoiceStates[0].GuildID,
+		"channels":          guild.Channels[0].GetGuildID(),
+		"threads":           guild.Threads[0].GuildID,
+		"stage_instances":   guild.StageInstances[0].GuildID,
+		"soundboard_sounds": guild.SoundboardSounds[0].GuildID,
+	}
+	for field, guildID := range checks {
+		if guildID != 1 {
+			t.Errorf("%s: GuildID = %d, want 1", field, guildID)
+		}
+	}
+}
@@ -54,7 +54,7 @@ func isAnimatedHash(hash string) bool {
 func buildImageURL(baseURL, path, hash string, config ImageConfig, allowedFormats [5]ImageFormat) string {
 	allowed := false
 	for _, f := range allowedFormats {
-		if config.Format == f {
+		if f != "" && config.Format == f {
 			allowed = true
 			break
 		}
@@ -87,10 +87,20 @@ func buildImageURL(baseURL, path, hash string, config ImageConfig, allowedFormat
  ***********************/
 
 func EmojiURL(emojiID Snowflake, format ImageFormat, size ImageSize) string {
+	return emojiURL(emojiID, format == ImageFormatGIF, format, size)
+}
+
+// emojiURL builds an emoji URL, emojis have no image hash so animated
+// stands in for the "a_" prefix of animated hashes.
+func emojiURL(emojiID Snowflake, animated bool, format ImageFormat, size ImageSize) string {
 	config := ImageConfig{Format: format, Size: size}
 	allowedFormats := [5]ImageFormat{ImageFormatPNG, ImageFormatGIF, ImageFormatWebP, ImageFormatJPEG, ImageFormatAVIF}
 	path := "emojis/" + emojiID.String()
-	return buildImageURL(ImageBaseURL, path, "", config, allowedFormats)
+	hash := ""
+	if animated {
+		hash = "a_"
+	}
+	return buildImageURL(ImageBaseURL, path, hash, config, allowedFormats)
 }
 
 /***********************
@@ -275,3 +285,76 @@ func TeamIconURL(teamID Snowflake, iconHash string, format ImageFormat, size Ima
 	path := "team-icons/" + teamID.String() + "/" + iconHash 
 	return buildImageURL(ImageBaseURL, path, iconHash, config, allowedFormats)
 }
+
+/***********************
+ *   Client defaults   *
+ ***********************/
+
+// ImageConfig returns the image format and size used by the image URL helpers of the client,
+// set with WithDefaultImageFormat and WithDefaultImageSize.
+//
+// Unset values fall back to ImageFormatDefault and ImageSizeDefault.
+func (c *Client) ImageConfig() ImageConfig {
+	config := c.imageConfig
+	if config.Format == "" {
+		config.Format = ImageFormatDefault
+	}
+	return config
+}
+
+// UserAvatarURL returns the URL to the user's avatar using the client's default image format and size.
+//
+// Users without a custom avatar get the URL to their default avatar.
+func (c *Client) UserAvatarURL(user *User) string {
+	config := c.ImageConfig()
+	return user.AvatarURLWith(config.Format, config.Size)
+}
+
+// UserBannerURL returns the URL to the user's banner using the client's default image format and size,
+// or an empty string if the user has no banner.
+func (c *Client) UserBannerURL(user *User) string {
+	config := c.ImageConfig()
+	return user.BannerURLWith(config.Format, config.Size)
+}
+
+// MemberAvatarURL returns the URL to the member's guild avatar using the client's default image format and size,
+// or an empty string if the member has no guild avatar.
+func (c *Client) MemberAvatarURL(member *Member) string {
+	config := c.ImageConfig()
+	return member.AvatarURLWith(config.Format, config.Size)
+}
+
+// GuildIconURL returns the URL to the guild's icon using the client's default image format and size,
+// or an empty string if the guild has no icon.
+func (c *Client) GuildIconURL(guild *Guild) string {
+	config := c.ImageConfig()
+	return guild.IconURLWith(config.Format, config.Size)
+}
+
+// GuildBannerURL returns the URL to the guild's banner using the client's default image format and size,
+// or an empty string if the guild has no banner.
+func (c *Client) GuildBannerURL(guild *Guild) string {
+	config := c.ImageConfig()
+	return guild.BannerURLWith(config.Format, config.Size)
+}
+
+// GuildSplashURL returns the URL to the guild's invite splash using the client's default image format and size,
+// or an empty string if the guild has no splash.
+func (c *Client) GuildSplashURL(guild *Guild) string {
+	config := c.ImageConfig()
+	return guild.SplashURLWith(config.Format, config.Size)
+}
+
+// RoleIconURL returns the URL to the role's icon using the client's default image format and size,
+// or an empty string if the role has no icon.
+func (c *Client) RoleIconURL(role *Role) string {
+	config := c.ImageConfig()
+	return role.IconURLWith(config.Format, config.Size)
+}
+
+// EmojiURL returns the URL to the custom emoji's image using the client's default image format and size,
+// or an empty string for unicode emojis.
+func (c *Client) EmojiURL(emoji *Emoji) string {
+	config := c.ImageConfig()
+	return emoji.URLWith(config.Format, config.Size)
+}
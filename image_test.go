@@ -0,0 +1,63 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClientDefaultImageConfig(t *testing.T) {
+	client := &Client{}
+	if got := client.ImageConfig(); got.Format != ImageFormatDefault || got.Size != ImageSizeDefault {
+		t.Errorf("ImageConfig() = %+v, want package defaults", got)
+	}
+
+	user := &User{ID: 1, Avatar: "abc"}
+	if got, want := client.UserAvatarURL(user), user.AvatarURL(); got != want {
+		t.Errorf("UserAvatarURL() = %q, want %q", got, want)
+	}
+}
+
+func TestClientDefaultImageOverride(t *testing.T) {
+	client := New(context.Background(), WithDefaultImageFormat(ImageFormatWebP), WithDefaultImageSize(ImageSize256))
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"user avatar", client.UserAvatarURL(&User{ID: 1, Avatar: "abc"}), ImageBaseURL + "avatars/1/abc.webp?size=256"},
+		{"animated avatar", client.UserAvatarURL(&User{ID: 1, Avatar: "a_abc"}), ImageBaseURL + "avatars/1/a_abc.webp?size=256&animated=true"},
+		{"guild icon", client.GuildIconURL(&Guild{ID: 2, Icon: "def"}), ImageBaseURL + "icons/2/def.webp?size=256"},
+		{"no guild icon", client.GuildIconURL(&Guild{ID: 2}), ""},
+		{"custom emoji", client.EmojiURL(&Emoji{ID: 3}), ImageBaseURL + "emojis/3.webp?size=256"},
+		{"unicode emoji", client.EmojiURL(&Emoji{Name: "👍"}), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientDefaultImageFormatFallback(t *testing.T) {
+	// Lottie isn't supported by avatars, the first supported format is used instead.
+	client := New(context.Background(), WithDefaultImageFormat(ImageFormatLottie))
+	if got, want := client.UserAvatarURL(&User{ID: 1, Avatar: "abc"}), ImageBaseURL+"avatars/1/abc.png"; got != want {
+		t.Errorf("UserAvatarURL() = %q, want %q", got, want)
+	}
+}
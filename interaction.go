@@ -17,6 +17,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+
+	"github.com/marouanesouiri/stdx/result"
 )
 
 // InteractionType represents the type of an interaction in Discord.
@@ -258,6 +260,24 @@ type InteractionFields struct {
 
 	// Token is a token used to respond to the interaction.
 	Token string `json:"token"`
+
+	// AuthorizingIntegrationOwners maps installation contexts that the interaction was authorized for
+	// to related user or guild IDs.
+	//
+	// Note:
+	//   - The ApplicationIntegrationTypeGuildInstall key holds the guild ID, or 0 when the
+	//     interaction is triggered from a bot DM of a guild-installed app.
+	//   - The ApplicationIntegrationTypeUserInstall key holds the ID of the authorizing user.
+	AuthorizingIntegrationOwners map[ApplicationIntegrationType]Snowflake `json:"authorizing_integration_owners"`
+
+	// Context indicates where the interaction was triggered from.
+	//
+	// Note:
+	//   - Not sent for PingInteraction.
+	Context InteractionContextType `json:"context"`
+
+	// state tracks the responses sent to the interaction, set when it's received through the gateway.
+	state *interactionState
 }
 
 func (i *InteractionFields) GetID() Snowflake {
@@ -276,6 +296,26 @@ func (i *InteractionFields) GetToken() string {
 	return i.Token
 }
 
+func (i *InteractionFields) GetContext() InteractionContextType {
+	return i.Context
+}
+
+func (i *InteractionFields) GetAuthorizingIntegrationOwners() map[ApplicationIntegrationType]Snowflake {
+	return i.AuthorizingIntegrationOwners
+}
+
+// IsUserInstalled reports whether the interaction was authorized through a user installation of the app.
+func (i *InteractionFields) IsUserInstalled() bool {
+	_, ok := i.AuthorizingIntegrationOwners[ApplicationIntegrationTypeUserInstall]
+	return ok
+}
+
+// IsGuildInstalled reports whether the interaction was authorized through a guild installation of the app.
+func (i *InteractionFields) IsGuildInstalled() bool {
+	_, ok := i.AuthorizingIntegrationOwners[ApplicationIntegrationTypeGuildInstall]
+	return ok
+}
+
 // ApplicationCommandInteractionFields holds fields common to all application command interactions.
 //
 // Reference: https://discord.com/developers/docs/interactions/receiving-and-responding
@@ -312,13 +352,6 @@ type ApplicationCommandInteractionFields struct {
 	// Entitlements is a list of entitlements for the invoking user.
 	Entitlements []Entitlement `json:"entitlement"`
 
-	// AuthorizingIntegrationOwners maps installation contexts that the interaction was authorized for
-	// to related user or guild IDs.
-	AuthorizingIntegrationOwners map[ApplicationIntegrationType]Snowflake `json:"authorizing_integration_owners"`
-
-	// Context indicates where the interaction was triggered from.
-	Context InteractionContextType `json:"context"`
-
 	// AttachmentSizeLimit is the maximum size of attachments in bytes for this interaction.
 	AttachmentSizeLimit int `json:"attachment_size_limit"`
 }
@@ -363,6 +396,9 @@ type MessageCommandInteraction struct {
 // TODO: continue the three interactions under this comment.
 type ComponentInteraction struct {
 	InteractionFields
+
+	// Message is the message the component is attached to.
+	Message Message `json:"message"`
 }
 type AutoCompleteInteraction struct {
 	InteractionFields
@@ -410,6 +446,15 @@ type Interaction interface {
 	GetType() InteractionType
 	GetApplicationID() Snowflake
 	GetToken() string
+	GetContext() InteractionContextType
+	GetAuthorizingIntegrationOwners() map[ApplicationIntegrationType]Snowflake
+	Acknowledged() bool
+	Respond(resp InteractionResponse) result.Void
+	DeferReply(ephemeral bool) result.Void
+	RespondWithModal(modal ModalOptions) result.Void
+	EditOriginal(opts EditMessageOptions) result.Result[Message]
+	FollowUp(data InteractionResponseData) result.Result[Message]
+	DeleteOriginal() result.Void
 }
 
 var (
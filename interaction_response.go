@@ -0,0 +1,566 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
+
+// ErrInteractionAlreadyAcknowledged is returned when an initial response is sent
+// to an interaction that was already responded to or deferred.
+var ErrInteractionAlreadyAcknowledged = errors.New("interaction already acknowledged")
+
+// ErrInteractionNotAcknowledged is returned when a follow-up or an edit of the original
+// response is attempted before the interaction was responded to or deferred.
+var ErrInteractionNotAcknowledged = errors.New("interaction not acknowledged")
+
+// InteractionResponseType is the type of the response sent to an interaction.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object-interaction-callback-type
+type InteractionResponseType int
+
+const (
+	// InteractionResponseTypePong acknowledges a Ping.
+	InteractionResponseTypePong InteractionResponseType = 1
+
+	// InteractionResponseTypeChannelMessageWithSource responds to an interaction with a message.
+	InteractionResponseTypeChannelMessageWithSource InteractionResponseType = 4
+
+	// InteractionResponseTypeDeferredChannelMessageWithSource acknowledges an interaction
+	// and edits a response later, the user sees a loading state.
+	InteractionResponseTypeDeferredChannelMessageWithSource InteractionResponseType = 5
+
+	// InteractionResponseTypeDeferredUpdateMessage acknowledges a component interaction
+	// and edits the original message later, the user doesn't see a loading state.
+	InteractionResponseTypeDeferredUpdateMessage InteractionResponseType = 6
+
+	// InteractionResponseTypeUpdateMessage edits the message the component was attached to.
+	InteractionResponseTypeUpdateMessage InteractionResponseType = 7
+
+	// InteractionResponseTypeApplicationCommandAutocompleteResult responds to an autocomplete interaction with suggested choices.
+	InteractionResponseTypeApplicationCommandAutocompleteResult InteractionResponseType = 8
+
+	// InteractionResponseTypeModal responds to an interaction with a popup modal.
+	InteractionResponseTypeModal InteractionResponseType = 9
+
+	// InteractionResponseTypeLaunchActivity launches the Activity associated with the app.
+	InteractionResponseTypeLaunchActivity InteractionResponseType = 12
+)
+
+// InteractionResponseData is the message sent in response to an interaction, or as a follow-up message.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object-messages
+type InteractionResponseData struct {
+	// TTS indicates whether the message is text to speech.
+	TTS bool `json:"tts,omitempty"`
+
+	// Content is the message text (max 2000 characters).
+	Content string `json:"content,omitempty"`
+
+	// Embeds is the list of rich embeds to include (max 10).
+	Embeds []Embed `json:"embeds,omitempty"`
+
+	// AllowedMentions controls which mentions will notify their targets.
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// Flags is the message flags to set.
+	//
+	// Note:
+	//   - Only MessageFlagSuppressEmbeds, MessageFlagEphemeral, MessageFlagSuppressNotifications
+	//     and MessageFlagIsComponentsV2 can be set.
+	Flags MessageFlags `json:"flags,omitempty"`
+
+	// Components is the list of components to include with the message.
+	Components []LayoutComponent `json:"components,omitempty"`
+
+	// Choices is the list of suggestions of an autocomplete response (max 25).
+	Choices []AutocompleteChoice `json:"choices,omitzero"`
+
+	// CustomID is the developer-defined identifier of a modal response (max 100 characters).
+	CustomID string `json:"custom_id,omitempty"`
+
+	// Title is the title of a modal response (max 45 characters).
+	Title string `json:"title,omitempty"`
+
+	// Files is the list of files to upload as attachments.
+	Files []File `json:"-"`
+}
+
+// AutocompleteChoice is a value suggested to the user while filling an autocomplete option.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object-autocomplete
+type AutocompleteChoice struct {
+	// Name is the name shown to the user (1-100 characters).
+	Name string `json:"name"`
+
+	// NameLocalizations is a localization dictionary for the name field.
+	NameLocalizations map[Locale]string `json:"name_localizations,omitempty"`
+
+	// Value is the value of the choice, a string, an integer or a float matching the type of the option.
+	Value any `json:"value"`
+}
+
+// ModalOptions contains the parameters of a modal sent in response to an interaction.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object-modal
+type ModalOptions struct {
+	// CustomID is a developer-defined identifier of the modal, returned in the ModalSubmitInteraction (max 100 characters).
+	CustomID string
+
+	// Title is the title of the popup modal (max 45 characters).
+	Title string
+
+	// Components is the list of components of the modal (1-5), LabelComponent holding a TextInputComponent
+	// or a StringSelectMenuComponent, or TextDisplayComponent.
+	Components []LayoutComponent
+}
+
+// AddTextInput adds a text input with the given label to the modal and returns the modal for chaining.
+//
+// Usage example:
+//
+//	modal := dwaz.ModalOptions{CustomID: "feedback", Title: "Feedback"}
+//	modal.AddTextInput("Your feedback", dwaz.NewTextInputBuilder().
+//		SetCustomID("text").
+//		SetStyle(dwaz.TextInputStyleParagraph).
+//		Build())
+func (m *ModalOptions) AddTextInput(label string, input *TextInputComponent) *ModalOptions {
+	m.Components = append(m.Components, NewLabelBuilder().SetLabel(label).AddComponent(input).Build())
+	return m
+}
+
+// Validate checks the modal against Discord's limits before it's sent.
+func (m *ModalOptions) Validate() error {
+	if m.CustomID == "" || utf8.RuneCountInString(m.CustomID) > 100 {
+		return errors.New("modal custom id must be 1-100 characters")
+	}
+	if m.Title == "" || utf8.RuneCountInString(m.Title) > 45 {
+		return errors.New("modal title must be 1-45 characters")
+	}
+	if len(m.Components) == 0 || len(m.Components) > 5 {
+		return fmt.Errorf("modal must have 1-5 components, got %d", len(m.Components))
+	}
+	return nil
+}
+
+// InteractionResponse is the initial response sent to an interaction.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-response-object
+type InteractionResponse struct {
+	// Type is the type of the response.
+	Type InteractionResponseType `json:"type"`
+
+	// Data is the message of the response.
+	//
+	// Optional:
+	//   - May be nil for Pong and deferred responses.
+	Data *InteractionResponseData `json:"data,omitempty"`
+}
+
+// CreateInteractionResponse sends the initial response to an interaction.
+//
+// Note:
+//   - An interaction can only be responded to once, within 3 seconds of receiving it.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#create-interaction-response
+func (r *requester) CreateInteractionResponse(interactionID Snowflake, token string, resp InteractionResponse) result.Void {
+	type responseData struct {
+		InteractionResponseData
+		Attachments []attachmentPayload `json:"attachments,omitempty"`
+	}
+	reqPayload := struct {
+		Type InteractionResponseType `json:"type"`
+		Data *responseData           `json:"data,omitempty"`
+	}{Type: resp.Type}
+
+	var files []File
+	if resp.Data != nil {
+		files = resp.Data.Files
+		reqPayload.Data = &responseData{*resp.Data, newAttachmentPayloads(files)}
+	}
+
+	payload, err := json.Marshal(reqPayload)
+	if err != nil {
+		return result.ErrVoid(err)
+	}
+	reqBody, contentType, err := encodeMessageBody(payload, files)
+	if err != nil {
+		return result.ErrVoid(err)
+	}
+
+	res := r.DoRequest(Request{
+		Method:      "POST",
+		URL:         "/interactions/" + interactionID.String() + "/" + token + "/callback",
+		Body:        reqBody,
+		ContentType: contentType,
+		NoAuth:      true,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// EditOriginalInteractionResponse edits the initial response to an interaction.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#edit-original-interaction-response
+func (r *requester) EditOriginalInteractionResponse(applicationID Snowflake, token string, opts EditMessageOptions) result.Result[Message] {
+	payload, err := MarshalPartial(struct {
+		EditMessageOptions
+		Attachments []attachmentPayload `json:"attachments,omitzero"`
+	}{opts, opts.attachmentPayloads()})
+	if err != nil {
+		return result.Err[Message](err)
+	}
+	reqBody, contentType, err := encodeMessageBody(payload, opts.Files)
+	if err != nil {
+		return result.Err[Message](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method:      "PATCH",
+		URL:         webhookEndpoint(applicationID, token, "/messages/@original", nil),
+		Body:        reqBody,
+		ContentType: contentType,
+		NoAuth:      true,
+	})
+	if res.IsErr() {
+		return result.Err[Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var message Message
+	if err := json.NewDecoder(body).Decode(&message); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/webhooks/{id}/{token}/messages/@original",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Message](err)
+	}
+	return result.Ok(message)
+}
+
+// CreateFollowupMessage sends a follow-up message for an interaction.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#create-followup-message
+func (r *requester) CreateFollowupMessage(applicationID Snowflake, token string, data InteractionResponseData) result.Result[Message] {
+	payload, err := json.Marshal(struct {
+		InteractionResponseData
+		Attachments []attachmentPayload `json:"attachments,omitempty"`
+	}{data, newAttachmentPayloads(data.Files)})
+	if err != nil {
+		return result.Err[Message](err)
+	}
+	reqBody, contentType, err := encodeMessageBody(payload, data.Files)
+	if err != nil {
+		return result.Err[Message](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method:      "POST",
+		URL:         webhookEndpoint(applicationID, token, "", nil),
+		Body:        reqBody,
+		ContentType: contentType,
+		NoAuth:      true,
+	})
+	if res.IsErr() {
+		return result.Err[Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var message Message
+	if err := json.NewDecoder(body).Decode(&message); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/webhooks/{id}/{token}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Message](err)
+	}
+	return result.Ok(message)
+}
+
+// DeleteOriginalInteractionResponse deletes the initial response to an interaction.
+//
+// Reference: https://discord.com/developers/docs/interactions/receiving-and-responding#delete-original-interaction-response
+func (r *requester) DeleteOriginalInteractionResponse(applicationID Snowflake, token string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    webhookEndpoint(applicationID, token, "/messages/@original", nil),
+		NoAuth: true,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+/*****************************
+ *   Responding to interactions
+ *****************************/
+
+// interactionState tracks whether the initial response of an interaction was sent.
+type interactionState struct {
+	client *Client
+	acked  atomic.Bool
+
+	mu         sync.Mutex  // guards deferTimer and deferFired
+	deferTimer *time.Timer // automatic deferral, nil if none or once the interaction is acknowledged
+	deferFired bool        // whether deferTimer already ran
+}
+
+// bind attaches the client the interaction was received by, so the interaction can respond to itself.
+//
+// If the client has an auto-defer delay, the interaction is deferred once the delay elapses without a response.
+func (i *InteractionFields) bind(client *Client) {
+	i.state = &interactionState{client: client}
+	if client.autoDeferAfter > 0 {
+		i.scheduleAutoDefer(client.autoDeferAfter)
+	}
+}
+
+// scheduleAutoDefer sends a deferred response if the interaction isn't acknowledged after the given delay.
+//
+// Ping and autocomplete interactions can't be deferred and are left untouched.
+func (i *InteractionFields) scheduleAutoDefer(after time.Duration) {
+	var resp InteractionResponse
+	switch i.Type {
+	case InteractionTypeApplicationCommand, InteractionTypeModalSubmit:
+		resp.Type = InteractionResponseTypeDeferredChannelMessageWithSource
+	case InteractionTypeComponent:
+		resp.Type = InteractionResponseTypeDeferredUpdateMessage
+	default:
+		return
+	}
+
+	state := i.state
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.deferTimer = time.AfterFunc(after, func() {
+		state.mu.Lock()
+		state.deferFired = true
+		state.mu.Unlock()
+
+		err := i.respond(resp, false)
+		if err != nil && !errors.Is(err, ErrInteractionAlreadyAcknowledged) {
+			state.client.Logger.WithFields(map[string]any{
+				"interaction_id": i.ID,
+				"error":          err.Error(),
+			}).Warn("failed auto-deferring interaction")
+		}
+	})
+}
+
+// stopAutoDefer cancels the pending automatic deferral, if any.
+func (s *interactionState) stopAutoDefer() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deferTimer != nil {
+		s.deferTimer.Stop()
+		s.deferTimer = nil
+	}
+}
+
+// rearmAutoDefer runs the automatic deferral again if it already ran while a failed response
+// was in flight, in which case it gave up because the interaction looked acknowledged.
+func (s *interactionState) rearmAutoDefer() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deferTimer != nil && s.deferFired {
+		s.deferFired = false
+		s.deferTimer.Reset(0)
+	}
+}
+
+// responder returns the state of the interaction, or an error if it isn't bound to a client.
+func (i *InteractionFields) responder() (*interactionState, error) {
+	if i.state == nil || i.state.client == nil {
+		return nil, errors.New("interaction is not bound to a client")
+	}
+	return i.state, nil
+}
+
+// Acknowledged reports whether the initial response of the interaction was sent.
+func (i *InteractionFields) Acknowledged() bool {
+	return i.state != nil && i.state.acked.Load()
+}
+
+// Respond sends the initial response to the interaction.
+//
+// Returns ErrInteractionAlreadyAcknowledged if the interaction was already responded to or deferred.
+//
+// Usage example:
+//
+//	interaction.Respond(dwaz.InteractionResponse{
+//		Type: dwaz.InteractionResponseTypeChannelMessageWithSource,
+//		Data: &dwaz.InteractionResponseData{Content: "Pong!"},
+//	})
+func (i *InteractionFields) Respond(resp InteractionResponse) result.Void {
+	if err := i.respond(resp, true); err != nil {
+		return result.ErrVoid(err)
+	}
+	return result.OkVoid()
+}
+
+// respond sends the initial response to the interaction.
+//
+// The automatic deferral is only canceled once the response went through. If it failed and
+// rearm is true, the deferral is sent right away when it's already due.
+func (i *InteractionFields) respond(resp InteractionResponse, rearm bool) error {
+	state, err := i.responder()
+	if err != nil {
+		return err
+	}
+	if resp.Type == InteractionResponseTypeModal && (i.Type == InteractionTypeModalSubmit || i.Type == InteractionTypeAutocomplete || i.Type == InteractionTypePing) {
+		return errors.New("Respond: a modal can't be the response to a modal submit, autocomplete or ping interaction")
+	}
+	if !state.acked.CompareAndSwap(false, true) {
+		return ErrInteractionAlreadyAcknowledged
+	}
+
+	res := state.client.CreateInteractionResponse(i.ID, i.Token, resp)
+	if res.IsErr() {
+		// The response didn't go through, allow the caller to try again.
+		state.acked.Store(false)
+		if rearm {
+			state.rearmAutoDefer()
+		}
+		return res.Err()
+	}
+	state.stopAutoDefer()
+	return nil
+}
+
+// DeferReply acknowledges the interaction, showing a loading state until the response is edited with EditOriginal.
+//
+// Returns ErrInteractionAlreadyAcknowledged if the interaction was already responded to or deferred.
+func (i *InteractionFields) DeferReply(ephemeral bool) result.Void {
+	resp := InteractionResponse{Type: InteractionResponseTypeDeferredChannelMessageWithSource}
+	if ephemeral {
+		resp.Data = &InteractionResponseData{Flags: MessageFlagEphemeral}
+	}
+	return i.Respond(resp)
+}
+
+// RespondWithModal responds to the interaction with a popup modal.
+//
+// Returns an error if the modal is invalid or the interaction is a modal submit, and
+// ErrInteractionAlreadyAcknowledged if the interaction was already responded to or deferred.
+func (i *InteractionFields) RespondWithModal(modal ModalOptions) result.Void {
+	if err := modal.Validate(); err != nil {
+		return result.ErrVoid(fmt.Errorf("RespondWithModal: %w", err))
+	}
+	return i.Respond(InteractionResponse{
+		Type: InteractionResponseTypeModal,
+		Data: &InteractionResponseData{
+			CustomID:   modal.CustomID,
+			Title:      modal.Title,
+			Components: modal.Components,
+		},
+	})
+}
+
+// EditOriginal edits the initial response of the interaction.
+//
+// Returns ErrInteractionNotAcknowledged if the interaction wasn't responded to or deferred yet.
+func (i *InteractionFields) EditOriginal(opts EditMessageOptions) result.Result[Message] {
+	state, err := i.responder()
+	if err != nil {
+		return result.Err[Message](err)
+	}
+	if !state.acked.Load() {
+		return result.Err[Message](ErrInteractionNotAcknowledged)
+	}
+	return state.client.EditOriginalInteractionResponse(i.ApplicationID, i.Token, opts)
+}
+
+// FollowUp sends a follow-up message for the interaction.
+//
+// Returns ErrInteractionNotAcknowledged if the interaction wasn't responded to or deferred yet.
+func (i *InteractionFields) FollowUp(data InteractionResponseData) result.Result[Message] {
+	state, err := i.responder()
+	if err != nil {
+		return result.Err[Message](err)
+	}
+	if !state.acked.Load() {
+		return result.Err[Message](ErrInteractionNotAcknowledged)
+	}
+	return state.client.CreateFollowupMessage(i.ApplicationID, i.Token, data)
+}
+
+// DeleteOriginal deletes the initial response of the interaction.
+//
+// Returns ErrInteractionNotAcknowledged if the interaction wasn't responded to or deferred yet.
+func (i *InteractionFields) DeleteOriginal() result.Void {
+	state, err := i.responder()
+	if err != nil {
+		return result.ErrVoid(err)
+	}
+	if !state.acked.Load() {
+		return result.ErrVoid(ErrInteractionNotAcknowledged)
+	}
+	return state.client.DeleteOriginalInteractionResponse(i.ApplicationID, i.Token)
+}
+
+// DisableAllComponents edits the message the component is attached to, disabling all of its
+// buttons and select menus, typically once the interactions it was waiting for are over.
+//
+// The message is updated as the response to the interaction if it wasn't acknowledged yet,
+// otherwise by editing the original response, which is the message itself after a deferred update.
+// Unlike Client.DisableAllComponents, this also works on ephemeral messages.
+func (i *ComponentInteraction) DisableAllComponents() result.Void {
+	components := DisableComponents(i.Message.Components)
+	if !i.Acknowledged() {
+		res := i.Respond(InteractionResponse{
+			Type: InteractionResponseTypeUpdateMessage,
+			Data: &InteractionResponseData{Components: components},
+		})
+		if !errors.Is(res.Err(), ErrInteractionAlreadyAcknowledged) {
+			return res
+		}
+	}
+	if res := i.EditOriginal(EditMessageOptions{Components: optional.Some(components)}); res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	return result.OkVoid()
+}
+
+// Autocomplete responds to the autocomplete interaction with the suggested choices (max 25).
+//
+// An empty list tells the user there are no matching values.
+//
+// Returns ErrInteractionAlreadyAcknowledged if the interaction was already responded to.
+func (i *AutoCompleteInteraction) Autocomplete(choices []AutocompleteChoice) result.Void {
+	if choices == nil {
+		choices = []AutocompleteChoice{}
+	}
+	return i.Respond(InteractionResponse{
+		Type: InteractionResponseTypeApplicationCommandAutocompleteResult,
+		Data: &InteractionResponseData{Choices: choices},
+	})
+}
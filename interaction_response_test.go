@@ -0,0 +1,451 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+func receiveTestInteraction(t *testing.T, client *Client) Interaction {
+	t.Helper()
+
+	var got Interaction
+	client.OnInteractionCreate(func(evt InteractionCreateEvent) { got = evt.Interaction })
+	client.handlersManagers["INTERACTION_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"1","application_id":"2","token":"tok","type":2,"data":{"type":1,"name":"ping"}}`))
+	if got == nil {
+		t.Fatal("interaction was not dispatched")
+	}
+	return got
+}
+
+func TestInteractionRespondTwice(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		if req.Method != http.MethodPost || req.URL.Path != "/interactions/1/tok/callback" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		if req.Header.Get("Authorization") != "" {
+			t.Error("interaction responses must not be authenticated")
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["type"] != float64(InteractionResponseTypeChannelMessageWithSource) {
+			t.Errorf("type = %v", body["type"])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	interaction := receiveTestInteraction(t, client)
+
+	resp := InteractionResponse{
+		Type: InteractionResponseTypeChannelMessageWithSource,
+		Data: &InteractionResponseData{Content: "Pong!"},
+	}
+	if err := interaction.Respond(resp).Err(); err != nil {
+		t.Fatalf("Respond: %v", err)
+	}
+	if !interaction.Acknowledged() {
+		t.Error("interaction should be acknowledged")
+	}
+	if err := interaction.Respond(resp).Err(); !errors.Is(err, ErrInteractionAlreadyAcknowledged) {
+		t.Errorf("second Respond error = %v, want ErrInteractionAlreadyAcknowledged", err)
+	}
+	if err := interaction.DeferReply(false).Err(); !errors.Is(err, ErrInteractionAlreadyAcknowledged) {
+		t.Errorf("DeferReply after Respond error = %v, want ErrInteractionAlreadyAcknowledged", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
+
+func TestInteractionFollowUpBeforeAck(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /interactions/1/tok/callback":
+			var body map[string]any
+			json.NewDecoder(req.Body).Decode(&body)
+			if body["type"] != float64(InteractionResponseTypeDeferredChannelMessageWithSource) {
+				t.Errorf("type = %v", body["type"])
+			}
+			if data, _ := body["data"].(map[string]any); data["flags"] != float64(MessageFlagEphemeral) {
+				t.Errorf("data = %v", body["data"])
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /webhooks/2/tok":
+			io.WriteString(w, `{"id":"10","channel_id":"3","content":"done"}`)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	interaction := receiveTestInteraction(t, client)
+
+	if res := interaction.FollowUp(InteractionResponseData{Content: "done"}); !errors.Is(res.Err(), ErrInteractionNotAcknowledged) {
+		t.Errorf("FollowUp before ack error = %v, want ErrInteractionNotAcknowledged", res.Err())
+	}
+	if res := interaction.EditOriginal(EditMessageOptions{}); !errors.Is(res.Err(), ErrInteractionNotAcknowledged) {
+		t.Errorf("EditOriginal before ack error = %v, want ErrInteractionNotAcknowledged", res.Err())
+	}
+
+	if err := interaction.DeferReply(true).Err(); err != nil {
+		t.Fatalf("DeferReply: %v", err)
+	}
+	res := interaction.FollowUp(InteractionResponseData{Content: "done"})
+	if res.IsErr() {
+		t.Fatalf("FollowUp: %v", res.Err())
+	}
+	if res.Value().ID != 10 {
+		t.Errorf("message = %+v", res.Value())
+	}
+}
+
+func TestInteractionRespondUnbound(t *testing.T) {
+	var interaction ChatInputCommandInteraction
+	if err := interaction.Respond(InteractionResponse{Type: InteractionResponseTypePong}).Err(); err == nil {
+		t.Error("expected an error responding to an interaction not bound to a client")
+	}
+}
+
+func TestCreateInteractionResponseMessageBody(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/interactions/1/tok/callback" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		want := `{"type":4,"data":{"content":"Pong!","embeds":[{"title":"Latency"}],"flags":64}}`
+		if string(body) != want {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := r.CreateInteractionResponse(1, "tok", InteractionResponse{
+		Type: InteractionResponseTypeChannelMessageWithSource,
+		Data: &InteractionResponseData{
+			Content: "Pong!",
+			Embeds:  []Embed{{Title: "Latency"}},
+			Flags:   MessageFlagEphemeral,
+		},
+	})
+	if res.IsErr() {
+		t.Fatalf("CreateInteractionResponse: %v", res.Err())
+	}
+}
+
+func TestInteractionDeferThenEdit(t *testing.T) {
+	var steps []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		steps = append(steps, req.Method+" "+req.URL.Path)
+		switch req.Method + " " + req.URL.Path {
+		case "POST /interactions/1/tok/callback":
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != `{"type":5}` {
+				t.Errorf("defer body = %s", body)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "PATCH /webhooks/2/tok/messages/@original":
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != `{"content":"Done!"}` {
+				t.Errorf("edit body = %s", body)
+			}
+			io.WriteString(w, `{"id":"10","channel_id":"3","content":"Done!"}`)
+		case "DELETE /webhooks/2/tok/messages/@original":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	interaction := receiveTestInteraction(t, client)
+
+	if err := interaction.DeleteOriginal().Err(); !errors.Is(err, ErrInteractionNotAcknowledged) {
+		t.Errorf("DeleteOriginal before ack error = %v, want ErrInteractionNotAcknowledged", err)
+	}
+	if err := interaction.DeferReply(false).Err(); err != nil {
+		t.Fatalf("DeferReply: %v", err)
+	}
+	res := interaction.EditOriginal(EditMessageOptions{Content: optional.Some("Done!")})
+	if res.IsErr() {
+		t.Fatalf("EditOriginal: %v", res.Err())
+	}
+	if res.Value().Content != "Done!" {
+		t.Errorf("message = %+v", res.Value())
+	}
+	if err := interaction.DeleteOriginal().Err(); err != nil {
+		t.Fatalf("DeleteOriginal: %v", err)
+	}
+	if len(steps) != 3 {
+		t.Errorf("requests = %v", steps)
+	}
+}
+
+func TestAutocompleteResponse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"type":8,"data":{"choices":[]}}` {
+			t.Errorf("body = %s", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	var interaction Interaction
+	client.OnInteractionCreate(func(evt InteractionCreateEvent) { interaction = evt.Interaction })
+	client.handlersManagers["INTERACTION_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"1","application_id":"2","token":"tok","type":4}`))
+
+	autocomplete, ok := interaction.(*AutoCompleteInteraction)
+	if !ok {
+		t.Fatalf("interaction = %#v", interaction)
+	}
+	if err := autocomplete.Autocomplete(nil).Err(); err != nil {
+		t.Fatalf("Autocomplete: %v", err)
+	}
+}
+
+func TestRespondWithModalBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Type InteractionResponseType `json:"type"`
+			Data struct {
+				CustomID   string `json:"custom_id"`
+				Title      string `json:"title"`
+				Components []struct {
+					Type       ComponentType `json:"type"`
+					Label      string        `json:"label"`
+					Components []struct {
+						Type      ComponentType  `json:"type"`
+						CustomID  string         `json:"custom_id"`
+						Style     TextInputStyle `json:"style"`
+						MaxLength int            `json:"max_length"`
+					} `json:"components"`
+				} `json:"components"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.Type != InteractionResponseTypeModal || body.Data.CustomID != "feedback" || body.Data.Title != "Feedback" {
+			t.Errorf("body = %+v", body)
+		}
+		if len(body.Data.Components) != 1 || body.Data.Components[0].Type != ComponentTypeLabel || body.Data.Components[0].Label != "Your feedback" {
+			t.Fatalf("components = %+v", body.Data.Components)
+		}
+		input := body.Data.Components[0].Components[0]
+		if input.Type != ComponentTypeTextInput || input.CustomID != "text" || input.Style != TextInputStyleParagraph || input.MaxLength != 500 {
+			t.Errorf("text input = %+v", input)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	interaction := receiveTestInteraction(t, client)
+
+	modal := ModalOptions{CustomID: "feedback", Title: "Feedback"}
+	modal.AddTextInput("Your feedback", NewTextInputBuilder().
+		SetCustomID("text").
+		SetStyle(TextInputStyleParagraph).
+		SetMaxLength(500).
+		Build())
+	if err := interaction.RespondWithModal(modal).Err(); err != nil {
+		t.Fatalf("RespondWithModal: %v", err)
+	}
+}
+
+func TestRespondWithModalToModalSubmit(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	})
+
+	var interaction Interaction
+	client.OnInteractionCreate(func(evt InteractionCreateEvent) { interaction = evt.Interaction })
+	client.handlersManagers["INTERACTION_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"1","application_id":"2","token":"tok","type":5}`))
+
+	modal := ModalOptions{CustomID: "again", Title: "Again"}
+	modal.AddTextInput("Text", NewTextInputBuilder().SetCustomID("text").SetStyle(TextInputStyleShort).Build())
+	if err := interaction.RespondWithModal(modal).Err(); err == nil {
+		t.Fatal("expected an error responding to a modal submit with a modal")
+	}
+	if interaction.Acknowledged() {
+		t.Error("a rejected modal must not acknowledge the interaction")
+	}
+	if err := interaction.RespondWithModal(ModalOptions{CustomID: "empty", Title: "Empty"}).Err(); err == nil {
+		t.Error("expected an error for a modal without components")
+	}
+}
+
+func TestInteractionAutoDefer(t *testing.T) {
+	var deferrals atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /interactions/1/tok/callback":
+			deferrals.Add(1)
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != `{"type":5}` {
+				t.Errorf("defer body = %s", body)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "PATCH /webhooks/2/tok/messages/@original":
+			io.WriteString(w, `{"id":"10","channel_id":"3","content":"Done!"}`)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	client.autoDeferAfter = 20 * time.Millisecond
+
+	var editErr error
+	client.OnInteractionCreate(func(evt InteractionCreateEvent) {
+		// A slow handler, the interaction must be deferred while it runs.
+		time.Sleep(100 * time.Millisecond)
+		if !evt.Interaction.Acknowledged() {
+			editErr = errors.New("interaction was not auto-deferred")
+			return
+		}
+		editErr = evt.Interaction.EditOriginal(EditMessageOptions{Content: optional.Some("Done!")}).Err()
+	})
+	client.handlersManagers["INTERACTION_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"1","application_id":"2","token":"tok","type":2,"data":{"type":1,"name":"ping"}}`))
+
+	if editErr != nil {
+		t.Fatalf("EditOriginal after auto-defer: %v", editErr)
+	}
+	if n := deferrals.Load(); n != 1 {
+		t.Errorf("sent %d deferrals, want 1", n)
+	}
+}
+
+func TestInteractionAutoDeferSkippedWhenResponded(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"type":4,"data":{"content":"Pong!"}}` {
+			t.Errorf("response body = %s", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client.autoDeferAfter = 20 * time.Millisecond
+
+	interaction := receiveTestInteraction(t, client)
+	if err := interaction.Respond(InteractionResponse{
+		Type: InteractionResponseTypeChannelMessageWithSource,
+		Data: &InteractionResponseData{Content: "Pong!"},
+	}).Err(); err != nil {
+		t.Fatalf("Respond: %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if n := calls.Load(); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
+
+func TestInteractionAutoDeferAfterFailedRespond(t *testing.T) {
+	tests := map[string]time.Duration{
+		"fails before the deferral is due": 0,
+		"fails after the deferral is due":  60 * time.Millisecond,
+	}
+	for name, delay := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deferrals atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+				body, _ := io.ReadAll(req.Body)
+				if string(body) == `{"type":5}` {
+					deferrals.Add(1)
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				time.Sleep(delay)
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"code":50035,"message":"Invalid Form Body"}`)
+			})
+			client.autoDeferAfter = 20 * time.Millisecond
+
+			interaction := receiveTestInteraction(t, client)
+			err := interaction.Respond(InteractionResponse{
+				Type: InteractionResponseTypeChannelMessageWithSource,
+				Data: &InteractionResponseData{Content: "Pong!"},
+			}).Err()
+			if err == nil {
+				t.Fatal("expected Respond to fail")
+			}
+
+			time.Sleep(80 * time.Millisecond)
+			if n := deferrals.Load(); n != 1 {
+				t.Errorf("sent %d deferrals after a failed Respond, want 1", n)
+			}
+			if !interaction.Acknowledged() {
+				t.Error("interaction should be acknowledged by the deferral")
+			}
+		})
+	}
+}
+
+func TestComponentInteractionDisableAllComponents(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, req.Method+" "+req.URL.Path+" "+string(body))
+		switch req.Method {
+		case "POST":
+			w.WriteHeader(http.StatusNoContent)
+		case "PATCH":
+			io.WriteString(w, `{"id":"10","channel_id":"3"}`)
+		}
+	})
+
+	receive := func() *ComponentInteraction {
+		var got Interaction
+		client.OnInteractionCreate(func(evt InteractionCreateEvent) { got = evt.Interaction })
+		client.handlersManagers["INTERACTION_CREATE"].handleEvent(client, false, 0, []byte(`{"id":"1","application_id":"2","token":"tok","type":3,
+			"message":{"id":"10","channel_id":"3","flags":64,"components":[{"type":1,"components":[{"type":2,"style":1,"label":"Yes","custom_id":"yes"}]}]}}`))
+		interaction, ok := got.(*ComponentInteraction)
+		if !ok {
+			t.Fatalf("got %T, want *ComponentInteraction", got)
+		}
+		return interaction
+	}
+
+	// not acknowledged: the message is updated as the interaction response.
+	if err := receive().DisableAllComponents().Err(); err != nil {
+		t.Fatalf("DisableAllComponents: %v", err)
+	}
+	// deferred: the original response, the message itself, is edited.
+	deferred := receive()
+	if err := deferred.Respond(InteractionResponse{Type: InteractionResponseTypeDeferredUpdateMessage}).Err(); err != nil {
+		t.Fatalf("Respond: %v", err)
+	}
+	if err := deferred.DisableAllComponents().Err(); err != nil {
+		t.Fatalf("DisableAllComponents after defer: %v", err)
+	}
+
+	components := `"components":[{"type":1,"components":[{"type":2,"custom_id":"yes","style":1,"label":"Yes","disabled":true}]}]`
+	want := []string{
+		`POST /interactions/1/tok/callback {"type":7,"data":{` + components + `}}`,
+		`POST /interactions/1/tok/callback {"type":6}`,
+		`PATCH /webhooks/2/tok/messages/@original {` + components + `}`,
+	}
+	if !slices.Equal(bodies, want) {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(bodies, "\n"), strings.Join(want, "\n"))
+	}
+}
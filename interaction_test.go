@@ -0,0 +1,77 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import "testing"
+
+func TestUnmarshalInteractionContext(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   string
+		context   InteractionContextType
+		userOwner bool
+		guildID   Snowflake
+	}{
+		{
+			name:    "guild",
+			payload: `{"id":"1","type":2,"data":{"type":1},"context":0,"authorizing_integration_owners":{"0":"50"}}`,
+			context: InteractionContextTypeGuild,
+			guildID: 50,
+		},
+		{
+			name:    "bot dm",
+			payload: `{"id":"1","type":2,"data":{"type":1},"context":1,"authorizing_integration_owners":{"0":"0"}}`,
+			context: InteractionContextTypeBotDM,
+		},
+		{
+			name:      "private channel",
+			payload:   `{"id":"1","type":3,"context":2,"authorizing_integration_owners":{"1":"70"}}`,
+			context:   InteractionContextTypePrivateChannel,
+			userOwner: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, err := UnmarshalInteraction([]byte(tt.payload))
+			if err != nil {
+				t.Fatalf("UnmarshalInteraction: %v", err)
+			}
+			if got := i.GetContext(); got != tt.context {
+				t.Errorf("GetContext() = %d, want %d", got, tt.context)
+			}
+
+			owners := i.GetAuthorizingIntegrationOwners()
+			if tt.userOwner {
+				if owners[ApplicationIntegrationTypeUserInstall] != 70 {
+					t.Errorf("user install owner = %d, want 70", owners[ApplicationIntegrationTypeUserInstall])
+				}
+			} else if id, ok := owners[ApplicationIntegrationTypeGuildInstall]; !ok || id != tt.guildID {
+				t.Errorf("guild install owner = %d, %v, want %d", id, ok, tt.guildID)
+			}
+		})
+	}
+}
+
+func TestInteractionInstallation(t *testing.T) {
+	i := InteractionFields{AuthorizingIntegrationOwners: map[ApplicationIntegrationType]Snowflake{
+		ApplicationIntegrationTypeUserInstall: 70,
+	}}
+	if !i.IsUserInstalled() {
+		t.Error("IsUserInstalled() = false, want true")
+	}
+	if i.IsGuildInstalled() {
+		t.Error("IsGuildInstalled() = true, want false")
+	}
+}
@@ -14,6 +14,7 @@
 package dwaz
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -143,6 +144,18 @@ func (m *Member) CreatedAt() time.Time {
 	return m.ID.Timestamp()
 }
 
+// IsPending returns true if the member has not yet passed the guild's Membership Screening.
+//
+// Pending members cannot send messages or interact with the guild until they pass it.
+func (m *Member) IsPending() bool {
+	return m.Pending
+}
+
+// HasCompletedOnboarding returns true if the member has completed the guild's onboarding.
+func (m *Member) HasCompletedOnboarding() bool {
+	return m.Flags.Has(MemberFlagCompletedOnboarding)
+}
+
 // AvatarURL returns the URL to the members's avatar image.
 //
 // If the member has a custom avatar set, it returns the URL to that avatar, otherwise empty string.
@@ -182,8 +195,8 @@ func (m *Member) AvatarURLWith(format ImageFormat, size ImageSize) string {
 //
 //	url := member.BannerURL()
 func (m *Member) BannerURL() string {
-	if m.Avatar != "" {
-		return GuildMemberBannerURL(m.GuildID, m.ID, m.Avatar, ImageFormatDefault, ImageSizeDefault)
+	if m.Banner != "" {
+		return GuildMemberBannerURL(m.GuildID, m.ID, m.Banner, ImageFormatDefault, ImageSizeDefault)
 	}
 	return ""
 }
@@ -197,8 +210,8 @@ func (m *Member) BannerURL() string {
 //
 //	url := member.BannerURLWith(ImageFormatWebP, ImageSize512)
 func (m *Member) BannerURLWith(format ImageFormat, size ImageSize) string {
-	if m.Avatar != "" {
-		return GuildMemberBannerURL(m.GuildID, m.ID, m.Avatar, format, size)
+	if m.Banner != "" {
+		return GuildMemberBannerURL(m.GuildID, m.ID, m.Banner, format, size)
 	}
 	return ""
 }
@@ -243,6 +256,24 @@ type FullMember struct {
 	User User `json:"user"`
 }
 
+var _ json.Unmarshaler = (*FullMember)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler for FullMember.
+//
+// Discord only sends the member's ID inside the user object, so it's copied to Member.ID.
+func (m *FullMember) UnmarshalJSON(buf []byte) error {
+	type fullMember FullMember
+	var temp fullMember
+	if err := json.Unmarshal(buf, &temp); err != nil {
+		return err
+	}
+	*m = FullMember(temp)
+	if m.ID.UnSet() {
+		m.ID = m.User.ID
+	}
+	return nil
+}
+
 // DisplayName returns the member's nickname if set,
 // otherwise it returns their global display name if set,
 // otherwise it falls back to their username.
@@ -303,8 +334,8 @@ func (m *FullMember) AvatarURLWith(format ImageFormat, size ImageSize) string {
 //
 //	url := member.BannerURL()
 func (m *FullMember) BannerURL() string {
-	if m.Avatar != "" {
-		return GuildMemberBannerURL(m.GuildID, m.ID, m.Avatar, ImageFormatDefault, ImageSizeDefault)
+	if m.Banner != "" {
+		return GuildMemberBannerURL(m.GuildID, m.ID, m.Banner, ImageFormatDefault, ImageSizeDefault)
 	}
 	return m.User.BannerURL()
 }
@@ -319,8 +350,8 @@ func (m *FullMember) BannerURL() string {
 //
 //	url := member.BannerURLWith(ImageFormatWebP, ImageSize512)
 func (m *FullMember) BannerURLWith(format ImageFormat, size ImageSize) string {
-	if m.Avatar != "" {
-		return GuildMemberBannerURL(m.GuildID, m.ID, m.Avatar, format, size)
+	if m.Banner != "" {
+		return GuildMemberBannerURL(m.GuildID, m.ID, m.Banner, format, size)
 	}
 	return m.User.BannerURLWith(format, size)
 }
@@ -337,6 +368,23 @@ type ResolvedMember struct {
 	Permissions Permissions `json:"permissions,omitempty"`
 }
 
+var _ json.Unmarshaler = (*ResolvedMember)(nil)
+
+// UnmarshalJSON implements json.Unmarshaler for ResolvedMember.
+func (m *ResolvedMember) UnmarshalJSON(buf []byte) error {
+	var temp struct {
+		Permissions Permissions `json:"permissions"`
+	}
+	if err := json.Unmarshal(buf, &temp); err != nil {
+		return err
+	}
+	if err := m.FullMember.UnmarshalJSON(buf); err != nil {
+		return err
+	}
+	m.Permissions = temp.Permissions
+	return nil
+}
+
 type PartialMember struct {
 	// ID is the user's unique Discord ID.
 	ID Snowflake `json:"id"`
@@ -418,8 +466,8 @@ func (m *PartialMember) AvatarURLWith(format ImageFormat, size ImageSize) string
 //
 //	url := member.BannerURL()
 func (m *PartialMember) BannerURL() string {
-	if m.Avatar != "" {
-		return GuildMemberBannerURL(m.GuildID, m.ID, m.Avatar, ImageFormatDefault, ImageSizeDefault)
+	if m.Banner != "" {
+		return GuildMemberBannerURL(m.GuildID, m.ID, m.Banner, ImageFormatDefault, ImageSizeDefault)
 	}
 	return ""
 }
@@ -433,8 +481,8 @@ func (m *PartialMember) BannerURL() string {
 //
 //	url := member.BannerURLWith(ImageFormatWebP, ImageSize512)
 func (m *PartialMember) BannerURLWith(format ImageFormat, size ImageSize) string {
-	if m.Avatar != "" {
-		return GuildMemberBannerURL(m.GuildID, m.ID, m.Avatar, format, size)
+	if m.Banner != "" {
+		return GuildMemberBannerURL(m.GuildID, m.ID, m.Banner, format, size)
 	}
 	return ""
 }
@@ -0,0 +1,243 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+func TestMemberFlagsHas(t *testing.T) {
+	flags := MemberFlagDidRejoin | MemberFlagCompletedOnboarding | MemberFlagDMSettingsUpsellAcknowledged
+
+	if !flags.Has(MemberFlagDidRejoin) {
+		t.Errorf("expected flags to have DidRejoin")
+	}
+	if !flags.Has(MemberFlagDidRejoin, MemberFlagCompletedOnboarding) {
+		t.Errorf("expected flags to have DidRejoin and CompletedOnboarding")
+	}
+	if flags.Has(MemberFlagCompletedOnboarding, MemberFlagIsGuest) {
+		t.Errorf("expected flags to not have CompletedOnboarding and IsGuest at the same time")
+	}
+	if flags.Has(MemberFlagQuarantinedUsername) {
+		t.Errorf("expected flags to not have QuarantinedUsername")
+	}
+	if MemberFlagDMSettingsUpsellAcknowledged != 1<<9 || MemberFlagQuarantinedGuildTag != 1<<10 {
+		t.Errorf("unexpected flag values after the reserved bit: %d, %d", MemberFlagDMSettingsUpsellAcknowledged, MemberFlagQuarantinedGuildTag)
+	}
+}
+
+func TestFullMemberOnboardingHelpers(t *testing.T) {
+	member := FullMember{Member: Member{Pending: true, Flags: MemberFlagStartedOnboarding}}
+
+	if !member.IsPending() {
+		t.Errorf("expected member to be pending")
+	}
+	if member.HasCompletedOnboarding() {
+		t.Errorf("expected member to not have completed onboarding")
+	}
+
+	member.Pending = false
+	member.Flags |= MemberFlagCompletedOnboarding
+
+	if member.IsPending() {
+		t.Errorf("expected member to not be pending")
+	}
+	if !member.HasCompletedOnboarding() {
+		t.Errorf("expected member to have completed onboarding")
+	}
+}
+
+func TestFullMemberUnmarshalSetsID(t *testing.T) {
+	var member ResolvedMember
+	data := `{"user": {"id": "42", "username": "bob"}, "nick": "b", "permissions": "8"}`
+	if err := json.Unmarshal([]byte(data), &member); err != nil {
+		t.Fatalf("failed to unmarshal member: %v", err)
+	}
+
+	if member.ID != 42 {
+		t.Errorf("expected member id to be copied from the user, got %d", member.ID)
+	}
+	if member.Nickname != "b" || member.User.Username != "bob" {
+		t.Errorf("unexpected member %+v", member.FullMember)
+	}
+	if member.Permissions != PermissionAdministrator {
+		t.Errorf("expected resolved permissions to be decoded, got %d", member.Permissions)
+	}
+}
+
+func TestGuildMemberEventsUpdateCache(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.handlersManagers["GUILD_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"id":"1","name":"guild","member_count":10}`))
+
+	client.handlersManagers["GUILD_MEMBER_ADD"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","user":{"id":"5","username":"alice"},"nick":"al","roles":[]}`))
+
+	memberOpt := client.GetMember(1, 5)
+	if !memberOpt.IsPresent() || memberOpt.Get().Nickname != "al" || memberOpt.Get().GuildID != 1 {
+		t.Fatalf("member after add = %+v", memberOpt)
+	}
+	if !client.GetUser(5).IsPresent() {
+		t.Error("user was not cached on add")
+	}
+	if count := client.GetGuild(1).Get().MemberCount; count != 11 {
+		t.Errorf("member count after add = %d, want 11", count)
+	}
+
+	var updated GuildMemberUpdateEvent
+	client.OnGuildMemberUpdate(func(evt GuildMemberUpdateEvent) { updated = evt })
+	client.handlersManagers["GUILD_MEMBER_UPDATE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","user":{"id":"5","username":"alice"},"nick":"alice!","roles":[]}`))
+
+	if !updated.OldMember.IsPresent() || updated.OldMember.Get().Nickname != "al" {
+		t.Errorf("old member = %+v", updated.OldMember)
+	}
+	if updated.Member.Nickname != "alice!" {
+		t.Errorf("new member = %+v", updated.Member)
+	}
+	if nick := client.GetMember(1, 5).Get().Nickname; nick != "alice!" {
+		t.Errorf("cached nickname after update = %q", nick)
+	}
+
+	client.handlersManagers["GUILD_MEMBER_REMOVE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","user":{"id":"5","username":"alice"}}`))
+
+	if client.GetMember(1, 5).IsPresent() {
+		t.Error("member is still cached after remove")
+	}
+	if count := client.GetGuild(1).Get().MemberCount; count != 10 {
+		t.Errorf("member count after remove = %d, want 10", count)
+	}
+}
+
+// slowGuildCache is an in memory cache whose guild reads are slow,
+// so concurrent read-modify-write updates of a guild overlap.
+type slowGuildCache struct {
+	*InMemoryCacheManager
+}
+
+func (c slowGuildCache) GetGuild(guildID Snowflake) optional.Option[Guild] {
+	guild := c.InMemoryCacheManager.GetGuild(guildID)
+	time.Sleep(time.Millisecond)
+	return guild
+}
+
+func TestConcurrentGuildMemberEventsKeepCount(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.CacheManager = slowGuildCache{NewInMemoryCacheManager(CacheFlagsAll)}
+	client.PutGuild(Guild{ID: 1, MemberCount: 100})
+
+	const joins, leaves = 30, 10
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range joins + leaves {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			user := `{"id":"` + strconv.Itoa(1000+i) + `","username":"user"}`
+			if i < joins {
+				client.handlersManagers["GUILD_MEMBER_ADD"].handleEvent(client, false, 0,
+					[]byte(`{"guild_id":"1","user":`+user+`,"roles":[]}`))
+			} else {
+				client.handlersManagers["GUILD_MEMBER_REMOVE"].handleEvent(client, false, 0,
+					[]byte(`{"guild_id":"1","user":`+user+`}`))
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if count := client.GetGuild(1).Get().MemberCount; count != 100+joins-leaves {
+		t.Errorf("member count = %d, want %d", count, 100+joins-leaves)
+	}
+}
+
+func TestGuildMemberUpdateUncached(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	var updated GuildMemberUpdateEvent
+	client.OnGuildMemberUpdate(func(evt GuildMemberUpdateEvent) { updated = evt })
+	client.handlersManagers["GUILD_MEMBER_UPDATE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","user":{"id":"5","username":"alice"},"roles":[]}`))
+
+	if updated.OldMember.IsPresent() {
+		t.Errorf("old member of an uncached member = %+v", updated.OldMember)
+	}
+	if !client.GetMember(1, 5).IsPresent() {
+		t.Error("member was not cached on update")
+	}
+}
+
+func TestModifyMemberUpdatesCache(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "PATCH" || req.URL.Path != "/guilds/1/members/2" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		w.Write([]byte(`{"nick": "new", "user": {"id": "2", "username": "bob"}}`))
+	})
+	client.PutMember(Member{ID: 2, GuildID: 1, Nickname: "old"})
+
+	res := client.ModifyMember(1, 2, ModifyMemberOptions{Nickname: optional.Some("new")})
+	if res.IsErr() {
+		t.Fatalf("ModifyMember failed: %v", res.Err())
+	}
+
+	member := client.GetMember(1, 2)
+	if !member.IsPresent() || member.Get().Nickname != "new" {
+		t.Fatalf("cached member = %+v", member)
+	}
+	user := client.GetUser(2)
+	if !user.IsPresent() || user.Get().Username != "bob" {
+		t.Fatalf("cached user = %+v", user)
+	}
+}
+
+func TestFullMemberImageURLs(t *testing.T) {
+	member := FullMember{
+		Member: Member{ID: 2, GuildID: 1, Avatar: "guildavatar", Banner: "guildbanner"},
+		User:   User{ID: 2, Avatar: "useravatar", Banner: "userbanner", Discriminator: "0"},
+	}
+	if got, want := member.AvatarURL(), ImageBaseURL+"guilds/1/users/2/avatars/guildavatar.png"; got != want {
+		t.Errorf("AvatarURL() = %q, want %q", got, want)
+	}
+	if got, want := member.BannerURL(), ImageBaseURL+"guilds/1/users/2/banners/guildbanner.png"; got != want {
+		t.Errorf("BannerURL() = %q, want %q", got, want)
+	}
+
+	// without guild images, the user's global ones are used
+	member.Avatar, member.Banner = "", ""
+	if got, want := member.AvatarURLWith(ImageFormatWebP, ImageSize512), ImageBaseURL+"avatars/2/useravatar.webp?size=512"; got != want {
+		t.Errorf("AvatarURLWith() = %q, want %q", got, want)
+	}
+	if got, want := member.BannerURL(), ImageBaseURL+"banners/2/userbanner.png"; got != want {
+		t.Errorf("BannerURL() = %q, want %q", got, want)
+	}
+
+	// without any custom image, the default avatar is used and there is no banner
+	member.User.Avatar, member.User.Banner = "", ""
+	if got, want := member.AvatarURL(), DefaultUserAvatarURL(member.User.DefaultAvatarIndex()); got != want {
+		t.Errorf("AvatarURL() = %q, want %q", got, want)
+	}
+	if got := member.BannerURL(); got != "" {
+		t.Errorf("BannerURL() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,128 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"slices"
+	"strings"
+)
+
+// Mentions holds the mentions found in message content, see ParseMentions.
+type Mentions struct {
+	// Users are the ids of the mentioned users, in order of first appearance.
+	Users []Snowflake
+	// Roles are the ids of the mentioned roles, in order of first appearance.
+	Roles []Snowflake
+	// Channels are the ids of the mentioned channels, in order of first appearance.
+	Channels []Snowflake
+	// Everyone reports whether the content contains @everyone.
+	Everyone bool
+	// Here reports whether the content contains @here.
+	Here bool
+}
+
+// ParseUserMention parses a user mention, "<@id>" or the legacy nickname form "<@!id>".
+//
+// Returns false if s is not a user mention.
+func ParseUserMention(s string) (Snowflake, bool) {
+	if id, ok := parseMention(s, "@!"); ok {
+		return id, true
+	}
+	return parseMention(s, "@")
+}
+
+// ParseChannelMention parses a channel mention, "<#id>".
+//
+// Returns false if s is not a channel mention.
+func ParseChannelMention(s string) (Snowflake, bool) {
+	return parseMention(s, "#")
+}
+
+// ParseRoleMention parses a role mention, "<@&id>".
+//
+// Returns false if s is not a role mention.
+func ParseRoleMention(s string) (Snowflake, bool) {
+	return parseMention(s, "@&")
+}
+
+// ParseMentions returns all the user, role and channel mentions found in content,
+// and whether it contains @everyone or @here.
+//
+// Each id is listed once, even if mentioned multiple times.
+//
+// Note:
+//   - This only reads the content, Discord may not notify all of them, see AllowedMentions.
+//
+// Example:
+//
+//	mentions := dwaz.ParseMentions("!ban <@!123> <@456>")
+//	// mentions.Users == []dwaz.Snowflake{123, 456}
+func ParseMentions(content string) Mentions {
+	var mentions Mentions
+	mentions.Everyone = strings.Contains(content, "@everyone")
+	mentions.Here = strings.Contains(content, "@here")
+
+	for i := 0; i < len(content); {
+		start := strings.IndexByte(content[i:], '<')
+		if start < 0 {
+			break
+		}
+		start += i
+		end := strings.IndexByte(content[start:], '>')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		token := content[start : end+1]
+		if id, ok := ParseUserMention(token); ok {
+			mentions.Users = appendUnique(mentions.Users, id)
+		} else if id, ok := ParseRoleMention(token); ok {
+			mentions.Roles = appendUnique(mentions.Roles, id)
+		} else if id, ok := ParseChannelMention(token); ok {
+			mentions.Channels = appendUnique(mentions.Channels, id)
+		} else {
+			// Not a mention, another one may start inside it, e.g. "<<@123>".
+			i = start + 1
+			continue
+		}
+		i = end + 1
+	}
+	return mentions
+}
+
+// parseMention parses a mention of the form "<" + prefix + id + ">".
+func parseMention(s, prefix string) (Snowflake, bool) {
+	raw, ok := strings.CutPrefix(s, "<"+prefix)
+	if !ok {
+		return 0, false
+	}
+	raw, ok = strings.CutSuffix(raw, ">")
+	if !ok {
+		return 0, false
+	}
+	id, err := ParseSnowflake(raw)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// appendUnique appends id to ids if it's not already in it.
+func appendUnique(ids []Snowflake, id Snowflake) []Snowflake {
+	if slices.Contains(ids, id) {
+		return ids
+	}
+	return append(ids, id)
+}
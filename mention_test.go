@@ -0,0 +1,71 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSingleMentions(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  func(string) (Snowflake, bool)
+		input  string
+		want   Snowflake
+		wantOk bool
+	}{
+		{"user", ParseUserMention, "<@123>", 123, true},
+		{"nicknamed user", ParseUserMention, "<@!123>", 123, true},
+		{"role as user", ParseUserMention, "<@&123>", 0, false},
+		{"channel", ParseChannelMention, "<#456>", 456, true},
+		{"user as channel", ParseChannelMention, "<@456>", 0, false},
+		{"role", ParseRoleMention, "<@&789>", 789, true},
+		{"user as role", ParseRoleMention, "<@789>", 0, false},
+		{"missing bracket", ParseUserMention, "<@123", 0, false},
+		{"not an id", ParseUserMention, "<@abc>", 0, false},
+		{"zero id", ParseUserMention, "<@0>", 0, false},
+		{"surrounding text", ParseUserMention, "hi <@123>", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.parse(tt.input)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("parse(%q) = %d, %v, want %d, %v", tt.input, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	content := "@here <@!1> and <@2> check <#3>, <@&4> <<@5> <@1> <@&nope> <#3> @everyone <@6"
+	got := ParseMentions(content)
+
+	if want := []Snowflake{1, 2, 5}; !slices.Equal(got.Users, want) {
+		t.Errorf("Users = %v, want %v", got.Users, want)
+	}
+	if want := []Snowflake{4}; !slices.Equal(got.Roles, want) {
+		t.Errorf("Roles = %v, want %v", got.Roles, want)
+	}
+	if want := []Snowflake{3}; !slices.Equal(got.Channels, want) {
+		t.Errorf("Channels = %v, want %v", got.Channels, want)
+	}
+	if !got.Everyone || !got.Here {
+		t.Errorf("Everyone = %v, Here = %v, want both true", got.Everyone, got.Here)
+	}
+
+	if empty := ParseMentions("no mentions here"); empty.Users != nil || empty.Roles != nil || empty.Channels != nil || empty.Everyone || empty.Here {
+		t.Errorf("ParseMentions without mentions = %+v", empty)
+	}
+}
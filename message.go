@@ -15,12 +15,19 @@ package dwaz
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
 )
 
 // Embed field limits as defined by Discord's API.
@@ -231,6 +238,59 @@ type MessageReaction struct {
 	BurstColors []string `json:"burst_colors"`
 }
 
+// sameReactionEmoji reports whether a and b are the same reaction emoji,
+// custom emojis are compared by ID and unicode emojis by name.
+func sameReactionEmoji(a, b Emoji) bool {
+	if a.ID != 0 || b.ID != 0 {
+		return a.ID == b.ID
+	}
+	return a.Name == b.Name
+}
+
+// addReaction counts a new reaction with emoji on the message, me reports whether the current user reacted.
+func (m *Message) addReaction(emoji Emoji, burst, me bool) {
+	i := slices.IndexFunc(m.Reactions, func(r MessageReaction) bool { return sameReactionEmoji(r.Emoji, emoji) })
+	if i == -1 {
+		m.Reactions = append(m.Reactions, MessageReaction{Emoji: emoji})
+		i = len(m.Reactions) - 1
+	}
+	reaction := &m.Reactions[i]
+	reaction.Count++
+	if burst {
+		reaction.CountDetails.Burst++
+		reaction.MeBurst = reaction.MeBurst || me
+	} else {
+		reaction.CountDetails.Normal++
+		reaction.Me = reaction.Me || me
+	}
+}
+
+// removeReaction uncounts a reaction with emoji from the message, dropping the emoji when no reaction is left.
+// me reports whether the reaction of the current user was removed.
+func (m *Message) removeReaction(emoji Emoji, burst, me bool) {
+	i := slices.IndexFunc(m.Reactions, func(r MessageReaction) bool { return sameReactionEmoji(r.Emoji, emoji) })
+	if i == -1 {
+		return
+	}
+	reaction := &m.Reactions[i]
+	reaction.Count--
+	if burst {
+		reaction.CountDetails.Burst = max(reaction.CountDetails.Burst-1, 0)
+		reaction.MeBurst = reaction.MeBurst && !me
+	} else {
+		reaction.CountDetails.Normal = max(reaction.CountDetails.Normal-1, 0)
+		reaction.Me = reaction.Me && !me
+	}
+	if reaction.Count <= 0 {
+		m.Reactions = slices.Delete(m.Reactions, i, i+1)
+	}
+}
+
+// removeReactionEmoji drops all the reactions with emoji from the message.
+func (m *Message) removeReactionEmoji(emoji Emoji) {
+	m.Reactions = slices.DeleteFunc(m.Reactions, func(r MessageReaction) bool { return sameReactionEmoji(r.Emoji, emoji) })
+}
+
 // ReactionCountDetails provides counts for normal and super reactions to a message.
 //
 // Reference: https://discord.com/developers/docs/resources/message#reaction-count-details-object
@@ -414,6 +474,54 @@ type MessageReference struct {
 	FailIfNotExists bool `json:"fail_if_not_exists,omitempty"`
 }
 
+// AllowedMentionType is a type of mention that can be parsed from message content.
+//
+// Reference: https://discord.com/developers/docs/resources/message#allowed-mentions-object-allowed-mention-types
+type AllowedMentionType string
+
+const (
+	// AllowedMentionTypeRoles controls role mentions.
+	AllowedMentionTypeRoles AllowedMentionType = "roles"
+	// AllowedMentionTypeUsers controls user mentions.
+	AllowedMentionTypeUsers AllowedMentionType = "users"
+	// AllowedMentionTypeEveryone controls @everyone and @here mentions.
+	AllowedMentionTypeEveryone AllowedMentionType = "everyone"
+)
+
+// AllowedMentions controls which mentions in a sent message will notify their targets.
+//
+// Note:
+//   - An empty AllowedMentions suppresses all mentions.
+//   - A type must not be listed in Parse if the matching Users or Roles list is set.
+//
+// Reference: https://discord.com/developers/docs/resources/message#allowed-mentions-object
+type AllowedMentions struct {
+	// Parse is the list of mention types to parse from the content.
+	Parse []AllowedMentionType `json:"parse,omitempty"`
+
+	// Roles is the list of role IDs allowed to be mentioned (max 100).
+	Roles []Snowflake `json:"roles,omitempty"`
+
+	// Users is the list of user IDs allowed to be mentioned (max 100).
+	Users []Snowflake `json:"users,omitempty"`
+
+	// RepliedUser is whether to mention the author of the message being replied to.
+	RepliedUser bool `json:"replied_user,omitempty"`
+}
+
+// SuppressAllMentions returns an AllowedMentions that stops every mention in the content,
+// including replied user pings, from notifying anyone.
+//
+// Usage example:
+//
+//	client.SendMessage(channelID, dwaz.CreateMessageOptions{
+//		Content:         userInput,
+//		AllowedMentions: dwaz.SuppressAllMentions(),
+//	})
+func SuppressAllMentions() *AllowedMentions {
+	return &AllowedMentions{}
+}
+
 // PartialMessage represents a lightweight version of a Discord message.
 //
 // Reference: https://discord.com/developers/docs/resources/message#message-reference-structure
@@ -691,6 +799,60 @@ type Embed struct {
 	Fields []EmbedField `json:"fields,omitempty"`
 }
 
+// Validate checks the embed against Discord's embed limits.
+//
+// Lengths are counted in characters, not bytes. The total limit only covers this embed,
+// the 6000 characters are shared by all embeds of a message.
+func (e *Embed) Validate() error {
+	if n := utf8.RuneCountInString(e.Title); n > EmbedTitleMaxLength {
+		return fmt.Errorf("embed title must be max %d characters, got %d", EmbedTitleMaxLength, n)
+	}
+	if n := utf8.RuneCountInString(e.Description); n > EmbedDescriptionMaxLength {
+		return fmt.Errorf("embed description must be max %d characters, got %d", EmbedDescriptionMaxLength, n)
+	}
+	if len(e.Fields) > EmbedFieldsMaxCount {
+		return fmt.Errorf("embed can contain a maximum of %d fields, got %d", EmbedFieldsMaxCount, len(e.Fields))
+	}
+	for i, field := range e.Fields {
+		if n := utf8.RuneCountInString(field.Name); n > EmbedFieldNameMaxLength {
+			return fmt.Errorf("embed field[%d] name must be max %d characters, got %d", i, EmbedFieldNameMaxLength, n)
+		}
+		if n := utf8.RuneCountInString(field.Value); n > EmbedFieldValueMaxLength {
+			return fmt.Errorf("embed field[%d] value must be max %d characters, got %d", i, EmbedFieldValueMaxLength, n)
+		}
+	}
+	if e.Footer != nil {
+		if n := utf8.RuneCountInString(e.Footer.Text); n > EmbedFooterTextMaxLength {
+			return fmt.Errorf("embed footer text must be max %d characters, got %d", EmbedFooterTextMaxLength, n)
+		}
+	}
+	if e.Author != nil {
+		if n := utf8.RuneCountInString(e.Author.Name); n > EmbedAuthorNameMaxLength {
+			return fmt.Errorf("embed author name must be max %d characters, got %d", EmbedAuthorNameMaxLength, n)
+		}
+	}
+	if n := e.Length(); n > EmbedTotalCharacterLimit {
+		return fmt.Errorf("embed must be max %d characters in total, got %d", EmbedTotalCharacterLimit, n)
+	}
+	return nil
+}
+
+// Length returns the number of characters counted toward EmbedTotalCharacterLimit:
+// title, description, field names and values, footer text and author name.
+func (e *Embed) Length() int {
+	n := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	for _, field := range e.Fields {
+		n += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	if e.Footer != nil {
+		n += utf8.RuneCountInString(e.Footer.Text)
+	}
+	if e.Author != nil {
+		n += utf8.RuneCountInString(e.Author.Name)
+	}
+	return n
+}
+
 // Builder returns a new EmbedBuilder initialized with a copy of the current embed.
 func (e *Embed) Builder() EmbedBuilder {
 	return EmbedBuilder{embed: *e}
@@ -1086,18 +1248,12 @@ func NewEmbedBuilder() *EmbedBuilder {
 
 // SetTitle sets the embed title (max 256 chars).
 func (b *EmbedBuilder) SetTitle(title string) *EmbedBuilder {
-	if len(title) > EmbedTitleMaxLength {
-		panic("Embed title must be max " + strconv.Itoa(EmbedTitleMaxLength) + " characters, got " + strconv.Itoa(len(title)))
-	}
 	b.embed.Title = title
 	return b
 }
 
 // SetDescription sets the embed description (max 4096 chars).
 func (b *EmbedBuilder) SetDescription(desc string) *EmbedBuilder {
-	if len(desc) > EmbedDescriptionMaxLength {
-		panic("Embed description must be max " + strconv.Itoa(EmbedDescriptionMaxLength) + " characters, got " + strconv.Itoa(len(desc)))
-	}
 	b.embed.Description = desc
 	return b
 }
@@ -1120,11 +1276,8 @@ func (b *EmbedBuilder) SetColor(color Color) *EmbedBuilder {
 	return b
 }
 
-// SetFooter sets the embed footer text and optional icon URL.
+// SetFooter sets the embed footer text (max 2048 chars) and optional icon URL.
 func (b *EmbedBuilder) SetFooter(text, iconURL string) *EmbedBuilder {
-	if len(text) > EmbedFooterTextMaxLength {
-		panic("Embed footer text must be max " + strconv.Itoa(EmbedFooterTextMaxLength) + " characters, got " + strconv.Itoa(len(text)))
-	}
 	b.embed.Footer = &EmbedFooter{
 		Text:    text,
 		IconURL: iconURL,
@@ -1144,11 +1297,8 @@ func (b *EmbedBuilder) SetThumbnail(url string) *EmbedBuilder {
 	return b
 }
 
-// SetAuthor sets the embed author name and optional URL/icon.
+// SetAuthor sets the embed author name (max 256 chars) and optional URL/icon.
 func (b *EmbedBuilder) SetAuthor(name, url, iconURL string) *EmbedBuilder {
-	if len(name) > EmbedAuthorNameMaxLength {
-		panic("Embed author name must be max " + strconv.Itoa(EmbedAuthorNameMaxLength) + " characters, got " + strconv.Itoa(len(name)))
-	}
 	b.embed.Author = &EmbedAuthor{
 		Name:    name,
 		URL:     url,
@@ -1157,17 +1307,9 @@ func (b *EmbedBuilder) SetAuthor(name, url, iconURL string) *EmbedBuilder {
 	return b
 }
 
-// AddField appends a field to the embed fields slice.
+// AddField appends a field to the embed fields slice (max 25 fields,
+// name max 256 chars, value max 1024 chars).
 func (b *EmbedBuilder) AddField(name, value string, inline bool) *EmbedBuilder {
-	if len(b.embed.Fields) >= EmbedFieldsMaxCount {
-		panic("Embed can contain a maximum of " + strconv.Itoa(EmbedFieldsMaxCount) + " fields")
-	}
-	if len(name) > EmbedFieldNameMaxLength {
-		panic("Embed field name must be max " + strconv.Itoa(EmbedFieldNameMaxLength) + " characters, got " + strconv.Itoa(len(name)))
-	}
-	if len(value) > EmbedFieldValueMaxLength {
-		panic("Embed field value must be max " + strconv.Itoa(EmbedFieldValueMaxLength) + " characters, got " + strconv.Itoa(len(value)))
-	}
 	b.embed.Fields = append(b.embed.Fields, EmbedField{
 		Name:   name,
 		Value:  value,
@@ -1178,9 +1320,6 @@ func (b *EmbedBuilder) AddField(name, value string, inline bool) *EmbedBuilder {
 
 // AddBlankField appends a blank field to the embed fields slice.
 func (b *EmbedBuilder) AddBlankField(inline bool) *EmbedBuilder {
-	if len(b.embed.Fields) >= EmbedFieldsMaxCount {
-		panic("Embed can contain a maximum of " + strconv.Itoa(EmbedFieldsMaxCount) + " fields")
-	}
 	b.embed.Fields = append(b.embed.Fields, EmbedField{
 		Name:   "\u200E",
 		Value:  "\u200E",
@@ -1191,17 +1330,6 @@ func (b *EmbedBuilder) AddBlankField(inline bool) *EmbedBuilder {
 
 // SetFields sets all embed fields at once.
 func (e *EmbedBuilder) SetFields(fields ...EmbedField) *EmbedBuilder {
-	if len(fields) > EmbedFieldsMaxCount {
-		panic("Embed can contain a maximum of " + strconv.Itoa(EmbedFieldsMaxCount) + " fields, got " + strconv.Itoa(len(fields)))
-	}
-	for i, field := range fields {
-		if len(field.Name) > EmbedFieldNameMaxLength {
-			panic("Embed field[" + strconv.Itoa(i) + "] name must be max " + strconv.Itoa(EmbedFieldNameMaxLength) + " characters, got " + strconv.Itoa(len(field.Name)))
-		}
-		if len(field.Value) > EmbedFieldValueMaxLength {
-			panic("Embed field[" + strconv.Itoa(i) + "] value must be max " + strconv.Itoa(EmbedFieldValueMaxLength) + " characters, got " + strconv.Itoa(len(field.Value)))
-		}
-	}
 	e.embed.Fields = fields
 	return e
 }
@@ -1220,6 +1348,1167 @@ func (b *EmbedBuilder) Reset() {
 }
 
 // Build returns the final Embed object ready to send.
-func (b *EmbedBuilder) Build() Embed {
-	return b.embed
+//
+// Returns an error if the embed exceeds one of Discord's limits, see Embed.Validate.
+//
+// Usage example:
+//
+//	embed, err := dwaz.NewEmbedBuilder().
+//		SetTitle("Stats").
+//		AddField("Members", "42", true).
+//		Build()
+func (b *EmbedBuilder) Build() (Embed, error) {
+	if err := b.embed.Validate(); err != nil {
+		return Embed{}, err
+	}
+	return b.embed, nil
+}
+
+// AllowedMentionsBuilder helps build an AllowedMentions with chainable methods.
+//
+// The builder starts with every mention suppressed, only what is allowed notifies its targets.
+type AllowedMentionsBuilder struct {
+	mentions AllowedMentions
+}
+
+// NewAllowedMentionsBuilder creates a new AllowedMentionsBuilder instance.
+func NewAllowedMentionsBuilder() *AllowedMentionsBuilder {
+	return &AllowedMentionsBuilder{}
+}
+
+// AllowEveryone lets @everyone and @here mentions notify.
+func (b *AllowedMentionsBuilder) AllowEveryone() *AllowedMentionsBuilder {
+	return b.parse(AllowedMentionTypeEveryone, true)
+}
+
+// SuppressEveryone stops @everyone and @here mentions from notifying.
+func (b *AllowedMentionsBuilder) SuppressEveryone() *AllowedMentionsBuilder {
+	return b.parse(AllowedMentionTypeEveryone, false)
+}
+
+// AllowAllUsers lets every user mention notify.
+func (b *AllowedMentionsBuilder) AllowAllUsers() *AllowedMentionsBuilder {
+	b.mentions.Users = nil
+	return b.parse(AllowedMentionTypeUsers, true)
+}
+
+// AllowAllRoles lets every role mention notify.
+func (b *AllowedMentionsBuilder) AllowAllRoles() *AllowedMentionsBuilder {
+	b.mentions.Roles = nil
+	return b.parse(AllowedMentionTypeRoles, true)
+}
+
+// AllowUsers lets only the mentions of these users notify (max 100).
+func (b *AllowedMentionsBuilder) AllowUsers(userIDs ...Snowflake) *AllowedMentionsBuilder {
+	b.mentions.Users = append(b.mentions.Users, userIDs...)
+	return b.parse(AllowedMentionTypeUsers, false)
+}
+
+// AllowRoles lets only the mentions of these roles notify (max 100).
+func (b *AllowedMentionsBuilder) AllowRoles(roleIDs ...Snowflake) *AllowedMentionsBuilder {
+	b.mentions.Roles = append(b.mentions.Roles, roleIDs...)
+	return b.parse(AllowedMentionTypeRoles, false)
+}
+
+// SetRepliedUser sets whether the author of the message being replied to is pinged.
+func (b *AllowedMentionsBuilder) SetRepliedUser(ping bool) *AllowedMentionsBuilder {
+	b.mentions.RepliedUser = ping
+	return b
+}
+
+// parse adds or removes a mention type from the parsed types.
+func (b *AllowedMentionsBuilder) parse(mentionType AllowedMentionType, allow bool) *AllowedMentionsBuilder {
+	b.mentions.Parse = slices.DeleteFunc(b.mentions.Parse, func(t AllowedMentionType) bool { return t == mentionType })
+	if allow {
+		b.mentions.Parse = append(b.mentions.Parse, mentionType)
+	}
+	return b
+}
+
+// Reset clears the builder state, allowing it to be reused.
+func (b *AllowedMentionsBuilder) Reset() {
+	b.mentions = AllowedMentions{}
+}
+
+// Build returns the final AllowedMentions.
+func (b *AllowedMentionsBuilder) Build() *AllowedMentions {
+	mentions := b.mentions
+	mentions.Parse = slices.Clone(b.mentions.Parse)
+	mentions.Users = slices.Clone(b.mentions.Users)
+	mentions.Roles = slices.Clone(b.mentions.Roles)
+	return &mentions
+}
+
+/***********************
+ *    Messages REST    *
+ ***********************/
+
+// CreateMessageOptions contains parameters for sending a message.
+//
+// Note:
+//   - At least one of Content, Embeds, Components, StickerIDs or Files is required.
+//
+// Reference: https://discord.com/developers/docs/resources/message#create-message-jsonform-params
+type CreateMessageOptions struct {
+	// Content is the message text (max 2000 characters).
+	Content string `json:"content,omitempty"`
+
+	// Embeds is the list of rich embeds to include (max 10).
+	Embeds []Embed `json:"embeds,omitempty"`
+
+	// Components is the list of components to include with the message.
+	Components []LayoutComponent `json:"components,omitempty"`
+
+	// AllowedMentions controls which mentions will notify their targets.
+	//
+	// Optional:
+	//   - If nil, all mentions in the content are parsed.
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// MessageReference makes the message a reply to, or a forward of, another message.
+	MessageReference *MessageReference `json:"message_reference,omitempty"`
+
+	// StickerIDs is the list of sticker IDs to send with the message (max 3).
+	StickerIDs []Snowflake `json:"sticker_ids,omitempty"`
+
+	// Flags is the message flags to set.
+	//
+	// Note:
+	//   - Only MessageFlagSuppressEmbeds, MessageFlagSuppressNotifications,
+	//     MessageFlagIsVoiceMessage and MessageFlagIsComponentsV2 can be set.
+	Flags MessageFlags `json:"flags,omitempty"`
+
+	// Files is the list of files to upload as attachments.
+	//
+	// Note:
+	//   - If set, the request is sent as multipart/form-data.
+	Files []File `json:"-"`
+}
+
+// EditMessageOptions contains parameters for editing a message.
+//
+// All fields are optional, only the fields that are set will be updated.
+//
+// Reference: https://discord.com/developers/docs/resources/message#edit-message-jsonform-params
+type EditMessageOptions struct {
+	// Content is the new message text (max 2000 characters).
+	Content optional.Option[string] `json:"content,omitzero"`
+
+	// Embeds replaces the embeds of the message (max 10).
+	Embeds optional.Option[[]Embed] `json:"embeds,omitzero"`
+
+	// Components replaces the components of the message.
+	Components optional.Option[[]LayoutComponent] `json:"components,omitzero"`
+
+	// Flags is the new message flags.
+	//
+	// Note:
+	//   - Only MessageFlagSuppressEmbeds can be toggled.
+	Flags optional.Option[MessageFlags] `json:"flags,omitzero"`
+
+	// AllowedMentions controls which mentions in the new content will notify their targets.
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// Files is the list of files to upload as attachments, see AddAttachment.
+	//
+	// Note:
+	//   - Existing attachments of the message are removed when files are uploaded,
+	//     unless they are listed in KeptAttachments.
+	Files []File `json:"-"`
+
+	// KeptAttachments is the IDs of the existing attachments to keep, see KeepAttachments.
+	//
+	// Discord's rules for the attachments of an edited message are:
+	//   - KeptAttachments nil and no Files: the attachments are left untouched, e.g. on a content-only edit.
+	//   - Otherwise: the message ends up with exactly KeptAttachments followed by Files,
+	//     every other attachment is deleted. A non-nil empty KeptAttachments without Files removes them all.
+	KeptAttachments []Snowflake `json:"-"`
+}
+
+// KeepAttachments marks existing attachments of the message as kept and returns the options for chaining.
+//
+// Usage example:
+//
+//	opts := dwaz.EditMessageOptions{Content: optional.Some("Updated report")}
+//	opts.KeepAttachments(message.Attachments).AddAttachment(dwaz.File{Name: "v2.txt", Reader: f})
+func (o *EditMessageOptions) KeepAttachments(existing []Attachment) *EditMessageOptions {
+	if o.KeptAttachments == nil {
+		o.KeptAttachments = make([]Snowflake, 0, len(existing))
+	}
+	for _, attachment := range existing {
+		o.KeptAttachments = append(o.KeptAttachments, attachment.ID)
+	}
+	return o
+}
+
+// AddAttachment adds a file to upload and returns the options for chaining.
+//
+// Note:
+//   - Uploading a file removes the attachments not marked with KeepAttachments.
+func (o *EditMessageOptions) AddAttachment(file File) *EditMessageOptions {
+	o.Files = append(o.Files, file)
+	return o
+}
+
+// attachmentPayloads returns the attachments array of the edit, kept attachments first,
+// or nil when the attachments of the message are left untouched.
+func (o *EditMessageOptions) attachmentPayloads() []attachmentPayload {
+	if o.KeptAttachments == nil && len(o.Files) == 0 {
+		return nil
+	}
+	attachments := make([]attachmentPayload, 0, len(o.KeptAttachments)+len(o.Files))
+	for _, id := range o.KeptAttachments {
+		attachments = append(attachments, attachmentPayload{ID: id})
+	}
+	return append(attachments, newAttachmentPayloads(o.Files)...)
+}
+
+// encodeMessageBody encodes a message payload, switching to multipart/form-data when files are attached.
+//
+// Returns the body and the Content-Type to send it with, empty for JSON.
+func encodeMessageBody(payload []byte, files []File) ([]byte, string, error) {
+	if len(files) == 0 {
+		return payload, "", nil
+	}
+	return encodeMultipart(payload, files)
+}
+
+// SendMessage sends a message to a channel.
+//
+// Requires the PermissionSendMessages permission, or PermissionSendMessagesInThreads in threads.
+// Replies also require PermissionReadMessageHistory, and uploads require PermissionAttachFiles.
+//
+// Usage example:
+//
+//	res := client.SendMessage(channelID, dwaz.CreateMessageOptions{
+//		Content: "Hello!",
+//		Files:   []dwaz.File{{Name: "report.txt", Reader: f}},
+//	})
+//
+// Reference: https://discord.com/developers/docs/resources/message#create-message
+func (r *requester) SendMessage(channelID Snowflake, opts CreateMessageOptions) result.Result[Message] {
+	return r.SendMessageCtx(context.Background(), channelID, opts)
+}
+
+// SendMessageCtx is like SendMessage but aborts when ctx is canceled, returning ctx.Err().
+//
+// Reference: https://discord.com/developers/docs/resources/message#create-message
+func (r *requester) SendMessageCtx(ctx context.Context, channelID Snowflake, opts CreateMessageOptions) result.Result[Message] {
+	payload, err := json.Marshal(struct {
+		CreateMessageOptions
+		Attachments []attachmentPayload `json:"attachments,omitempty"`
+	}{opts, newAttachmentPayloads(opts.Files)})
+	if err != nil {
+		return result.Err[Message](err)
+	}
+	reqBody, contentType, err := encodeMessageBody(payload, opts.Files)
+	if err != nil {
+		return result.Err[Message](err)
+	}
+
+	res := r.DoRequestCtx(ctx, Request{
+		Method:      "POST",
+		URL:         "/channels/" + channelID.String() + "/messages",
+		Body:        reqBody,
+		ContentType: contentType,
+	})
+	if res.IsErr() {
+		return result.Err[Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var message Message
+	if err := json.NewDecoder(body).Decode(&message); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/channels/{id}/messages",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Message](err)
+	}
+	return result.Ok(message)
+}
+
+// TryDM opens a DM channel with a user and sends a message to it.
+//
+// Returns Ok(None) when Discord refuses the DM with RestErrorCodeCannotSendMessagesToUser,
+// so callers can fall back to something else, like mentioning the user in a guild channel.
+// Any other failure is returned as an error.
+//
+// Usage example:
+//
+//	res := client.TryDM(userID, dwaz.CreateMessageOptions{Content: "You have been verified!"})
+//	if res.IsOk() && !res.Value().IsPresent() {
+//		client.SendMessage(channelID, dwaz.CreateMessageOptions{Content: "<@" + userID.String() + "> please enable your DMs."})
+//	}
+func (c *Client) TryDM(userID Snowflake, opts CreateMessageOptions) result.Result[optional.Option[Message]] {
+	dm := c.CreateDM(userID)
+	if dm.IsErr() {
+		return tryDMResult(dm.Err())
+	}
+	res := c.SendMessage(dm.Value().ID, opts)
+	if res.IsErr() {
+		return tryDMResult(res.Err())
+	}
+	return result.Ok(optional.Some(res.Value()))
+}
+
+// tryDMResult maps a DM refused by Discord to Ok(None) and any other error to Err.
+func tryDMResult(err error) result.Result[optional.Option[Message]] {
+	var restErr *RestError
+	if errors.As(err, &restErr) && restErr.Code == RestErrorCodeCannotSendMessagesToUser {
+		return result.Ok(optional.None[Message]())
+	}
+	return result.Err[optional.Option[Message]](err)
+}
+
+// MessageContentMaxLength is the maximum number of characters in the content of a message.
+const MessageContentMaxLength = 2000
+
+// SendLongMessage sends content as several messages of at most MessageContentMaxLength characters,
+// in order, and returns the created messages.
+//
+// The content is split with SplitMessageContent. The other fields of opts are applied as follows:
+//   - MessageReference is only set on the first message, so only it replies.
+//   - Embeds, Components, StickerIDs and Files are only sent with the last message.
+//   - AllowedMentions and Flags are sent with every message.
+//
+// Note:
+//   - Messages go through the rate limiter like any request, long content in a busy channel
+//     may take a while to be fully sent.
+//   - If a message fails, the error is returned and the messages already sent are kept.
+func (c *Client) SendLongMessage(channelID Snowflake, content string, opts CreateMessageOptions) result.Result[[]Message] {
+	chunks := SplitMessageContent(content, MessageContentMaxLength)
+	if len(chunks) == 0 {
+		chunks = []string{""}
+	}
+
+	messages := make([]Message, 0, len(chunks))
+	for i, chunk := range chunks {
+		chunkOpts := CreateMessageOptions{
+			Content:         chunk,
+			AllowedMentions: opts.AllowedMentions,
+			Flags:           opts.Flags,
+		}
+		if i == 0 {
+			chunkOpts.MessageReference = opts.MessageReference
+		}
+		if i == len(chunks)-1 {
+			chunkOpts.Embeds = opts.Embeds
+			chunkOpts.Components = opts.Components
+			chunkOpts.StickerIDs = opts.StickerIDs
+			chunkOpts.Files = opts.Files
+		}
+
+		res := c.SendMessage(channelID, chunkOpts)
+		if res.IsErr() {
+			return result.Err[[]Message](fmt.Errorf("SendLongMessage: sending part %d of %d: %w", i+1, len(chunks), res.Err()))
+		}
+		messages = append(messages, res.Value())
+	}
+	return result.Ok(messages)
+}
+
+// SplitMessageContent splits content into chunks of at most limit characters,
+// MessageContentMaxLength if limit isn't positive.
+//
+// Content is split between lines when possible, then between words, and only cuts words longer
+// than a whole chunk. A code block spanning several chunks is closed at the end of a chunk and
+// reopened, with the same language, at the start of the next one.
+//
+// Usage example:
+//
+//	for _, chunk := range dwaz.SplitMessageContent(logs, dwaz.MessageContentMaxLength) {
+//		client.SendMessage(channelID, dwaz.CreateMessageOptions{Content: chunk})
+//	}
+func SplitMessageContent(content string, limit int) []string {
+	if limit <= 0 {
+		limit = MessageContentMaxLength
+	}
+	s := contentSplitter{limit: limit}
+	for line := range strings.SplitAfterSeq(content, "\n") {
+		s.add(line)
+	}
+	s.flush()
+	return s.chunks
+}
+
+// codeFence is the marker opening and closing a code block.
+const codeFence = "```"
+
+// contentSplitter accumulates lines into chunks for SplitMessageContent.
+type contentSplitter struct {
+	limit  int
+	chunks []string
+
+	buf        strings.Builder
+	size       int    // characters in buf
+	hasContent bool   // whether buf holds more than a reopened code fence
+	fence      string // line that opened the code block buf ends in, empty if none
+}
+
+// add appends a line, including its line break, flushing chunks as needed.
+func (s *contentSplitter) add(line string) {
+	fenceAfter := s.fence
+	if strings.HasPrefix(strings.TrimSpace(line), codeFence) {
+		if s.fence == "" {
+			fenceAfter = strings.TrimSpace(line)
+		} else {
+			fenceAfter = ""
+		}
+	}
+
+	if s.fits(line, fenceAfter) {
+		s.write(line, fenceAfter)
+		return
+	}
+	if s.hasContent {
+		s.flush()
+		if s.fits(line, fenceAfter) {
+			s.write(line, fenceAfter)
+			return
+		}
+	}
+
+	// the line doesn't fit in a whole chunk, cut it between words.
+	runes := []rune(line)
+	for len(runes) > 0 {
+		room := s.limit - s.size - s.closingSize(fenceAfter)
+		if room <= 0 {
+			s.flush()
+			continue
+		}
+		if len(runes) <= room {
+			s.write(string(runes), fenceAfter)
+			return
+		}
+		cut := room
+		if i := lastRuneIndex(runes[:room], ' '); i > 0 {
+			cut = i + 1
+		}
+		s.write(string(runes[:cut]), fenceAfter)
+		runes = runes[cut:]
+		s.flush()
+	}
+}
+
+// fits reports whether line fits in the current chunk, keeping room to close the code block.
+func (s *contentSplitter) fits(line, fenceAfter string) bool {
+	return s.size+utf8.RuneCountInString(line)+s.closingSize(fenceAfter) <= s.limit
+}
+
+// closingSize returns the room needed to close the code block opened by fence.
+func (s *contentSplitter) closingSize(fence string) int {
+	if fence == "" {
+		return 0
+	}
+	return len("\n" + codeFence)
+}
+
+func (s *contentSplitter) write(text, fenceAfter string) {
+	s.buf.WriteString(text)
+	s.size += utf8.RuneCountInString(text)
+	s.hasContent = true
+	s.fence = fenceAfter
+}
+
+// flush ends the current chunk, closing its code block and reopening it in the next chunk.
+func (s *contentSplitter) flush() {
+	if !s.hasContent {
+		return
+	}
+	chunk := strings.TrimRight(s.buf.String(), " \n")
+	if s.fence != "" {
+		chunk += "\n" + codeFence
+	}
+	if chunk != "" {
+		s.chunks = append(s.chunks, chunk)
+	}
+
+	s.buf.Reset()
+	s.size = 0
+	s.hasContent = false
+	// a code block language too long to reopen is dropped rather than leaving no room for content.
+	if s.fence != "" && utf8.RuneCountInString(s.fence)+1+s.closingSize(s.fence) < s.limit/2 {
+		s.buf.WriteString(s.fence + "\n")
+		s.size = utf8.RuneCountInString(s.fence) + 1
+	} else {
+		s.fence = ""
+	}
+}
+
+// lastRuneIndex returns the index of the last r in runes, or -1.
+func lastRuneIndex(runes []rune, r rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// EditMessage edits a message previously sent by the current user.
+//
+// Only Flags can be edited on messages sent by other users, which requires the PermissionManageMessages permission.
+//
+// Reference: https://discord.com/developers/docs/resources/message#edit-message
+func (r *requester) EditMessage(channelID, messageID Snowflake, opts EditMessageOptions) result.Result[Message] {
+	payload, err := MarshalPartial(struct {
+		EditMessageOptions
+		Attachments []attachmentPayload `json:"attachments,omitzero"`
+	}{opts, opts.attachmentPayloads()})
+	if err != nil {
+		return result.Err[Message](err)
+	}
+	reqBody, contentType, err := encodeMessageBody(payload, opts.Files)
+	if err != nil {
+		return result.Err[Message](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method:      "PATCH",
+		URL:         "/channels/" + channelID.String() + "/messages/" + messageID.String(),
+		Body:        reqBody,
+		ContentType: contentType,
+	})
+	if res.IsErr() {
+		return result.Err[Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var message Message
+	if err := json.NewDecoder(body).Decode(&message); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/channels/{id}/messages/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Message](err)
+	}
+	return result.Ok(message)
+}
+
+// DisableAllComponents edits a message to disable all of its buttons and select menus,
+// typically once the interactions it was waiting for are over.
+//
+// The message must have been sent by the current user, use ComponentInteraction.DisableAllComponents
+// from a component interaction.
+func (c *Client) DisableAllComponents(message Message) result.Result[Message] {
+	return c.EditMessage(message.ChannelID, message.ID, EditMessageOptions{
+		Components: optional.Some(DisableComponents(message.Components)),
+	})
+}
+
+// FetchMessage retrieves a single message of a channel.
+//
+// Requires the PermissionViewChannel and PermissionReadMessageHistory permissions.
+//
+// Reference: https://discord.com/developers/docs/resources/message#get-channel-message
+func (r *requester) FetchMessage(channelID, messageID Snowflake) result.Result[Message] {
+	res := r.DoRequest(Request{
+		Method: "GET",
+		URL:    "/channels/" + channelID.String() + "/messages/" + messageID.String(),
+	})
+	if res.IsErr() {
+		return result.Err[Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var message Message
+	if err := json.NewDecoder(body).Decode(&message); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/channels/{id}/messages/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Message](err)
+	}
+	return result.Ok(message)
+}
+
+// FetchMessage retrieves a single message of a channel.
+//
+// The fetched message is put in the cache if CacheFlagMessages is enabled.
+//
+// Requires the PermissionViewChannel and PermissionReadMessageHistory permissions.
+func (c *Client) FetchMessage(channelID, messageID Snowflake) result.Result[Message] {
+	res := c.requester.FetchMessage(channelID, messageID)
+	if res.IsOk() && c.Flags().Has(CacheFlagMessages) {
+		c.PutMessage(res.Value())
+	}
+	return res
+}
+
+// Message returns a message of a channel from the cache, falling back to FetchMessage on a cache miss.
+//
+// Returns None if the message can't be fetched, for example if it doesn't exist.
+func (c *Client) Message(channelID, messageID Snowflake) optional.Option[Message] {
+	if message, err := c.GetMessage(messageID).GetErr(); err == nil && message.ChannelID == channelID {
+		return optional.Some(message)
+	}
+	res := c.FetchMessage(channelID, messageID)
+	if res.IsErr() {
+		return optional.None[Message]()
+	}
+	return optional.Some(res.Value())
+}
+
+// FetchMessagesOptions contains parameters for fetching the messages of a channel.
+//
+// Note:
+//   - Around, Before and After are mutually exclusive, only one may be set.
+//
+// Reference: https://discord.com/developers/docs/resources/message#get-channel-messages
+type FetchMessagesOptions struct {
+	// Around gets messages around this message ID.
+	Around Snowflake
+
+	// Before gets messages before this message ID.
+	Before Snowflake
+
+	// After gets messages after this message ID.
+	After Snowflake
+
+	// Limit is the maximum number of messages to return (1-100).
+	//
+	// Note:
+	//   - Defaults to 50 if not specified.
+	Limit int
+}
+
+// FetchMessages retrieves the messages of a channel, newest first.
+//
+// Requires the PermissionViewChannel permission, and PermissionReadMessageHistory
+// otherwise no messages will be returned.
+//
+// Reference: https://discord.com/developers/docs/resources/message#get-channel-messages
+func (r *requester) FetchMessages(channelID Snowflake, opts FetchMessagesOptions) result.Result[[]Message] {
+	params := url.Values{}
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if !opts.Around.UnSet() {
+		params.Set("around", opts.Around.String())
+	}
+	if !opts.Before.UnSet() {
+		params.Set("before", opts.Before.String())
+	}
+	if !opts.After.UnSet() {
+		params.Set("after", opts.After.String())
+	}
+
+	endpoint := "/channels/" + channelID.String() + "/messages"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	if res.IsErr() {
+		return result.Err[[]Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var messages []Message
+	if err := json.NewDecoder(body).Decode(&messages); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/channels/{id}/messages",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]Message](err)
+	}
+	return result.Ok(messages)
+}
+
+// DeleteMessage deletes a message.
+//
+// Requires the PermissionManageMessages permission if the message was not sent by the current user.
+//
+// Reference: https://discord.com/developers/docs/resources/message#delete-message
+func (r *requester) DeleteMessage(channelID, messageID Snowflake, reason string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/channels/" + channelID.String() + "/messages/" + messageID.String(),
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// BulkDeleteMaxAge is the maximum age of a message that can still be deleted with BulkDeleteMessages.
+const BulkDeleteMaxAge = 14 * 24 * time.Hour
+
+// BulkDeleteMessagesOlderThanAllowed partitions message IDs into the ones that can still be
+// bulk deleted and the ones older than BulkDeleteMaxAge, based on their snowflake timestamps.
+//
+// The order of the IDs is preserved in both slices.
+func BulkDeleteMessagesOlderThanAllowed(messageIDs []Snowflake) (deletable, tooOld []Snowflake) {
+	return partitionBulkDeletable(messageIDs, time.Now())
+}
+
+// partitionBulkDeletable is BulkDeleteMessagesOlderThanAllowed relative to now.
+func partitionBulkDeletable(messageIDs []Snowflake, now time.Time) (deletable, tooOld []Snowflake) {
+	for _, messageID := range messageIDs {
+		if now.Sub(messageID.Timestamp()) < BulkDeleteMaxAge {
+			deletable = append(deletable, messageID)
+		} else {
+			tooOld = append(tooOld, messageID)
+		}
+	}
+	return deletable, tooOld
+}
+
+// BulkDeleteMessages deletes multiple messages in a single request.
+//
+// Requires the PermissionManageMessages permission.
+//
+// Note:
+//   - Between 2 and 100 messages can be deleted at once.
+//   - Messages older than BulkDeleteMaxAge cannot be bulk deleted, use
+//     BulkDeleteMessagesOlderThanAllowed to filter them out beforehand.
+//   - Returns an error without sending a request if any of these constraints is not met.
+//
+// Reference: https://discord.com/developers/docs/resources/message#bulk-delete-messages
+func (r *requester) BulkDeleteMessages(channelID Snowflake, messageIDs []Snowflake, reason string) result.Void {
+	if len(messageIDs) < 2 || len(messageIDs) > 100 {
+		return result.ErrVoid(fmt.Errorf("BulkDeleteMessages: between 2 and 100 message IDs are required, got %d", len(messageIDs)))
+	}
+	if _, tooOld := partitionBulkDeletable(messageIDs, r.clock.Now()); len(tooOld) > 0 {
+		return result.ErrVoid(fmt.Errorf("BulkDeleteMessages: %d messages are older than 14 days", len(tooOld)))
+	}
+
+	reqBody, _ := json.Marshal(struct {
+		Messages []Snowflake `json:"messages"`
+	}{messageIDs})
+
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/channels/" + channelID.String() + "/messages/bulk-delete",
+		Body:   reqBody,
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// PurgeOptions contains parameters for purging the messages of a channel.
+type PurgeOptions struct {
+	// Limit is the number of most recent messages to scan (before Filter is applied).
+	//
+	// Note:
+	//   - Defaults to 100 if not specified.
+	Limit int
+
+	// Before starts scanning from the messages before this message ID.
+	//
+	// Optional:
+	//   - Defaults to the latest message of the channel.
+	Before Snowflake
+
+	// Filter decides which of the scanned messages are deleted, e.g. by author.
+	//
+	// Optional:
+	//   - If nil, all scanned messages are deleted.
+	Filter func(Message) bool
+
+	// SingleDeleteInterval is the delay between single deletes of messages older than BulkDeleteMaxAge.
+	//
+	// Note:
+	//   - Defaults to 1 second if not specified.
+	SingleDeleteInterval time.Duration
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string
+}
+
+// PurgeError is returned by PurgeMessages when a request fails, possibly after some messages were deleted.
+type PurgeError struct {
+	// Deleted is the number of messages deleted before the failing request.
+	Deleted int
+	// Err is the error of the failing request.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *PurgeError) Error() string {
+	return fmt.Sprintf("purge stopped after deleting %d messages: %v", e.Deleted, e.Err)
+}
+
+// Unwrap returns the error of the failing request.
+func (e *PurgeError) Unwrap() error {
+	return e.Err
+}
+
+// PurgeMessages deletes the most recent messages of a channel and returns how many were deleted.
+//
+// Messages younger than BulkDeleteMaxAge are deleted with BulkDeleteMessages in batches of 100,
+// older ones are deleted one by one, waiting SingleDeleteInterval between each request.
+// Messages that reach BulkDeleteMaxAge while the purge runs are deleted one by one as well.
+//
+// Requires the PermissionManageMessages and PermissionReadMessageHistory permissions.
+//
+// Note:
+//   - Errors are returned as a *PurgeError holding the number of messages deleted before the failure.
+//
+// Usage example:
+//
+//	res := client.PurgeMessages(channelID, dwaz.PurgeOptions{
+//		Limit:  50,
+//		Filter: func(m dwaz.Message) bool { return m.Author.ID == userID },
+//	})
+//	var purgeErr *dwaz.PurgeError
+//	if errors.As(res.Err(), &purgeErr) {
+//		fmt.Println("deleted", purgeErr.Deleted, "messages before failing:", purgeErr.Err)
+//	}
+func (c *Client) PurgeMessages(channelID Snowflake, opts PurgeOptions) result.Result[int] {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	interval := opts.SingleDeleteInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	messages := make([]Message, 0, limit)
+	before := opts.Before
+	for len(messages) < limit {
+		res := c.FetchMessages(channelID, FetchMessagesOptions{Before: before, Limit: min(limit-len(messages), 100)})
+		if res.IsErr() {
+			return result.Err[int](&PurgeError{Err: res.Err()})
+		}
+		page := res.Value()
+		if len(page) == 0 {
+			break
+		}
+		messages = append(messages, page...)
+		before = page[len(page)-1].ID
+	}
+
+	if opts.Filter != nil {
+		messages = slices.DeleteFunc(messages, func(m Message) bool { return !opts.Filter(m) })
+	}
+
+	clock := c.requester.clock
+	bulk, single := partitionPurgeMessages(messages, clock.Now())
+
+	deleted := 0
+	for _, batch := range bulk {
+		// Earlier batches take time, messages close to BulkDeleteMaxAge may have reached it since.
+		batch, agedOut := partitionBulkDeletable(batch, clock.Now())
+		if len(batch) < 2 {
+			single = append(append(batch, agedOut...), single...)
+			continue
+		}
+		single = append(agedOut, single...)
+
+		if res := c.BulkDeleteMessages(channelID, batch, opts.Reason); res.IsErr() {
+			return result.Err[int](&PurgeError{Deleted: deleted, Err: res.Err()})
+		}
+		deleted += len(batch)
+	}
+	for i, messageID := range single {
+		if i > 0 {
+			clock.Sleep(context.Background(), interval)
+		}
+		if res := c.DeleteMessage(channelID, messageID, opts.Reason); res.IsErr() {
+			return result.Err[int](&PurgeError{Deleted: deleted, Err: res.Err()})
+		}
+		deleted++
+	}
+	return result.Ok(deleted)
+}
+
+// partitionPurgeMessages splits messages into batches that can be bulk deleted
+// (younger than BulkDeleteMaxAge, 2-100 per batch) and message IDs that must be deleted one by one.
+func partitionPurgeMessages(messages []Message, now time.Time) (bulk [][]Snowflake, single []Snowflake) {
+	messageIDs := make([]Snowflake, len(messages))
+	for i, message := range messages {
+		messageIDs[i] = message.ID
+	}
+	recent, single := partitionBulkDeletable(messageIDs, now)
+
+	for batch := range slices.Chunk(recent, 100) {
+		if len(batch) < 2 {
+			single = append(batch, single...)
+			continue
+		}
+		bulk = append(bulk, batch)
+	}
+	return bulk, single
+}
+
+// CrosspostMessage publishes a message in an announcement channel to the channels following it.
+//
+// Requires the PermissionSendMessages permission if the current user sent the message,
+// or PermissionManageMessages otherwise.
+//
+// Note:
+//   - The message must be in an AnnouncementChannel, Discord rejects the request otherwise.
+//
+// Reference: https://discord.com/developers/docs/resources/message#crosspost-message
+func (r *requester) CrosspostMessage(channelID, messageID Snowflake) result.Result[Message] {
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/channels/" + channelID.String() + "/messages/" + messageID.String() + "/crosspost",
+	})
+	if res.IsErr() {
+		return result.Err[Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var message Message
+	if err := json.NewDecoder(body).Decode(&message); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/channels/{id}/messages/{id}/crosspost",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Message](err)
+	}
+	return result.Ok(message)
+}
+
+// PinMessage pins a message in a channel.
+//
+// Requires the PermissionPinMessages permission.
+//
+// Note:
+//   - A channel can have a maximum of 50 pinned messages.
+//
+// Reference: https://discord.com/developers/docs/resources/message#pin-message
+func (r *requester) PinMessage(channelID, messageID Snowflake, reason string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "PUT",
+		URL:    "/channels/" + channelID.String() + "/pins/" + messageID.String(),
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// UnpinMessage unpins a message in a channel.
+//
+// Requires the PermissionPinMessages permission.
+//
+// Reference: https://discord.com/developers/docs/resources/message#unpin-message
+func (r *requester) UnpinMessage(channelID, messageID Snowflake, reason string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/channels/" + channelID.String() + "/pins/" + messageID.String(),
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// FetchPinnedMessages retrieves all pinned messages in a channel.
+//
+// Requires the PermissionViewChannel and PermissionReadMessageHistory permissions.
+//
+// Reference: https://discord.com/developers/docs/resources/message#get-pinned-messages
+func (r *requester) FetchPinnedMessages(channelID Snowflake) result.Result[[]Message] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/channels/" + channelID.String() + "/pins"})
+	if res.IsErr() {
+		return result.Err[[]Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var messages []Message
+	if err := json.NewDecoder(body).Decode(&messages); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/channels/{id}/pins",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]Message](err)
+	}
+	return result.Ok(messages)
+}
+
+/************************
+ *    Reactions REST    *
+ ************************/
+
+// ReactionType is the type of a message reaction.
+//
+// Reference: https://discord.com/developers/docs/resources/message#get-reactions-reaction-types
+type ReactionType int
+
+const (
+	// ReactionTypeNormal is a normal reaction.
+	ReactionTypeNormal ReactionType = iota
+	// ReactionTypeBurst is a super reaction.
+	ReactionTypeBurst
+)
+
+// encodeReactionEmoji returns the URL path segment for a reaction emoji.
+//
+// The emoji may be a unicode emoji, "name:id" for a custom emoji, or a custom emoji mention
+// such as "<:name:id>" or "<a:name:id>".
+func encodeReactionEmoji(emoji string) string {
+	if strings.HasPrefix(emoji, "<") {
+		if parsed, err := ParseEmoji(emoji); err == nil {
+			emoji = parsed.Name + ":" + parsed.ID.String()
+		}
+	}
+	return url.PathEscape(emoji)
+}
+
+// reactionsEndpoint returns the endpoint of the reactions of a message with the given emoji.
+func reactionsEndpoint(channelID, messageID Snowflake, emoji string) string {
+	return "/channels/" + channelID.String() + "/messages/" + messageID.String() + "/reactions/" + encodeReactionEmoji(emoji)
+}
+
+// AddReaction adds a reaction to a message as the current user.
+//
+// The emoji is either a unicode emoji or "name:id" for a custom emoji.
+//
+// Requires the PermissionReadMessageHistory permission, and PermissionAddReactions
+// if nobody else has reacted to the message with this emoji.
+//
+// Reference: https://discord.com/developers/docs/resources/message#create-reaction
+func (r *requester) AddReaction(channelID, messageID Snowflake, emoji string) result.Void {
+	res := r.DoRequest(Request{Method: "PUT", URL: reactionsEndpoint(channelID, messageID, emoji) + "/@me"})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// RemoveOwnReaction removes a reaction the current user has made on a message.
+//
+// Reference: https://discord.com/developers/docs/resources/message#delete-own-reaction
+func (r *requester) RemoveOwnReaction(channelID, messageID Snowflake, emoji string) result.Void {
+	res := r.DoRequest(Request{Method: "DELETE", URL: reactionsEndpoint(channelID, messageID, emoji) + "/@me"})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// RemoveUserReaction removes a reaction another user has made on a message.
+//
+// Requires the PermissionManageMessages permission.
+//
+// Reference: https://discord.com/developers/docs/resources/message#delete-user-reaction
+func (r *requester) RemoveUserReaction(channelID, messageID Snowflake, emoji string, userID Snowflake) result.Void {
+	res := r.DoRequest(Request{Method: "DELETE", URL: reactionsEndpoint(channelID, messageID, emoji) + "/" + userID.String()})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// FetchReactionsOptions contains parameters for fetching the users who reacted to a message.
+//
+// Reference: https://discord.com/developers/docs/resources/message#get-reactions-query-string-params
+type FetchReactionsOptions struct {
+	// Type is the type of reactions to fetch.
+	//
+	// Note:
+	//   - Defaults to ReactionTypeNormal.
+	Type ReactionType
+
+	// After gets users after this user ID, used to paginate through the reactions.
+	After Snowflake
+
+	// Limit is the maximum number of users to return (1-100).
+	//
+	// Note:
+	//   - Defaults to 25 if not specified.
+	Limit int
+}
+
+// FetchReactions retrieves the users who reacted to a message with the given emoji, sorted by user ID.
+//
+// To fetch the next page, set After to the ID of the last user returned.
+//
+// Reference: https://discord.com/developers/docs/resources/message#get-reactions
+func (r *requester) FetchReactions(channelID, messageID Snowflake, emoji string, opts FetchReactionsOptions) result.Result[[]User] {
+	params := url.Values{}
+	if opts.Type != ReactionTypeNormal {
+		params.Set("type", strconv.Itoa(int(opts.Type)))
+	}
+	if !opts.After.UnSet() {
+		params.Set("after", opts.After.String())
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	endpoint := reactionsEndpoint(channelID, messageID, emoji)
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	res := r.DoRequest(Request{Method: "GET", URL: endpoint})
+	if res.IsErr() {
+		return result.Err[[]User](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var users []User
+	if err := json.NewDecoder(body).Decode(&users); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/channels/{id}/messages/{id}/reactions/{emoji}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]User](err)
+	}
+	return result.Ok(users)
+}
+
+// DeleteAllReactions removes all reactions on a message.
+//
+// Requires the PermissionManageMessages permission.
+//
+// Reference: https://discord.com/developers/docs/resources/message#delete-all-reactions
+func (r *requester) DeleteAllReactions(channelID, messageID Snowflake) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/channels/" + channelID.String() + "/messages/" + messageID.String() + "/reactions",
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// DeleteAllReactionsForEmoji removes all reactions with the given emoji on a message.
+//
+// Requires the PermissionManageMessages permission.
+//
+// Reference: https://discord.com/developers/docs/resources/message#delete-all-reactions-for-emoji
+func (r *requester) DeleteAllReactionsForEmoji(channelID, messageID Snowflake, emoji string) result.Void {
+	res := r.DoRequest(Request{Method: "DELETE", URL: reactionsEndpoint(channelID, messageID, emoji)})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
 }
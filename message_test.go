@@ -0,0 +1,974 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+// Helpers
+
+// snowflakeAt returns a snowflake whose timestamp is t, with seq as its increment part.
+func snowflakeAt(t time.Time, seq uint64) Snowflake {
+	return Snowflake(uint64(t.UnixMilli()-discordEpoch)<<22 | seq)
+}
+
+// Tests
+
+func TestPartitionPurgeMessages(t *testing.T) {
+	now := time.Now()
+
+	var messages []Message
+	for i := range 150 {
+		messages = append(messages, Message{ID: snowflakeAt(now.Add(-time.Hour), uint64(i))})
+	}
+	for i := range 3 {
+		messages = append(messages, Message{ID: snowflakeAt(now.Add(-15*24*time.Hour), uint64(i))})
+	}
+
+	bulk, single := partitionPurgeMessages(messages, now)
+
+	if len(bulk) != 2 {
+		t.Fatalf("expected 2 bulk batches, got %d", len(bulk))
+	}
+	if len(bulk[0]) != 100 || len(bulk[1]) != 50 {
+		t.Errorf("unexpected batch sizes %d and %d", len(bulk[0]), len(bulk[1]))
+	}
+	if len(single) != 3 {
+		t.Errorf("expected 3 single deletes for old messages, got %d", len(single))
+	}
+}
+
+func TestPartitionPurgeMessagesLoneRecentMessage(t *testing.T) {
+	now := time.Now()
+	messages := []Message{
+		{ID: snowflakeAt(now.Add(-time.Minute), 0)},
+		{ID: snowflakeAt(now.Add(-20*24*time.Hour), 0)},
+	}
+
+	bulk, single := partitionPurgeMessages(messages, now)
+
+	if len(bulk) != 0 {
+		t.Errorf("expected no bulk batch for a single recent message, got %d", len(bulk))
+	}
+	if len(single) != 2 || single[0] != messages[0].ID {
+		t.Errorf("expected both messages to be deleted one by one, got %v", single)
+	}
+}
+
+func TestPartitionPurgeMessagesBoundary(t *testing.T) {
+	now := time.Now()
+	messages := []Message{
+		{ID: snowflakeAt(now.Add(-BulkDeleteMaxAge+time.Minute), 0)},
+		{ID: snowflakeAt(now.Add(-BulkDeleteMaxAge+time.Minute), 1)},
+		{ID: snowflakeAt(now.Add(-BulkDeleteMaxAge), 0)},
+	}
+
+	bulk, single := partitionPurgeMessages(messages, now)
+
+	if len(bulk) != 1 || len(bulk[0]) != 2 {
+		t.Errorf("expected the two messages younger than 14 days to be bulk deleted, got %v", bulk)
+	}
+	if len(single) != 1 || single[0] != messages[2].ID {
+		t.Errorf("expected the 14 days old message to be deleted alone, got %v", single)
+	}
+}
+
+func TestPurgeMessages(t *testing.T) {
+	now := time.Now()
+	recentA, recentB := snowflakeAt(now.Add(-time.Hour), 1), snowflakeAt(now.Add(-time.Hour), 2)
+	other := snowflakeAt(now.Add(-time.Hour), 3)
+
+	var bulkDeleted []Snowflake
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Query().Get("before") == "":
+			w.Write([]byte(`[
+				{"id": "` + recentB.String() + `", "author": {"id": "1"}},
+				{"id": "` + other.String() + `", "author": {"id": "2"}},
+				{"id": "` + recentA.String() + `", "author": {"id": "1"}}
+			]`))
+		case req.Method == "GET":
+			w.Write([]byte(`[]`))
+		case req.Method == "POST" && req.URL.Path == "/channels/5/messages/bulk-delete":
+			var body struct {
+				Messages []Snowflake `json:"messages"`
+			}
+			buf, _ := io.ReadAll(req.Body)
+			json.Unmarshal(buf, &body)
+			bulkDeleted = body.Messages
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+
+	res := client.PurgeMessages(5, PurgeOptions{
+		Limit:  10,
+		Filter: func(m Message) bool { return m.Author.ID == 1 },
+	})
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if res.Value() != 2 {
+		t.Errorf("expected 2 deleted messages, got %d", res.Value())
+	}
+	if len(bulkDeleted) != 2 || bulkDeleted[0] != recentB || bulkDeleted[1] != recentA {
+		t.Errorf("unexpected bulk deleted ids %v", bulkDeleted)
+	}
+}
+
+// purgeTestMessages returns a JSON page of messages with the given IDs.
+func purgeTestMessages(ids []Snowflake) []byte {
+	messages := make([]map[string]any, len(ids))
+	for i, id := range ids {
+		messages[i] = map[string]any{"id": id.String(), "author": map[string]any{"id": "1"}}
+	}
+	buf, _ := json.Marshal(messages)
+	return buf
+}
+
+func TestPurgeMessagesReturnsCountOnError(t *testing.T) {
+	now := time.Now()
+	clock := &fakeClock{now: now}
+	ids := []Snowflake{
+		snowflakeAt(now.Add(-time.Hour), 1),
+		snowflakeAt(now.Add(-time.Hour), 2),
+		snowflakeAt(now.Add(-BulkDeleteMaxAge-time.Hour), 3),
+		snowflakeAt(now.Add(-BulkDeleteMaxAge-time.Hour), 4),
+	}
+
+	singleDeletes := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Query().Get("before") == "":
+			w.Write(purgeTestMessages(ids))
+		case req.Method == "GET":
+			w.Write([]byte(`[]`))
+		case req.Method == "POST":
+			w.WriteHeader(http.StatusNoContent)
+		case req.Method == "DELETE":
+			singleDeletes++
+			if singleDeletes == 2 {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"code": 50013, "message": "Missing Permissions"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	client.requester.clock = clock
+
+	res := client.PurgeMessages(5, PurgeOptions{})
+	var purgeErr *PurgeError
+	if !errors.As(res.Err(), &purgeErr) {
+		t.Fatalf("error = %v, want a *PurgeError", res.Err())
+	}
+	if purgeErr.Deleted != 3 {
+		t.Errorf("expected 3 deleted messages before the error, got %d", purgeErr.Deleted)
+	}
+	if !IsForbidden(res.Err()) {
+		t.Errorf("error = %v, want the 403 of the failing request", res.Err())
+	}
+	if sleeps := clock.sleeps(); len(sleeps) != 1 || sleeps[0] != time.Second {
+		t.Errorf("slept %v, want the default single delete interval once", sleeps)
+	}
+}
+
+func TestPurgeMessagesDeletesAgedOutMessagesOneByOne(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := &fakeClock{now: now}
+
+	// 100 messages for the first batch, then 2 messages for the second one,
+	// the oldest of which reaches BulkDeleteMaxAge while the first batch is deleted.
+	ids := make([]Snowflake, 0, 102)
+	for i := range 101 {
+		ids = append(ids, snowflakeAt(now.Add(-time.Hour), uint64(i)))
+	}
+	agingOut := snowflakeAt(now.Add(-BulkDeleteMaxAge+500*time.Millisecond), 0)
+	ids = append(ids, agingOut)
+
+	var bulkDeletes [][]Snowflake
+	var singleDeleted []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Query().Get("before") == "":
+			w.Write(purgeTestMessages(ids[:100]))
+		case req.Method == "GET" && req.URL.Query().Get("before") == ids[99].String():
+			w.Write(purgeTestMessages(ids[100:]))
+		case req.Method == "GET":
+			w.Write([]byte(`[]`))
+		case req.Method == "POST":
+			var body struct {
+				Messages []Snowflake `json:"messages"`
+			}
+			json.NewDecoder(req.Body).Decode(&body)
+			bulkDeletes = append(bulkDeletes, body.Messages)
+			clock.advance(time.Second)
+			w.WriteHeader(http.StatusNoContent)
+		case req.Method == "DELETE":
+			singleDeleted = append(singleDeleted, req.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	client.requester.clock = clock
+
+	res := client.PurgeMessages(5, PurgeOptions{Limit: 102})
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if res.Value() != 102 {
+		t.Errorf("expected 102 deleted messages, got %d", res.Value())
+	}
+	if len(bulkDeletes) != 1 || len(bulkDeletes[0]) != 100 {
+		t.Fatalf("expected a single bulk delete of 100 messages, got %d", len(bulkDeletes))
+	}
+	want := []string{
+		"/channels/5/messages/" + ids[100].String(),
+		"/channels/5/messages/" + agingOut.String(),
+	}
+	if !slices.Equal(singleDeleted, want) {
+		t.Errorf("single deleted %v, want %v", singleDeleted, want)
+	}
+}
+
+func TestSendMessagePlainContent(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/channels/10/messages" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+
+		var payload map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if payload["content"] != "hello" {
+			t.Errorf("content = %v, want hello", payload["content"])
+		}
+		if _, ok := payload["attachments"]; ok {
+			t.Error("attachments should be omitted without files")
+		}
+
+		io.WriteString(w, `{"id":"20","channel_id":"10","content":"hello"}`)
+	})
+
+	res := r.SendMessage(10, CreateMessageOptions{Content: "hello"})
+	if res.IsErr() {
+		t.Fatalf("SendMessage: %v", res.Err())
+	}
+	if msg := res.Value(); msg.ID != 20 || msg.Content != "hello" {
+		t.Errorf("message = %+v", msg)
+	}
+}
+
+func TestSendMessageMultipart(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+			t.Fatalf("Content-Type = %q, want multipart/form-data", req.Header.Get("Content-Type"))
+		}
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse multipart: %v", err)
+		}
+
+		var payload struct {
+			Content     string `json:"content"`
+			Attachments []struct {
+				ID          string `json:"id"`
+				Filename    string `json:"filename"`
+				Description string `json:"description"`
+			} `json:"attachments"`
+		}
+		if err := json.Unmarshal([]byte(req.FormValue("payload_json")), &payload); err != nil {
+			t.Fatalf("decode payload_json: %v", err)
+		}
+		if payload.Content != "see file" {
+			t.Errorf("content = %q, want see file", payload.Content)
+		}
+		if len(payload.Attachments) != 1 || payload.Attachments[0].ID != "0" ||
+			payload.Attachments[0].Filename != "notes.txt" || payload.Attachments[0].Description != "my notes" {
+			t.Errorf("attachments = %+v", payload.Attachments)
+		}
+
+		file, header, err := req.FormFile("files[0]")
+		if err != nil {
+			t.Fatalf("files[0]: %v", err)
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if header.Filename != "notes.txt" || string(data) != "file content" {
+			t.Errorf("file = %q %q", header.Filename, data)
+		}
+
+		io.WriteString(w, `{"id":"21","channel_id":"10","content":"see file"}`)
+	})
+
+	res := r.SendMessage(10, CreateMessageOptions{
+		Content: "see file",
+		Files:   []File{{Name: "notes.txt", Reader: strings.NewReader("file content"), Description: "my notes"}},
+	})
+	if res.IsErr() {
+		t.Fatalf("SendMessage: %v", res.Err())
+	}
+	if res.Value().ID != 21 {
+		t.Errorf("ID = %d, want 21", res.Value().ID)
+	}
+}
+
+func TestBulkDeleteMessagesOlderThanAllowed(t *testing.T) {
+	now := time.Now()
+	recent := snowflakeAt(now.Add(-time.Hour), 1)
+	almost := snowflakeAt(now.Add(-BulkDeleteMaxAge+time.Minute), 2)
+	old := snowflakeAt(now.Add(-BulkDeleteMaxAge-time.Minute), 3)
+
+	deletable, tooOld := BulkDeleteMessagesOlderThanAllowed([]Snowflake{old, recent, almost})
+	if len(deletable) != 2 || deletable[0] != recent || deletable[1] != almost {
+		t.Errorf("deletable = %v, want [%d %d]", deletable, recent, almost)
+	}
+	if len(tooOld) != 1 || tooOld[0] != old {
+		t.Errorf("tooOld = %v, want [%d]", tooOld, old)
+	}
+}
+
+func TestBulkDeleteMessagesValidation(t *testing.T) {
+	requests := 0
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	now := time.Now()
+	ids := func(n int) []Snowflake {
+		out := make([]Snowflake, n)
+		for i := range out {
+			out[i] = snowflakeAt(now.Add(-time.Hour), uint64(i))
+		}
+		return out
+	}
+
+	for _, n := range []int{0, 1, 101} {
+		if res := r.BulkDeleteMessages(10, ids(n), ""); !res.IsErr() {
+			t.Errorf("BulkDeleteMessages with %d IDs should fail", n)
+		}
+	}
+
+	withOld := append(ids(5), snowflakeAt(now.Add(-BulkDeleteMaxAge-time.Hour), 9))
+	if res := r.BulkDeleteMessages(10, withOld, ""); !res.IsErr() {
+		t.Error("BulkDeleteMessages with a message older than 14 days should fail")
+	}
+	if requests != 0 {
+		t.Fatalf("invalid calls sent %d requests, want 0", requests)
+	}
+
+	for _, n := range []int{2, 100} {
+		if res := r.BulkDeleteMessages(10, ids(n), ""); res.IsErr() {
+			t.Errorf("BulkDeleteMessages with %d IDs: %v", n, res.Err())
+		}
+	}
+	if requests != 2 {
+		t.Errorf("valid calls sent %d requests, want 2", requests)
+	}
+}
+
+func TestEncodeReactionEmoji(t *testing.T) {
+	tests := map[string]string{
+		"👍":               "%F0%9F%91%8D",
+		"blob:123":        "blob:123",
+		"<:blob:123>":     "blob:123",
+		"<a:party:456>":   "party:456",
+		"name with/slash": "name%20with%2Fslash",
+	}
+	for emoji, want := range tests {
+		if got := encodeReactionEmoji(emoji); got != want {
+			t.Errorf("encodeReactionEmoji(%q) = %q, want %q", emoji, got, want)
+		}
+	}
+}
+
+func TestAddReactionPath(t *testing.T) {
+	var paths []string
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", req.Method)
+		}
+		paths = append(paths, req.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, emoji := range []string{"👍", "blob:123"} {
+		if res := r.AddReaction(10, 20, emoji); res.IsErr() {
+			t.Fatalf("AddReaction(%q): %v", emoji, res.Err())
+		}
+	}
+
+	want := []string{
+		"/channels/10/messages/20/reactions/%F0%9F%91%8D/@me",
+		"/channels/10/messages/20/reactions/blob:123/@me",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(paths), len(want))
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("path[%d] = %q, want %q", i, paths[i], want[i])
+		}
+	}
+}
+
+func TestFetchReactionsPaginatesWithAfter(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("after") {
+		case "":
+			io.WriteString(w, `[{"id":"1","username":"a"},{"id":"2","username":"b"}]`)
+		case "2":
+			io.WriteString(w, `[{"id":"3","username":"c"}]`)
+		default:
+			io.WriteString(w, `[]`)
+		}
+		if got := req.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want 2", got)
+		}
+	})
+
+	var ids []Snowflake
+	opts := FetchReactionsOptions{Limit: 2}
+	for {
+		res := r.FetchReactions(10, 20, "👍", opts)
+		if res.IsErr() {
+			t.Fatalf("FetchReactions: %v", res.Err())
+		}
+		users := res.Value()
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+		if len(users) < opts.Limit {
+			break
+		}
+		opts.After = users[len(users)-1].ID
+	}
+
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Errorf("ids = %v, want [1 2 3]", ids)
+	}
+}
+
+func TestPinEndpoints(t *testing.T) {
+	var requests []string
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		if req.Method == http.MethodGet {
+			io.WriteString(w, `[{"id":"20","channel_id":"10","pinned":true},{"id":"21","channel_id":"10","pinned":true}]`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if res := r.PinMessage(10, 20, "important"); res.IsErr() {
+		t.Fatalf("PinMessage: %v", res.Err())
+	}
+	if res := r.UnpinMessage(10, 20, ""); res.IsErr() {
+		t.Fatalf("UnpinMessage: %v", res.Err())
+	}
+	res := r.FetchPinnedMessages(10)
+	if res.IsErr() {
+		t.Fatalf("FetchPinnedMessages: %v", res.Err())
+	}
+	if messages := res.Value(); len(messages) != 2 || messages[0].ID != 20 || messages[1].ID != 21 {
+		t.Errorf("messages = %+v", messages)
+	}
+
+	want := []string{"PUT /channels/10/pins/20", "DELETE /channels/10/pins/20", "GET /channels/10/pins"}
+	if !slices.Equal(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestCrosspostMessage(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/channels/10/messages/20/crosspost" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		io.WriteString(w, `{"id":"20","channel_id":"10","content":"news","flags":1}`)
+	})
+
+	res := r.CrosspostMessage(10, 20)
+	if res.IsErr() {
+		t.Fatalf("CrosspostMessage: %v", res.Err())
+	}
+	msg := res.Value()
+	if msg.ID != 20 || msg.Content != "news" || !msg.Flags.Has(MessageFlagCrossposted) {
+		t.Errorf("message = %+v", msg)
+	}
+}
+
+func TestFetchMessageWriteThrough(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Path != "/channels/10/messages/20" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		io.WriteString(w, `{"id":"20","channel_id":"10","content":"hello","reactions":[{"count":1,"count_details":{"normal":1},"emoji":{"name":"👍"}}]}`)
+	})
+
+	if res := client.FetchMessage(10, 20); res.IsErr() {
+		t.Fatalf("FetchMessage: %v", res.Err())
+	}
+	if !client.GetMessage(20).IsPresent() {
+		t.Fatal("fetched message should be cached")
+	}
+
+	message, err := client.Message(10, 20).GetErr()
+	if err != nil || message.Content != "hello" {
+		t.Errorf("Message(10, 20) = %+v, %v", message, err)
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+
+	reactionAdd := client.handlersManagers["MESSAGE_REACTION_ADD"]
+	reactionAdd.handleEvent(client, false, 0, []byte(`{"user_id":"5","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+	reactionAdd.handleEvent(client, false, 0, []byte(`{"user_id":"5","channel_id":"10","message_id":"20","emoji":{"id":"99","name":"party"},"burst":true}`))
+	client.handlersManagers["MESSAGE_REACTION_REMOVE"].handleEvent(client, false, 0,
+		[]byte(`{"user_id":"5","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+
+	reactions := client.GetMessage(20).Get().Reactions
+	if len(reactions) != 2 {
+		t.Fatalf("reactions = %+v, want 2", reactions)
+	}
+	if reactions[0].Count != 1 || reactions[0].CountDetails.Normal != 1 {
+		t.Errorf("thumbs up reaction = %+v", reactions[0])
+	}
+	if reactions[1].Emoji.ID != 99 || reactions[1].Count != 1 || reactions[1].CountDetails.Burst != 1 {
+		t.Errorf("custom reaction = %+v", reactions[1])
+	}
+
+	client.handlersManagers["MESSAGE_REACTION_REMOVE_EMOJI"].handleEvent(client, false, 0,
+		[]byte(`{"channel_id":"10","message_id":"20","emoji":{"id":"99","name":"party"}}`))
+	if reactions := client.GetMessage(20).Get().Reactions; len(reactions) != 1 {
+		t.Errorf("reactions after emoji removal = %+v", reactions)
+	}
+}
+
+// slowMessageCache is an in memory cache whose message reads are slow,
+// so concurrent read-modify-write updates of a message overlap.
+type slowMessageCache struct {
+	*InMemoryCacheManager
+}
+
+func (c slowMessageCache) GetMessage(messageID Snowflake) optional.Option[Message] {
+	message := c.InMemoryCacheManager.GetMessage(messageID)
+	time.Sleep(time.Millisecond)
+	return message
+}
+
+func TestConcurrentReactionAddsKeepCount(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.CacheManager = slowMessageCache{NewInMemoryCacheManager(CacheFlagsAll)}
+	client.PutMessage(Message{ID: 20, ChannelID: 10})
+
+	const reactions = 50
+	reactionAdd := client.handlersManagers["MESSAGE_REACTION_ADD"]
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range reactions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			reactionAdd.handleEvent(client, false, 0,
+				[]byte(`{"user_id":"`+strconv.Itoa(100+i)+`","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	got := client.GetMessage(20).Get().Reactions
+	if len(got) != 1 || got[0].Count != reactions || got[0].CountDetails.Normal != reactions {
+		t.Errorf("reactions = %+v, want a count of %d", got, reactions)
+	}
+}
+
+func TestSelfReactionSetsMe(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.selfID.Store(5)
+	client.PutMessage(Message{ID: 20, ChannelID: 10})
+
+	reactionAdd := client.handlersManagers["MESSAGE_REACTION_ADD"]
+	reactionAdd.handleEvent(client, false, 0, []byte(`{"user_id":"6","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+	if reaction := client.GetMessage(20).Get().Reactions[0]; reaction.Me {
+		t.Errorf("reaction of another user set Me: %+v", reaction)
+	}
+
+	reactionAdd.handleEvent(client, false, 0, []byte(`{"user_id":"5","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+	reactionAdd.handleEvent(client, false, 0, []byte(`{"user_id":"5","channel_id":"10","message_id":"20","emoji":{"name":"👍"},"burst":true}`))
+	if reaction := client.GetMessage(20).Get().Reactions[0]; !reaction.Me || !reaction.MeBurst {
+		t.Errorf("self reactions = %+v, want Me and MeBurst", reaction)
+	}
+
+	client.handlersManagers["MESSAGE_REACTION_REMOVE"].handleEvent(client, false, 0,
+		[]byte(`{"user_id":"5","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+	if reaction := client.GetMessage(20).Get().Reactions[0]; reaction.Me || !reaction.MeBurst || reaction.Count != 2 {
+		t.Errorf("after removing the self reaction = %+v, want only MeBurst", reaction)
+	}
+}
+
+func TestEmbedBuilderBuild(t *testing.T) {
+	now := time.Now()
+	embed, err := NewEmbedBuilder().
+		SetTitle("Title").
+		SetDescription("Description").
+		SetURL("https://example.com").
+		SetColor(0xff0000).
+		SetTimestamp(now).
+		SetAuthor("Author", "https://example.com/author", "https://example.com/author.png").
+		SetFooter("Footer", "https://example.com/footer.png").
+		SetImage("https://example.com/image.png").
+		SetThumbnail("https://example.com/thumb.png").
+		AddField("Name", "Value", true).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if embed.Title != "Title" || embed.Description != "Description" || embed.URL != "https://example.com" || embed.Color != 0xff0000 {
+		t.Errorf("unexpected embed: %+v", embed)
+	}
+	if embed.Timestamp == nil || !embed.Timestamp.Equal(now) {
+		t.Errorf("unexpected timestamp: %v", embed.Timestamp)
+	}
+	if embed.Author == nil || embed.Author.Name != "Author" || embed.Footer == nil || embed.Footer.Text != "Footer" {
+		t.Errorf("unexpected author/footer: %+v %+v", embed.Author, embed.Footer)
+	}
+	if embed.Image == nil || embed.Image.URL != "https://example.com/image.png" || embed.Thumbnail == nil || embed.Thumbnail.URL != "https://example.com/thumb.png" {
+		t.Errorf("unexpected image/thumbnail: %+v %+v", embed.Image, embed.Thumbnail)
+	}
+	if len(embed.Fields) != 1 || embed.Fields[0] != (EmbedField{Name: "Name", Value: "Value", Inline: true}) {
+		t.Errorf("unexpected fields: %+v", embed.Fields)
+	}
+	if n := embed.Length(); n != len("TitleDescriptionNameValueFooterAuthor") {
+		t.Errorf("Length() = %d", n)
+	}
+}
+
+func TestEmbedBuilderLimits(t *testing.T) {
+	tooManyFields := NewEmbedBuilder()
+	for range EmbedFieldsMaxCount + 1 {
+		tooManyFields.AddField("n", "v", false)
+	}
+	tooLong := NewEmbedBuilder()
+	for range 5 {
+		tooLong.AddField("n", strings.Repeat("a", EmbedFieldValueMaxLength), false)
+	}
+	tooLong.SetDescription(strings.Repeat("a", 1000))
+
+	tests := map[string]*EmbedBuilder{
+		"title":        NewEmbedBuilder().SetTitle(strings.Repeat("a", EmbedTitleMaxLength+1)),
+		"description":  NewEmbedBuilder().SetDescription(strings.Repeat("a", EmbedDescriptionMaxLength+1)),
+		"fields count": tooManyFields,
+		"field name":   NewEmbedBuilder().AddField(strings.Repeat("a", EmbedFieldNameMaxLength+1), "v", false),
+		"field value":  NewEmbedBuilder().AddField("n", strings.Repeat("a", EmbedFieldValueMaxLength+1), false),
+		"footer":       NewEmbedBuilder().SetFooter(strings.Repeat("a", EmbedFooterTextMaxLength+1), ""),
+		"author":       NewEmbedBuilder().SetAuthor(strings.Repeat("a", EmbedAuthorNameMaxLength+1), "", ""),
+		"total":        tooLong,
+	}
+	for name, builder := range tests {
+		if _, err := builder.Build(); err == nil {
+			t.Errorf("%s: expected a limit error", name)
+		}
+	}
+
+	// limits count characters, not bytes.
+	if _, err := NewEmbedBuilder().SetTitle(strings.Repeat("é", EmbedTitleMaxLength)).Build(); err != nil {
+		t.Errorf("unexpected error for a multi-byte title: %v", err)
+	}
+}
+
+func TestTryDM(t *testing.T) {
+	blocked := false
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /users/@me/channels":
+			w.Write([]byte(`{"id": "30", "type": 1}`))
+		case "POST /channels/30/messages":
+			if blocked {
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `{"code": 50007, "message": "Cannot send messages to this user"}`)
+				return
+			}
+			w.Write([]byte(`{"id": "40", "channel_id": "30", "content": "hi"}`))
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+
+	res := client.TryDM(5, CreateMessageOptions{Content: "hi"})
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	if msg, err := res.Value().GetErr(); err != nil || msg.ID != 40 {
+		t.Errorf("unexpected message: %+v", res.Value())
+	}
+
+	blocked = true
+	res = client.TryDM(5, CreateMessageOptions{Content: "hi"})
+	if res.IsErr() {
+		t.Fatalf("a refused DM should not be an error: %v", res.Err())
+	}
+	if res.Value().IsPresent() {
+		t.Error("expected None for a refused DM")
+	}
+}
+
+func TestAllowedMentionsBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		mentions *AllowedMentions
+		want     string
+	}{
+		{"suppress all", SuppressAllMentions(), `{}`},
+		{"empty builder", NewAllowedMentionsBuilder().Build(), `{}`},
+		{
+			"everyone suppressed",
+			NewAllowedMentionsBuilder().AllowEveryone().AllowAllUsers().SuppressEveryone().Build(),
+			`{"parse":["users"]}`,
+		},
+		{
+			"whitelist",
+			NewAllowedMentionsBuilder().AllowAllUsers().AllowUsers(1, 2).AllowRoles(3).Build(),
+			`{"roles":["3"],"users":["1","2"]}`,
+		},
+		{
+			"all roles and replied user",
+			NewAllowedMentionsBuilder().AllowRoles(3).AllowAllRoles().AllowEveryone().SetRepliedUser(true).Build(),
+			`{"parse":["roles","everyone"],"replied_user":true}`,
+		},
+	}
+	for _, tt := range tests {
+		buf, err := json.Marshal(tt.mentions)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if string(buf) != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, buf, tt.want)
+		}
+	}
+}
+
+func TestSendMessageAllowedMentions(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			AllowedMentions json.RawMessage `json:"allowed_mentions"`
+		}
+		buf, _ := io.ReadAll(req.Body)
+		json.Unmarshal(buf, &body)
+		if string(body.AllowedMentions) != `{"users":["5"]}` {
+			t.Errorf("unexpected allowed_mentions: %s", body.AllowedMentions)
+		}
+		w.Write([]byte(`{"id": "1"}`))
+	})
+
+	res := r.SendMessage(10, CreateMessageOptions{
+		Content:         "@everyone <@5>",
+		AllowedMentions: NewAllowedMentionsBuilder().AllowUsers(5).Build(),
+	})
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+}
+
+func TestSplitMessageContent(t *testing.T) {
+	if chunks := SplitMessageContent("hello\nworld", 20); !slices.Equal(chunks, []string{"hello\nworld"}) {
+		t.Errorf("short content = %q", chunks)
+	}
+
+	chunks := SplitMessageContent("first line\nsecond line\nthird line", 24)
+	if !slices.Equal(chunks, []string{"first line\nsecond line", "third line"}) {
+		t.Errorf("split on lines = %q", chunks)
+	}
+
+	chunks = SplitMessageContent("lorem ipsum dolor sit amet consectetur", 12)
+	if !slices.Equal(chunks, []string{"lorem ipsum", "dolor sit", "amet", "consectetur"}) {
+		t.Errorf("split on words = %q", chunks)
+	}
+
+	chunks = SplitMessageContent(strings.Repeat("é", 25), 10)
+	if !slices.Equal(chunks, []string{strings.Repeat("é", 10), strings.Repeat("é", 10), strings.Repeat("é", 5)}) {
+		t.Errorf("split long word = %q", chunks)
+	}
+}
+
+func TestSplitMessageContentCodeBlock(t *testing.T) {
+	var lines []string
+	for i := range 20 {
+		lines = append(lines, "fmt.Println("+strconv.Itoa(i)+")")
+	}
+	content := "Output:\n```go\n" + strings.Join(lines, "\n") + "\n```\nDone."
+
+	chunks := SplitMessageContent(content, 100)
+	if len(chunks) < 3 {
+		t.Fatalf("expected several chunks, got %q", chunks)
+	}
+	var rebuilt []string
+	for i, chunk := range chunks {
+		if n := utf8.RuneCountInString(chunk); n > 100 {
+			t.Errorf("chunk %d has %d characters", i, n)
+		}
+		if strings.Count(chunk, "```")%2 != 0 {
+			t.Errorf("chunk %d has an unclosed code block: %q", i, chunk)
+		}
+		if i > 0 && i < len(chunks)-1 && !strings.HasPrefix(chunk, "```go\n") {
+			t.Errorf("chunk %d should reopen the go code block: %q", i, chunk)
+		}
+		for line := range strings.SplitSeq(chunk, "\n") {
+			if strings.HasPrefix(line, "fmt.") {
+				rebuilt = append(rebuilt, line)
+			}
+		}
+	}
+	if !slices.Equal(rebuilt, lines) {
+		t.Errorf("code lines lost or reordered: %q", rebuilt)
+	}
+	if last := chunks[len(chunks)-1]; !strings.HasSuffix(last, "```\nDone.") {
+		t.Errorf("last chunk = %q", last)
+	}
+}
+
+func TestSendLongMessage(t *testing.T) {
+	type sent struct {
+		Content          string            `json:"content"`
+		Embeds           []Embed           `json:"embeds"`
+		MessageReference *MessageReference `json:"message_reference"`
+	}
+	var messages []sent
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		var body sent
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		messages = append(messages, body)
+		io.WriteString(w, `{"id": "`+strconv.Itoa(len(messages))+`"}`)
+	})
+
+	content := strings.Repeat("word ", 500) // 2500 characters
+	res := client.SendLongMessage(10, content, CreateMessageOptions{
+		Embeds:           []Embed{{Title: "summary"}},
+		MessageReference: &MessageReference{MessageID: 5},
+	})
+	if res.IsErr() {
+		t.Fatalf("SendLongMessage: %v", res.Err())
+	}
+	if len(res.Value()) != 2 || res.Value()[0].ID != 1 || res.Value()[1].ID != 2 {
+		t.Fatalf("messages = %+v", res.Value())
+	}
+	if len(messages[0].Content) > MessageContentMaxLength || messages[0].Content+" "+messages[1].Content != strings.TrimSpace(content) {
+		t.Errorf("content not split in order: %d + %d characters", len(messages[0].Content), len(messages[1].Content))
+	}
+	if messages[0].MessageReference == nil || messages[1].MessageReference != nil {
+		t.Error("only the first message should reply")
+	}
+	if len(messages[0].Embeds) != 0 || len(messages[1].Embeds) != 1 {
+		t.Error("embeds should only be sent with the last message")
+	}
+}
+
+func TestEditMessageAttachments(t *testing.T) {
+	var payloads []map[string]json.RawMessage
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+			_, params, _ := strings.Cut(req.Header.Get("Content-Type"), "boundary=")
+			body = []byte(multipartPayloadJSON(t, body, params))
+		}
+		var payload map[string]json.RawMessage
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("invalid request body %q: %v", body, err)
+		}
+		payloads = append(payloads, payload)
+		io.WriteString(w, `{"id":"2","channel_id":"1"}`)
+	})
+
+	edits := map[string]func(EditMessageOptions){
+		"message": func(opts EditMessageOptions) { r.EditMessage(1, 2, opts) },
+		"interaction": func(opts EditMessageOptions) {
+			r.EditOriginalInteractionResponse(3, "token", opts)
+		},
+		"webhook": func(opts EditMessageOptions) {
+			r.EditWebhookMessage(4, "token", 2, EditWebhookMessageOptions{EditMessageOptions: opts})
+		},
+	}
+	for name, edit := range edits {
+		t.Run(name, func(t *testing.T) {
+			payloads = nil
+
+			// content-only edits must not send attachments, which would drop the existing ones
+			edit(EditMessageOptions{Content: optional.Some("edited")})
+
+			opts := EditMessageOptions{}
+			opts.KeepAttachments([]Attachment{{ID: 100}, {ID: 101}}).AddAttachment(File{Name: "new.txt", Reader: strings.NewReader("hi")})
+			edit(opts)
+
+			removeAll := EditMessageOptions{}
+			removeAll.KeepAttachments(nil)
+			edit(removeAll)
+
+			if len(payloads) != 3 {
+				t.Fatalf("expected 3 requests, got %d", len(payloads))
+			}
+			if _, ok := payloads[0]["attachments"]; ok {
+				t.Errorf("content-only edit sent attachments: %s", payloads[0]["attachments"])
+			}
+			if got, want := string(payloads[1]["attachments"]), `[{"id":"100"},{"id":"101"},{"id":"0","filename":"new.txt"}]`; got != want {
+				t.Errorf("attachments = %s, want %s", got, want)
+			}
+			if got := string(payloads[2]["attachments"]); got != `[]` {
+				t.Errorf("attachments = %s, want []", got)
+			}
+		})
+	}
+}
+
+// multipartPayloadJSON returns the payload_json part of a multipart body.
+func multipartPayloadJSON(t *testing.T, body []byte, boundary string) string {
+	t.Helper()
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("payload_json part not found: %v", err)
+		}
+		if part.FormName() == "payload_json" {
+			data, _ := io.ReadAll(part)
+			return string(data)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+/*****************************
+ *     Partial Updates
+ *****************************/
+
+// MarshalPartial encodes a PATCH-style options struct into a partial update JSON body.
+//
+// Optional fields (optional.Option[T] tagged with `omitzero`) are encoded as follows:
+//
+//	optional.None[T]()   -> the field is omitted, Discord leaves the value unchanged.
+//	optional.Some(zero)  -> the field is sent with its zero value, e.g. Some("") clears a nickname.
+//	optional.Nil[T]()    -> the field is sent as null, Discord resets/removes the value.
+//	optional.Some(value) -> the field is sent with value.
+//
+// Mixing up None and Some(zero) is easy: None means "leave as is" while
+// Some(zero) means "set to zero". Be explicit about which one you need.
+//
+// MarshalPartial returns an error if an optional.Option field of v is missing the
+// `omitzero` tag option, since such a field would always be sent and silently
+// overwrite the current value with null.
+func MarshalPartial(v any) ([]byte, error) {
+	if err := checkPartialFields(reflect.TypeOf(v)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// Enabled returns Some(true), a readable shorthand for turning on an optional boolean setting.
+//
+// Example usage:
+//
+//	client.ModifyGuildWidget(guildID, ModifyGuildWidgetOptions{Enabled: Enabled()})
+func Enabled() optional.Option[bool] {
+	return optional.Some(true)
+}
+
+// Disabled returns Some(false), a readable shorthand for turning off an optional boolean setting.
+//
+// Unlike Unset, the field is sent to Discord with the false value.
+//
+// Example usage:
+//
+//	client.ModifyGuildWelcomeScreen(guildID, ModifyGuildWelcomeScreenOptions{Enabled: Disabled()})
+func Disabled() optional.Option[bool] {
+	return optional.Some(false)
+}
+
+// Unset returns None, meaning the field is omitted and Discord leaves the current value unchanged.
+//
+// It's the zero value of optional.Option, spelled out for call sites where leaving
+// a setting as is should be explicit.
+//
+// Example usage:
+//
+//	opts := ModifyGuildWidgetOptions{Enabled: Unset[bool](), ChannelID: channelID}
+func Unset[T any]() optional.Option[T] {
+	return optional.None[T]()
+}
+
+// partialTypesChecked caches the result of checkPartialFields per struct type.
+var partialTypesChecked sync.Map
+
+// checkPartialFields verifies that every optional.Option field of the struct type t
+// (including fields of embedded structs) is tagged with `omitzero`.
+func checkPartialFields(t reflect.Type) error {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	if err, ok := partialTypesChecked.Load(t); ok {
+		if err == nil {
+			return nil
+		}
+		return err.(error)
+	}
+
+	var err error
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Anonymous {
+			if err = checkPartialFields(field.Type); err != nil {
+				break
+			}
+			continue
+		}
+		if !field.IsExported() || !isOptionType(field.Type) {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if !strings.Contains(tag, ",omitzero") {
+			err = fmt.Errorf("MarshalPartial: optional field %s.%s is missing the omitzero tag option", t.Name(), field.Name)
+			break
+		}
+	}
+
+	partialTypesChecked.Store(t, err)
+	return err
+}
+
+// isOptionType reports whether t is an instantiation of optional.Option.
+func isOptionType(t reflect.Type) bool {
+	return t.PkgPath() == "github.com/marouanesouiri/stdx/optional" && strings.HasPrefix(t.Name(), "Option[")
+}
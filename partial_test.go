@@ -0,0 +1,138 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+type partialTestOptions struct {
+	Name   optional.Option[string]      `json:"name,omitzero"`
+	Count  optional.Option[int]         `json:"count,omitzero"`
+	Flag   optional.Option[bool]        `json:"flag,omitzero"`
+	ID     optional.Option[Snowflake]   `json:"id,omitzero"`
+	Until  optional.Option[time.Time]   `json:"until,omitzero"`
+	Roles  optional.Option[[]Snowflake] `json:"roles,omitzero"`
+	Reason string                       `json:"-"`
+}
+
+func TestMarshalPartialNoneIsOmitted(t *testing.T) {
+	buf, err := MarshalPartial(partialTestOptions{Reason: "ignored"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buf) != `{}` {
+		t.Errorf("expected all None fields to be omitted, got %s", buf)
+	}
+}
+
+func TestMarshalPartialSomeZeroIsSent(t *testing.T) {
+	buf, err := MarshalPartial(partialTestOptions{
+		Name:  optional.Some(""),
+		Count: optional.Some(0),
+		Flag:  optional.Some(false),
+		ID:    optional.Some(Snowflake(0)),
+		Roles: optional.Some([]Snowflake{}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"name":"","count":0,"flag":false,"id":"0","roles":[]}`
+	if string(buf) != want {
+		t.Errorf("expected %s, got %s", want, buf)
+	}
+}
+
+func TestMarshalPartialNilIsNull(t *testing.T) {
+	buf, err := MarshalPartial(partialTestOptions{
+		Name:  optional.Nil[string](),
+		Until: optional.Nil[time.Time](),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"name":null,"until":null}`
+	if string(buf) != want {
+		t.Errorf("expected %s, got %s", want, buf)
+	}
+}
+
+func TestMarshalPartialValues(t *testing.T) {
+	until := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	buf, err := MarshalPartial(partialTestOptions{
+		Name:  optional.Some("bob"),
+		Count: optional.Some(3),
+		Flag:  optional.Some(true),
+		ID:    optional.Some(Snowflake(42)),
+		Until: optional.Some(until),
+		Roles: optional.Some([]Snowflake{1, 2}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"name":"bob","count":3,"flag":true,"id":"42","until":"2025-01-02T03:04:05Z","roles":["1","2"]}`
+	if string(buf) != want {
+		t.Errorf("expected %s, got %s", want, buf)
+	}
+}
+
+func TestMarshalPartialMissingOmitzero(t *testing.T) {
+	type badOptions struct {
+		Name optional.Option[string] `json:"name"`
+	}
+
+	if _, err := MarshalPartial(badOptions{}); err == nil {
+		t.Errorf("expected an error for an optional field without omitzero")
+	}
+}
+
+func TestMarshalPartialModifyMemberNickname(t *testing.T) {
+	buf, err := MarshalPartial(ModifyMemberOptions{Nickname: optional.Some("")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(buf) != `{"nick":""}` {
+		t.Errorf("expected the nickname to be cleared with an empty string, got %s", buf)
+	}
+
+	for _, opts := range []any{ModifyMemberOptions{}, ModifyGuildOptions{}, CreateChannelOptions{}, CreateGuildOptions{}} {
+		if _, err := MarshalPartial(opts); err != nil {
+			t.Errorf("unexpected error for %T: %v", opts, err)
+		}
+	}
+}
+
+func TestTriStateBoolHelpers(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  optional.Option[bool]
+		want string
+	}{
+		{"Enabled", Enabled(), `{"flag":true}`},
+		{"Disabled", Disabled(), `{"flag":false}`},
+		{"Unset", Unset[bool](), `{}`},
+	}
+	for _, tt := range tests {
+		buf, err := MarshalPartial(partialTestOptions{Flag: tt.opt})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if string(buf) != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, buf, tt.want)
+		}
+	}
+}
@@ -15,7 +15,11 @@ package dwaz
 
 import (
 	"encoding/json"
+	"slices"
 	"strconv"
+	"strings"
+
+	"github.com/marouanesouiri/stdx/result"
 )
 
 // Permissions flags for roles and channels permissions.
@@ -257,6 +261,60 @@ const (
 	PermissionBypassSlowmode Permissions = 1 << 52
 )
 
+// PermissionsAll contains every permission defined above, it's what owners and
+// Administrator members are granted.
+const PermissionsAll = PermissionCreateInstantInvite |
+	PermissionKickMembers |
+	PermissionBanMembers |
+	PermissionAdministrator |
+	PermissionManageChannels |
+	PermissionManageGuild |
+	PermissionAddReactions |
+	PermissionViewAuditLog |
+	PermissionPrioritySpeaker |
+	PermissionStream |
+	PermissionViewChannel |
+	PermissionSendMessages |
+	PermissionSendTTSMessages |
+	PermissionManageMessages |
+	PermissionEmbedLinks |
+	PermissionAttachFiles |
+	PermissionReadMessageHistory |
+	PermissionMentionEveryone |
+	PermissionUseExternalEmojis |
+	PermissionViewGuildInsights |
+	PermissionConnect |
+	PermissionSpeak |
+	PermissionMuteMembers |
+	PermissionDeafenMembers |
+	PermissionMoveMembers |
+	PermissionUseVAD |
+	PermissionChangeNickname |
+	PermissionManageNicknames |
+	PermissionManageRoles |
+	PermissionManageWebhooks |
+	PermissionManageGuildExpressions |
+	PermissionUseApplicationCommands |
+	PermissionRequestToSpeak |
+	PermissionManageEvents |
+	PermissionManageThreads |
+	PermissionCreatePublicThreads |
+	PermissionCreatePrivateThreads |
+	PermissionUseExternalStickers |
+	PermissionSendMessagesInThreads |
+	PermissionUseEmbeddedActivities |
+	PermissionModerateMembers |
+	PermissionViewCreatorMonetizationAnalytics |
+	PermissionUseSoundboard |
+	PermissionCreateGuildExpressions |
+	PermissionCreateEvents |
+	PermissionUseExternalSounds |
+	PermissionSendVoiceMessages |
+	PermissionSendPolls |
+	PermissionUseExternalApps |
+	PermissionPinMessages |
+	PermissionBypassSlowmode
+
 // PermissionName is a human-readable name for a Discord permission.
 type PermissionName = string
 
@@ -341,7 +399,29 @@ func (p *Permissions) Remove(perms ...Permissions) {
 	*p = BitFieldRemove(*p, perms...)
 }
 
-// PermissionsToNames returns a slice of PermissionName for all permissions set in the mask.
+// Toggle flips all given permissions, setting the cleared ones and clearing the set ones.
+func (p *Permissions) Toggle(perms ...Permissions) {
+	*p = BitFieldToggle(*p, perms...)
+}
+
+// Is returns true if p is exactly perms, with no other permission set.
+func (p Permissions) Is(perms Permissions) bool {
+	return p == perms
+}
+
+// String implements the fmt.Stringer interface, listing the names of the set permissions
+// separated by commas, e.g. "ViewChannel, SendMessages". Returns "None" if no permission is set.
+func (p Permissions) String() string {
+	names := p.Names()
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, ", ")
+}
+
+// Names returns a slice of PermissionName for all permissions set in the mask.
+//
+// Unknown bits are skipped.
 func (p Permissions) Names() []PermissionName {
 	var names []PermissionName
 	for bit := Permissions(1); bit != 0; bit <<= 1 {
@@ -484,3 +564,127 @@ func (p *Permissions) UnmarshalJSON(data []byte) error {
 func (p Permissions) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + strconv.FormatUint(uint64(p), 10) + `"`), nil
 }
+
+// ComputeBasePermissions returns the guild-wide permissions of a member, before channel overwrites.
+//
+// roles should contain the guild's roles, at least the @everyone role (whose ID is the guild ID)
+// and the roles of the member, other roles are ignored. The guild owner and members with
+// PermissionAdministrator get PermissionsAll.
+//
+// Reference: https://discord.com/developers/docs/topics/permissions#permission-overwrites
+func ComputeBasePermissions(guild *Guild, member *FullMember, roles []Role) Permissions {
+	if member.ID == guild.OwnerID {
+		return PermissionsAll
+	}
+
+	var permissions Permissions
+	for _, role := range roles {
+		if role.ID == guild.ID || slices.Contains(member.RoleIDs, role.ID) {
+			permissions |= role.Permissions
+		}
+	}
+	if permissions.Has(PermissionAdministrator) {
+		return PermissionsAll
+	}
+	return permissions
+}
+
+// ComputeChannelPermissions applies the permission overwrites of a channel to base, the member's
+// permissions returned by ComputeBasePermissions.
+//
+// Overwrites are applied in the order documented by Discord: the @everyone overwrite, then the
+// overwrites of the member's roles combined, then the member's own overwrite. Within each step
+// denied permissions are removed before allowed ones are added.
+//
+// Reference: https://discord.com/developers/docs/topics/permissions#permission-overwrites
+func ComputeChannelPermissions(base Permissions, channel GuildChannel, member *FullMember) Permissions {
+	if base.Has(PermissionAdministrator) {
+		return PermissionsAll
+	}
+
+	permissions := base
+	overwrites := channel.GetPermissionOverwrites()
+	guildID := channel.GetGuildID()
+
+	for _, overwrite := range overwrites {
+		if overwrite.Type == PermissionOverwriteTypeRole && overwrite.ID == guildID {
+			permissions &^= overwrite.Deny
+			permissions |= overwrite.Allow
+			break
+		}
+	}
+
+	var allow, deny Permissions
+	for _, overwrite := range overwrites {
+		if overwrite.Type == PermissionOverwriteTypeRole && overwrite.ID != guildID && slices.Contains(member.RoleIDs, overwrite.ID) {
+			allow |= overwrite.Allow
+			deny |= overwrite.Deny
+		}
+	}
+	permissions &^= deny
+	permissions |= allow
+
+	for _, overwrite := range overwrites {
+		if overwrite.Type == PermissionOverwriteTypeMember && overwrite.ID == member.ID {
+			permissions &^= overwrite.Deny
+			permissions |= overwrite.Allow
+			break
+		}
+	}
+	return permissions
+}
+
+// MemberPermissions computes the guild-wide permissions of a member with ComputeBasePermissions.
+//
+// The guild and the member are read from the cache, falling back to FetchGuild and FetchMember,
+// and the roles come from EnsureGuildRoles, so it works even when nothing is cached.
+func (c *Client) MemberPermissions(guildID, userID Snowflake) result.Result[Permissions] {
+	guild, member, roles, err := c.permissionsContext(guildID, userID)
+	if err != nil {
+		return result.Err[Permissions](err)
+	}
+	return result.Ok(ComputeBasePermissions(&guild, &member, roles))
+}
+
+// MemberChannelPermissions computes the permissions of a member in a guild channel with
+// ComputeChannelPermissions, see MemberPermissions for where the data comes from.
+//
+// Note:
+//   - Threads inherit the overwrites of their parent channel, pass the parent for threads.
+func (c *Client) MemberChannelPermissions(channel GuildChannel, userID Snowflake) result.Result[Permissions] {
+	guild, member, roles, err := c.permissionsContext(channel.GetGuildID(), userID)
+	if err != nil {
+		return result.Err[Permissions](err)
+	}
+	base := ComputeBasePermissions(&guild, &member, roles)
+	return result.Ok(ComputeChannelPermissions(base, channel, &member))
+}
+
+// permissionsContext gathers the guild, member and roles needed to compute the permissions of a member.
+func (c *Client) permissionsContext(guildID, userID Snowflake) (Guild, FullMember, []Role, error) {
+	guild, err := c.GetGuild(guildID).GetErr()
+	if err != nil {
+		res := c.FetchGuild(guildID, FetchGuildOptions{})
+		if res.IsErr() {
+			return Guild{}, FullMember{}, nil, res.Err()
+		}
+		guild = res.Value().Guild
+	}
+
+	var member FullMember
+	if cached, err := c.GetMember(guildID, userID).GetErr(); err == nil {
+		member.Member = cached
+	} else {
+		res := c.FetchMember(guildID, userID)
+		if res.IsErr() {
+			return Guild{}, FullMember{}, nil, res.Err()
+		}
+		member = res.Value()
+	}
+
+	roles := c.EnsureGuildRoles(guildID)
+	if roles.IsErr() {
+		return Guild{}, FullMember{}, nil, roles.Err()
+	}
+	return guild, member, roles.Value(), nil
+}
@@ -13,7 +13,11 @@
 
 package dwaz
 
-import "testing"
+import (
+	"io"
+	"net/http"
+	"testing"
+)
 
 func TestPermissionsNames(t *testing.T) {
 	perms := PermissionAddReactions | PermissionBanMembers
@@ -30,3 +34,147 @@ func TestPermissionsNames(t *testing.T) {
 		}
 	}
 }
+
+func testPermissionsChannel(overwrites ...PermissionOverwrite) *TextChannel {
+	return &TextChannel{GuildChannelFields: GuildChannelFields{
+		ChannelFields:        ChannelFields{ID: 10},
+		GuildID:              1,
+		PermissionOverwrites: overwrites,
+	}}
+}
+
+func TestComputeBasePermissions(t *testing.T) {
+	guild := &Guild{}
+	guild.ID = 1
+	guild.OwnerID = 99
+	roles := []Role{
+		{ID: 1, Permissions: PermissionViewChannel},
+		{ID: 2, Permissions: PermissionSendMessages},
+		{ID: 3, Permissions: PermissionAdministrator},
+		{ID: 4, Permissions: PermissionBanMembers},
+	}
+
+	member := &FullMember{Member: Member{ID: 5, RoleIDs: []Snowflake{2}}}
+	if got, want := ComputeBasePermissions(guild, member, roles), PermissionViewChannel|PermissionSendMessages; got != want {
+		t.Errorf("base = %v, want %v", got.Names(), want.Names())
+	}
+
+	admin := &FullMember{Member: Member{ID: 6, RoleIDs: []Snowflake{3}}}
+	if got := ComputeBasePermissions(guild, admin, roles); got != PermissionsAll {
+		t.Errorf("administrator base = %v, want PermissionsAll", got.Names())
+	}
+
+	owner := &FullMember{Member: Member{ID: 99}}
+	if got := ComputeBasePermissions(guild, owner, roles); got != PermissionsAll {
+		t.Errorf("owner base = %v, want PermissionsAll", got.Names())
+	}
+}
+
+func TestComputeChannelPermissionsAdministrator(t *testing.T) {
+	member := &FullMember{Member: Member{ID: 5}}
+	channel := testPermissionsChannel(
+		PermissionOverwrite{ID: 1, Type: PermissionOverwriteTypeRole, Deny: PermissionViewChannel},
+		PermissionOverwrite{ID: 5, Type: PermissionOverwriteTypeMember, Deny: PermissionSendMessages},
+	)
+	if got := ComputeChannelPermissions(PermissionAdministrator, channel, member); got != PermissionsAll {
+		t.Errorf("administrator channel permissions = %v, want PermissionsAll", got.Names())
+	}
+}
+
+func TestComputeChannelPermissionsOrder(t *testing.T) {
+	member := &FullMember{Member: Member{ID: 5, RoleIDs: []Snowflake{2, 3}}}
+	base := PermissionViewChannel | PermissionSendMessages | PermissionAddReactions
+	channel := testPermissionsChannel(
+		// member overwrite comes first to check it's still applied last.
+		PermissionOverwrite{ID: 5, Type: PermissionOverwriteTypeMember, Allow: PermissionAddReactions},
+		PermissionOverwrite{ID: 1, Type: PermissionOverwriteTypeRole, Deny: PermissionSendMessages | PermissionAddReactions},
+		// role 2 denies what role 3 allows: allows win over denies within the role step.
+		PermissionOverwrite{ID: 2, Type: PermissionOverwriteTypeRole, Deny: PermissionEmbedLinks},
+		PermissionOverwrite{ID: 3, Type: PermissionOverwriteTypeRole, Allow: PermissionSendMessages | PermissionEmbedLinks},
+		// role the member doesn't have.
+		PermissionOverwrite{ID: 4, Type: PermissionOverwriteTypeRole, Deny: PermissionViewChannel},
+	)
+
+	want := PermissionViewChannel | PermissionSendMessages | PermissionEmbedLinks | PermissionAddReactions
+	if got := ComputeChannelPermissions(base, channel, member); got != want {
+		t.Errorf("channel permissions = %v, want %v", got.Names(), want.Names())
+	}
+}
+
+func TestComputeChannelPermissionsEveryoneOnly(t *testing.T) {
+	member := &FullMember{Member: Member{ID: 5}}
+	base := PermissionViewChannel | PermissionSendMessages
+	channel := testPermissionsChannel(
+		PermissionOverwrite{ID: 1, Type: PermissionOverwriteTypeRole, Allow: PermissionAttachFiles, Deny: PermissionSendMessages},
+	)
+
+	want := PermissionViewChannel | PermissionAttachFiles
+	if got := ComputeChannelPermissions(base, channel, member); got != want {
+		t.Errorf("channel permissions = %v, want %v", got.Names(), want.Names())
+	}
+}
+
+func TestPermissionsHighBits(t *testing.T) {
+	var perms Permissions
+	perms.Add(PermissionManageEvents, PermissionModerateMembers, PermissionBypassSlowmode)
+
+	if !perms.Has(PermissionManageEvents, PermissionModerateMembers, PermissionBypassSlowmode) {
+		t.Errorf("expected high bits to be set, got %d", uint64(perms))
+	}
+	if perms.Has(PermissionModerateMembers, PermissionSendMessages) {
+		t.Error("Has should require every permission")
+	}
+	if uint32(perms) != 0 {
+		t.Errorf("low 32 bits = %d, want 0: high permission bits must not wrap", uint32(perms))
+	}
+
+	perms.Remove(PermissionModerateMembers)
+	if perms.Has(PermissionModerateMembers) || !perms.Has(PermissionManageEvents, PermissionBypassSlowmode) {
+		t.Errorf("unexpected permissions after Remove: %v", perms)
+	}
+
+	perms.Toggle(PermissionModerateMembers, PermissionBypassSlowmode)
+	if !perms.Is(PermissionManageEvents | PermissionModerateMembers) {
+		t.Errorf("unexpected permissions after Toggle: %v", perms)
+	}
+	if perms.Is(PermissionManageEvents) {
+		t.Error("Is should require an exact match")
+	}
+}
+
+func TestPermissionsString(t *testing.T) {
+	if got := (PermissionViewChannel | PermissionModerateMembers).String(); got != "ViewChannel, ModerateMembers" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := Permissions(0).String(); got != "None" {
+		t.Errorf("String() = %q, want None", got)
+	}
+}
+
+func TestClientMemberPermissionsFetchesRoles(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/guilds/1/roles":
+			io.WriteString(w, `[{"id":"1","permissions":"1024"},{"id":"10","permissions":"2048"}]`)
+		case "/guilds/1/members/5":
+			io.WriteString(w, `{"user":{"id":"5"},"roles":["10"]}`)
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	client.PutGuild(Guild{ID: 1, OwnerID: 99})
+
+	res := client.MemberPermissions(1, 5)
+	if res.IsErr() {
+		t.Fatalf("MemberPermissions: %v", res.Err())
+	}
+	if want := PermissionViewChannel | PermissionSendMessages; res.Value() != want {
+		t.Errorf("permissions = %v, want %v", res.Value(), want)
+	}
+
+	channel := testPermissionsChannel(PermissionOverwrite{ID: 10, Type: PermissionOverwriteTypeRole, Deny: PermissionSendMessages})
+	res = client.MemberChannelPermissions(channel, 5)
+	if res.IsErr() || res.Value() != PermissionViewChannel {
+		t.Errorf("channel permissions = %v, %v", res.Value(), res.Err())
+	}
+}
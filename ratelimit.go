@@ -0,0 +1,258 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Clock is the time source used by the requester's rate limiter.
+//
+// The default is the system clock, tests can provide a fake clock through RequesterConfig.Clock
+// to control waits without sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep blocks for the given duration, or until ctx is done in which case it returns ctx.Err().
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rateLimitBucket tracks the state of a Discord rate limit bucket.
+//
+// It's locked for the whole request, so requests sharing a bucket are sent one at a time.
+type rateLimitBucket struct {
+	// lock holds a value while the bucket is locked, a channel instead of a sync.Mutex
+	// so waiting for it can be canceled.
+	lock chan struct{}
+
+	// remaining is the number of requests left before reset, -1 while unknown.
+	remaining int
+	reset     time.Time
+
+	// users is the number of requests holding or waiting for the bucket, and lastUsed the time
+	// the last one was released. Both are guarded by rateLimiter.mu.
+	users    int
+	lastUsed time.Time
+}
+
+// bucketIdleTimeout is how long a bucket stays unused, past its reset, before it's dropped.
+const bucketIdleTimeout = 5 * time.Minute
+
+// rateLimiter waits before sending requests that would exceed Discord's rate limits,
+// using the X-RateLimit-* headers of previous responses.
+//
+// Requests are grouped by route and major parameter (channel, guild or webhook ID) until
+// Discord tells which bucket the route belongs to, routes sharing a bucket hash then share
+// their limits per major parameter.
+//
+// Reference: https://discord.com/developers/docs/topics/rate-limits
+type rateLimiter struct {
+	clock Clock
+
+	mu          sync.Mutex
+	hashes      map[string]string // route template -> bucket hash
+	buckets     map[string]*rateLimitBucket
+	globalReset time.Time
+	lastSweep   time.Time // last time idle buckets were dropped, see sweep
+}
+
+func newRateLimiter(clock Clock) *rateLimiter {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &rateLimiter{
+		clock:   clock,
+		hashes:  make(map[string]string),
+		buckets: make(map[string]*rateLimitBucket),
+	}
+}
+
+// acquire waits until a request to the route can be sent without hitting a rate limit.
+//
+// The returned bucket is locked, release must be called with the response once the request is done.
+// Returns ctx.Err(), with the bucket left unlocked, if ctx is done before then.
+func (l *rateLimiter) acquire(ctx context.Context, method, url string) (*rateLimitBucket, error) {
+	route := routeTemplate(method, url)
+	major := majorParameter(url)
+
+	l.mu.Lock()
+	now := l.clock.Now()
+	if now.Sub(l.lastSweep) >= bucketIdleTimeout {
+		l.sweep(now)
+	}
+	key := route
+	if hash, ok := l.hashes[route]; ok {
+		key = hash
+	}
+	key += ":" + major
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{lock: make(chan struct{}, 1), remaining: -1}
+		l.buckets[key] = bucket
+	}
+	bucket.users++
+	l.mu.Unlock()
+
+	select {
+	case bucket.lock <- struct{}{}:
+	case <-ctx.Done():
+		l.unuse(bucket)
+		return nil, ctx.Err()
+	}
+	if bucket.remaining == 0 {
+		if wait := bucket.reset.Sub(l.clock.Now()); wait > 0 {
+			if err := l.clock.Sleep(ctx, wait); err != nil {
+				l.unuse(bucket)
+				<-bucket.lock
+				return nil, err
+			}
+		}
+		bucket.remaining = -1
+	}
+
+	l.mu.Lock()
+	globalReset := l.globalReset
+	l.mu.Unlock()
+	if wait := globalReset.Sub(l.clock.Now()); wait > 0 {
+		if err := l.clock.Sleep(ctx, wait); err != nil {
+			l.unuse(bucket)
+			<-bucket.lock
+			return nil, err
+		}
+	}
+	return bucket, nil
+}
+
+// unuse marks a request as done with the bucket, letting sweep drop the bucket once it's idle.
+func (l *rateLimiter) unuse(bucket *rateLimitBucket) {
+	l.mu.Lock()
+	bucket.users--
+	bucket.lastUsed = l.clock.Now()
+	l.mu.Unlock()
+}
+
+// sweep drops the buckets unused for bucketIdleTimeout whose limits were reset, so the limiter
+// doesn't grow with every channel, guild or webhook touched. Route hashes are kept, there is
+// one per route template.
+//
+// l.mu must be held.
+func (l *rateLimiter) sweep(now time.Time) {
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		if bucket.users == 0 && now.Sub(bucket.lastUsed) >= bucketIdleTimeout && !now.Before(bucket.reset) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// release updates the bucket from the rate limit headers of resp and unlocks it.
+//
+// resp is nil when the request failed before getting a response.
+func (l *rateLimiter) release(method, url string, bucket *rateLimitBucket, resp *http.Response) {
+	defer func() {
+		l.unuse(bucket)
+		<-bucket.lock
+	}()
+	if resp == nil {
+		return
+	}
+	header := resp.Header
+	now := l.clock.Now()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseRateLimitSeconds(header.Get("Retry-After"))
+		if header.Get("X-RateLimit-Global") == "true" {
+			l.mu.Lock()
+			l.globalReset = now.Add(retryAfter)
+			l.mu.Unlock()
+			return
+		}
+		bucket.remaining = 0
+		bucket.reset = now.Add(retryAfter)
+		return
+	}
+
+	if hash := header.Get("X-RateLimit-Bucket"); hash != "" {
+		route := routeTemplate(method, url)
+		l.mu.Lock()
+		if l.hashes[route] != hash {
+			l.hashes[route] = hash
+			// later requests look the bucket up by its hash, carry the current state over.
+			key := hash + ":" + majorParameter(url)
+			if _, ok := l.buckets[key]; !ok {
+				l.buckets[key] = bucket
+			}
+		}
+		l.mu.Unlock()
+	}
+
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	bucket.remaining = remaining
+	bucket.reset = now.Add(parseRateLimitSeconds(header.Get("X-RateLimit-Reset-After")))
+}
+
+// parseRateLimitSeconds parses a duration in seconds with an optional fraction, like "1.337".
+func parseRateLimitSeconds(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// majorParameter returns the top-level resource of a route that Discord scopes rate limits by:
+// the channel, guild or webhook ID (with its token), or an empty string.
+func majorParameter(url string) string {
+	if i := strings.IndexByte(url, '?'); i != -1 {
+		url = url[:i]
+	}
+	segments := strings.Split(strings.TrimPrefix(url, "/"), "/")
+	if len(segments) < 2 {
+		return ""
+	}
+	switch segments[0] {
+	case "channels", "guilds":
+		return segments[1]
+	case "webhooks":
+		if len(segments) >= 3 {
+			return segments[1] + "/" + segments[2]
+		}
+		return segments[1]
+	}
+	return ""
+}
@@ -0,0 +1,288 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/marouanesouiri/stdx/xlog"
+)
+
+// Helpers
+
+// fakeClock is a Clock whose Sleep advances the time instantly and records the duration.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.advance(d)
+	return nil
+}
+
+// advance moves the time forward by d, recording it as slept.
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.slept = append(c.slept, d)
+}
+
+func (c *fakeClock) sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.slept)
+}
+
+// newRateLimitedTestRequester returns a requester using clock for its rate limiter.
+func newRateLimitedTestRequester(t *testing.T, clock Clock, handler http.HandlerFunc) *requester {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := DefaultRequesterConfig()
+	config.BaseURL = server.URL
+	config.HTTPClient = server.Client()
+	config.Clock = clock
+
+	return newRequester(config, xlog.NewTextLogger(io.Discard, xlog.LogLevelErrorLevel))
+}
+
+// Tests
+
+func TestRateLimiterWaitsForBucketReset(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	requests := 0
+	r := newRateLimitedTestRequester(t, clock, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "2.5")
+		io.WriteString(w, `{"id": "1"}`)
+	})
+
+	if res := r.FetchMessage(10, 1); res.IsErr() {
+		t.Fatalf("first request: %v", res.Err())
+	}
+	if sleeps := clock.sleeps(); len(sleeps) != 0 {
+		t.Fatalf("first request should not wait, slept %v", sleeps)
+	}
+
+	// another channel is a different major parameter, it has its own limit.
+	if res := r.FetchMessage(11, 1); res.IsErr() {
+		t.Fatalf("other channel request: %v", res.Err())
+	}
+	if sleeps := clock.sleeps(); len(sleeps) != 0 {
+		t.Fatalf("other channel should not wait, slept %v", sleeps)
+	}
+
+	if res := r.FetchMessage(10, 2); res.IsErr() {
+		t.Fatalf("second request: %v", res.Err())
+	}
+	if sleeps := clock.sleeps(); !slices.Equal(sleeps, []time.Duration{2500 * time.Millisecond}) {
+		t.Errorf("second request slept %v, want [2.5s]", sleeps)
+	}
+	if requests != 3 {
+		t.Errorf("sent %d requests, want 3", requests)
+	}
+}
+
+func TestRateLimiterHonorsRetryAfter(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	requests := 0
+	r := newRateLimitedTestRequester(t, clock, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			io.WriteString(w, `{"message": "You are being rate limited.", "retry_after": 3, "global": false}`)
+			return
+		}
+		io.WriteString(w, `{"id": "1"}`)
+	})
+
+	if res := r.FetchMessage(10, 1); res.IsErr() {
+		t.Fatalf("rate limited request should be resent: %v", res.Err())
+	}
+	if requests != 2 {
+		t.Errorf("sent %d requests, want 2", requests)
+	}
+	if sleeps := clock.sleeps(); !slices.Equal(sleeps, []time.Duration{3 * time.Second}) {
+		t.Errorf("slept %v, want [3s]", sleeps)
+	}
+}
+
+func TestRateLimiterGlobal(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	requests := 0
+	r := newRateLimitedTestRequester(t, clock, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Path == "/guilds/1/roles" && requests == 1 {
+			w.Header().Set("Retry-After", "1.5")
+			w.Header().Set("X-RateLimit-Global", "true")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, `[]`)
+	})
+
+	if res := r.FetchRoles(1); res.IsErr() {
+		t.Fatalf("FetchRoles: %v", res.Err())
+	}
+	if sleeps := clock.sleeps(); !slices.Equal(sleeps, []time.Duration{1500 * time.Millisecond}) {
+		t.Errorf("slept %v, want [1.5s]", sleeps)
+	}
+
+	// the global limit also delays requests to unrelated routes.
+	r.limiter.globalReset = clock.Now().Add(time.Second)
+	if res := r.FetchMessages(10, FetchMessagesOptions{}); res.IsErr() {
+		t.Fatalf("FetchMessages: %v", res.Err())
+	}
+	if sleeps := clock.sleeps(); len(sleeps) != 2 || sleeps[1] != time.Second {
+		t.Errorf("slept %v, want [1.5s 1s]", sleeps)
+	}
+}
+
+func TestRequestContextCancelsWaits(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	r := newRateLimitedTestRequester(t, nil, func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		switch req.URL.Path {
+		case "/channels/1/messages":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset-After", "60")
+		case "/channels/2/messages":
+			<-release
+		case "/channels/3/messages":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, `[]`)
+	})
+	r.config.MaxRetries = 3
+	r.config.BackoffBase = 10 * time.Second
+
+	// the first request to channel 1 exhausts its bucket, the one to channel 2 holds its bucket.
+	if res := r.DoRequest(Request{Method: "GET", URL: "/channels/1/messages"}); res.IsErr() {
+		t.Fatalf("DoRequest: %v", res.Err())
+	}
+	go r.DoRequest(Request{Method: "GET", URL: "/channels/2/messages"})
+	for requests.Load() != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	defer close(release)
+
+	tests := map[string]string{
+		"bucket reset": "/channels/1/messages",
+		"bucket lock":  "/channels/2/messages",
+		"backoff":      "/channels/3/messages",
+	}
+	for name, url := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			res := r.DoRequestCtx(ctx, Request{Method: "GET", URL: url})
+			if !errors.Is(res.Err(), context.DeadlineExceeded) {
+				t.Errorf("error = %v, want context.DeadlineExceeded", res.Err())
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("canceled request returned after %v", elapsed)
+			}
+		})
+	}
+
+	// the global limit is waited for before any request.
+	r.limiter.mu.Lock()
+	r.limiter.globalReset = time.Now().Add(time.Minute)
+	r.limiter.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	before := requests.Load()
+	if res := r.DoRequestCtx(ctx, Request{Method: "GET", URL: "/channels/4/messages"}); !errors.Is(res.Err(), context.DeadlineExceeded) {
+		t.Errorf("global wait: error = %v, want context.DeadlineExceeded", res.Err())
+	}
+	if requests.Load() != before {
+		t.Error("request sent with a canceled context")
+	}
+}
+
+func TestRateLimiterDropsIdleBuckets(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	r := newRateLimitedTestRequester(t, clock, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.Header().Set("X-RateLimit-Reset-After", "2")
+		io.WriteString(w, `{"id": "1"}`)
+	})
+
+	for channelID := range Snowflake(50) {
+		if res := r.FetchMessage(channelID+1, 1); res.IsErr() {
+			t.Fatalf("FetchMessage: %v", res.Err())
+		}
+	}
+	if n := len(r.limiter.buckets); n < 50 {
+		t.Fatalf("expected a bucket per channel, got %d", n)
+	}
+
+	clock.advance(bucketIdleTimeout)
+	if res := r.FetchMessage(1, 2); res.IsErr() {
+		t.Fatalf("FetchMessage: %v", res.Err())
+	}
+	if n := len(r.limiter.buckets); n > 2 {
+		t.Errorf("idle buckets were not dropped, %d left", n)
+	}
+
+	if bucket := r.limiter.buckets["abcd:1"]; bucket == nil || bucket.remaining != 4 {
+		t.Errorf("bucket of the last request = %+v, want it tracked", bucket)
+	}
+}
+
+func TestMajorParameter(t *testing.T) {
+	tests := map[string]string{
+		"/channels/10/messages/1":        "10",
+		"/guilds/1/members?limit=5":      "1",
+		"/webhooks/5/token/messages/1":   "5/token",
+		"/users/@me":                     "",
+		"/interactions/1/token/callback": "",
+	}
+	for url, want := range tests {
+		if got := majorParameter(url); got != want {
+			t.Errorf("majorParameter(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
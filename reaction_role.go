@@ -0,0 +1,247 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ReactionRoleMode defines how a ReactionRoleManager reacts to reactions on a message.
+type ReactionRoleMode int
+
+const (
+	// ReactionRoleModeToggle gives the role when the reaction is added and takes it back when the reaction is removed.
+	ReactionRoleModeToggle ReactionRoleMode = iota
+
+	// ReactionRoleModeAddOnly gives the role when the reaction is added and keeps it when the reaction is removed.
+	ReactionRoleModeAddOnly
+
+	// ReactionRoleModeUnique lets a member hold only one role of the message at a time, reacting with
+	// another emoji swaps the role. Removing the reaction takes the role back.
+	ReactionRoleModeUnique
+)
+
+// reactionRoleMessage holds the reaction roles bound to a message.
+type reactionRoleMessage struct {
+	guildID Snowflake
+	mode    ReactionRoleMode
+	roles   map[string]Snowflake // reaction emoji key -> role ID
+}
+
+// ReactionRoleManager gives and takes roles when members react to messages.
+//
+// Role changes are checked against the cached roles of the bot: roles the bot can't manage,
+// because it lacks PermissionManageRoles or the role isn't below its highest role, are skipped.
+// Changes of the same member are applied one at a time and written through to the cached member,
+// so quick successive reactions don't overwrite each other.
+//
+// Usage example:
+//
+//	manager := dwaz.NewReactionRoleManager(client)
+//	manager.Bind(guildID, messageID, dwaz.ReactionRoleModeUnique, map[string]dwaz.Snowflake{
+//		"🔴":                        redRoleID,
+//		"<:blue:123456789012345678>": blueRoleID,
+//	})
+type ReactionRoleManager struct {
+	client *Client
+
+	mu       sync.RWMutex
+	messages map[Snowflake]*reactionRoleMessage
+
+	// memberLocks serializes the role changes of a member, indexed by user ID.
+	memberLocks [64]sync.Mutex
+}
+
+// NewReactionRoleManager creates a ReactionRoleManager and registers its reaction handlers on the client.
+//
+// Note:
+//   - Call it before Client.Start, registering handlers is not thread-safe.
+//   - Requires the GatewayIntentGuildMessageReactions intent.
+func NewReactionRoleManager(client *Client) *ReactionRoleManager {
+	m := &ReactionRoleManager{
+		client:   client,
+		messages: make(map[Snowflake]*reactionRoleMessage),
+	}
+	client.OnMessageReactionAdd(m.handleReactionAdd)
+	client.OnMessageReactionRemove(m.handleReactionRemove)
+	return m
+}
+
+// Bind sets the reaction roles of a message, replacing any previous binding of the message.
+//
+// The keys of roles are unicode emojis, custom emoji mentions ("<:name:id>") or "name:id".
+func (m *ReactionRoleManager) Bind(guildID, messageID Snowflake, mode ReactionRoleMode, roles map[string]Snowflake) {
+	binding := &reactionRoleMessage{
+		guildID: guildID,
+		mode:    mode,
+		roles:   make(map[string]Snowflake, len(roles)),
+	}
+	for emoji, roleID := range roles {
+		binding.roles[reactionRoleKey(emoji)] = roleID
+	}
+
+	m.mu.Lock()
+	m.messages[messageID] = binding
+	m.mu.Unlock()
+}
+
+// Unbind removes the reaction roles of a message. Roles already given are kept.
+func (m *ReactionRoleManager) Unbind(messageID Snowflake) {
+	m.mu.Lock()
+	delete(m.messages, messageID)
+	m.mu.Unlock()
+}
+
+// reactionRoleKey normalizes a reaction emoji to the ID of custom emojis, or the unicode emoji itself.
+func reactionRoleKey(emoji string) string {
+	emoji = strings.TrimSuffix(strings.TrimPrefix(emoji, "<"), ">")
+	if i := strings.LastIndexByte(emoji, ':'); i != -1 {
+		return emoji[i+1:]
+	}
+	return emoji
+}
+
+// emojiRoleKey returns the key of a received reaction emoji, see reactionRoleKey.
+func emojiRoleKey(emoji Emoji) string {
+	if emoji.ID != 0 {
+		return emoji.ID.String()
+	}
+	return emoji.Name
+}
+
+// lookup returns the binding of a message and the role bound to emoji, if any.
+func (m *ReactionRoleManager) lookup(messageID Snowflake, emoji Emoji) (*reactionRoleMessage, Snowflake, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	binding, ok := m.messages[messageID]
+	if !ok {
+		return nil, 0, false
+	}
+	roleID, ok := binding.roles[emojiRoleKey(emoji)]
+	return binding, roleID, ok
+}
+
+func (m *ReactionRoleManager) handleReactionAdd(evt MessageReactionAddEvent) {
+	if evt.UserID == m.client.SelfID() {
+		return
+	}
+	binding, roleID, ok := m.lookup(evt.MessageID, evt.Emoji)
+	if !ok || evt.GuildID != binding.guildID {
+		return
+	}
+	if err := m.checkManageable(binding.guildID, roleID); err != nil {
+		m.client.Logger.WithField("role_id", roleID).Warn("ReactionRoleManager: " + err.Error())
+		return
+	}
+
+	lock := &m.memberLocks[evt.UserID%Snowflake(len(m.memberLocks))]
+	lock.Lock()
+	defer lock.Unlock()
+
+	var err error
+	if binding.mode == ReactionRoleModeUnique {
+		err = m.swapRole(binding, evt.UserID, roleID)
+	} else {
+		err = m.client.AddMemberRole(binding.guildID, evt.UserID, roleID, AddMemberRoleOptions{Reason: "Reaction role"}).Err()
+	}
+	if err != nil {
+		m.client.Logger.WithFields(map[string]any{
+			"user_id": evt.UserID,
+			"role_id": roleID,
+			"error":   err.Error(),
+		}).Error("ReactionRoleManager: failed adding role")
+	}
+}
+
+func (m *ReactionRoleManager) handleReactionRemove(evt MessageReactionRemoveEvent) {
+	if evt.UserID == m.client.SelfID() {
+		return
+	}
+	binding, roleID, ok := m.lookup(evt.MessageID, evt.Emoji)
+	if !ok || evt.GuildID != binding.guildID || binding.mode == ReactionRoleModeAddOnly {
+		return
+	}
+	if err := m.checkManageable(binding.guildID, roleID); err != nil {
+		m.client.Logger.WithField("role_id", roleID).Warn("ReactionRoleManager: " + err.Error())
+		return
+	}
+
+	lock := &m.memberLocks[evt.UserID%Snowflake(len(m.memberLocks))]
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := m.client.RemoveMemberRole(binding.guildID, evt.UserID, roleID, RemoveMemberRoleOptions{Reason: "Reaction role"}).Err(); err != nil {
+		m.client.Logger.WithFields(map[string]any{
+			"user_id": evt.UserID,
+			"role_id": roleID,
+			"error":   err.Error(),
+		}).Error("ReactionRoleManager: failed removing role")
+	}
+}
+
+// swapRole gives roleID to the member and takes back the other roles of the binding, in a single request.
+//
+// The member's roles are read from the cache, falling back to FetchMember.
+func (m *ReactionRoleManager) swapRole(binding *reactionRoleMessage, userID, roleID Snowflake) error {
+	current := m.client.memberRoleIDs(binding.guildID, userID)
+	if current.IsErr() {
+		return current.Err()
+	}
+
+	others := make([]Snowflake, 0, len(binding.roles))
+	for _, id := range binding.roles {
+		if id != roleID {
+			others = append(others, id)
+		}
+	}
+	roles := addRoleIDs(removeRoleIDs(current.Value(), others), []Snowflake{roleID})
+	if len(roles) == len(current.Value()) && slices.Contains(current.Value(), roleID) {
+		return nil
+	}
+	return m.client.SetMemberRoles(binding.guildID, userID, roles, "Reaction role").Err()
+}
+
+// checkManageable returns an error if the cached roles show the bot can't give roleID.
+//
+// Returns nil when the bot member or its roles aren't cached, leaving the check to Discord.
+func (m *ReactionRoleManager) checkManageable(guildID, roleID Snowflake) error {
+	self, err := m.client.GetMember(guildID, m.client.SelfID()).GetErr()
+	if err != nil {
+		return nil
+	}
+	roles := m.client.GetRoles(append([]Snowflake{guildID, roleID}, self.RoleIDs...)...)
+	role, ok := roles[roleID]
+	if !ok {
+		return nil
+	}
+
+	permissions := roles[guildID].Permissions
+	highest := 0
+	for _, id := range self.RoleIDs {
+		selfRole := roles[id]
+		permissions |= selfRole.Permissions
+		highest = max(highest, selfRole.Position)
+	}
+	if !permissions.Has(PermissionAdministrator) && !permissions.Has(PermissionManageRoles) {
+		return fmt.Errorf("missing the ManageRoles permission to give role %s", roleID)
+	}
+	if role.Position >= highest {
+		return fmt.Errorf("role %s is not below the bot's highest role", roleID)
+	}
+	return nil
+}
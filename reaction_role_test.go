@@ -0,0 +1,169 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestReactionRoleManagerToggle(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client.selfID.Store(100)
+
+	manager := NewReactionRoleManager(client)
+	manager.Bind(1, 20, ReactionRoleModeToggle, map[string]Snowflake{
+		"👍":           30,
+		"<:party:99>": 31,
+	})
+
+	add := client.handlersManagers["MESSAGE_REACTION_ADD"]
+	remove := client.handlersManagers["MESSAGE_REACTION_REMOVE"]
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"id":"99","name":"party"}}`))
+	remove.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+
+	// ignored: bot's own reaction, unbound emoji and unbound message.
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"100","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"name":"🔥"}}`))
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"21","emoji":{"name":"👍"}}`))
+
+	manager.Unbind(20)
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+
+	want := []string{
+		"PUT /guilds/1/members/5/roles/30",
+		"PUT /guilds/1/members/5/roles/31",
+		"DELETE /guilds/1/members/5/roles/30",
+	}
+	if !slices.Equal(requests, want) {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+}
+
+func TestReactionRoleManagerUnique(t *testing.T) {
+	var sentRoles [][]Snowflake
+	var deleted []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case "GET":
+			w.Write([]byte(`{"user": {"id": "5"}, "roles": ["7", "30"]}`))
+		case "PATCH":
+			var body struct {
+				Roles []Snowflake `json:"roles"`
+			}
+			buf, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(buf, &body); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			sentRoles = append(sentRoles, body.Roles)
+			w.Write([]byte(`{"user": {"id": "5"}}`))
+		case "DELETE":
+			deleted = append(deleted, req.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	client.selfID.Store(100)
+
+	manager := NewReactionRoleManager(client)
+	manager.Bind(1, 20, ReactionRoleModeUnique, map[string]Snowflake{
+		"🔴":       30,
+		"blue:99": 31,
+	})
+
+	add := client.handlersManagers["MESSAGE_REACTION_ADD"]
+	remove := client.handlersManagers["MESSAGE_REACTION_REMOVE"]
+
+	// member isn't cached: roles are fetched, 30 is swapped for 31.
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"id":"99","name":"blue"}}`))
+	if len(sentRoles) != 1 || !slices.Equal(sentRoles[0], []Snowflake{7, 31}) {
+		t.Fatalf("unexpected roles after swap: %v", sentRoles)
+	}
+
+	// cached member already holding only the picked role: nothing to do.
+	client.PutMember(Member{ID: 5, GuildID: 1, RoleIDs: []Snowflake{7, 31}})
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"id":"99","name":"blue"}}`))
+	if len(sentRoles) != 1 {
+		t.Errorf("expected no request for an already held role, got %v", sentRoles[1:])
+	}
+
+	remove.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"id":"99","name":"blue"}}`))
+	if !slices.Equal(deleted, []string{"/guilds/1/members/5/roles/31"}) {
+		t.Errorf("unexpected removals: %v", deleted)
+	}
+}
+
+func TestReactionRoleManagerSkipsUnmanageableRole(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	})
+	client.selfID.Store(100)
+	client.PutMember(Member{ID: 100, GuildID: 1, RoleIDs: []Snowflake{40}})
+	client.PutRole(Role{ID: 1, GuildID: 1})
+	client.PutRole(Role{ID: 40, GuildID: 1, Position: 2, Permissions: PermissionManageRoles})
+	client.PutRole(Role{ID: 30, GuildID: 1, Position: 5})
+
+	manager := NewReactionRoleManager(client)
+	manager.Bind(1, 20, ReactionRoleModeToggle, map[string]Snowflake{"👍": 30})
+	client.handlersManagers["MESSAGE_REACTION_ADD"].handleEvent(client, false, 0,
+		[]byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+}
+
+func TestReactionRoleManagerSwapKeepsToggledRole(t *testing.T) {
+	var sentRoles [][]Snowflake
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case "PUT":
+			w.WriteHeader(http.StatusNoContent)
+		case "PATCH":
+			var body struct {
+				Roles []Snowflake `json:"roles"`
+			}
+			buf, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(buf, &body); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			sentRoles = append(sentRoles, body.Roles)
+			roles, _ := json.Marshal(body.Roles)
+			w.Write([]byte(`{"user": {"id": "5"}, "roles": ` + string(roles) + `}`))
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	client.selfID.Store(100)
+	client.PutMember(Member{ID: 5, GuildID: 1, RoleIDs: []Snowflake{7}})
+
+	manager := NewReactionRoleManager(client)
+	manager.Bind(1, 20, ReactionRoleModeToggle, map[string]Snowflake{"👍": 40})
+	manager.Bind(1, 21, ReactionRoleModeUnique, map[string]Snowflake{"🔴": 30, "🔵": 31})
+
+	add := client.handlersManagers["MESSAGE_REACTION_ADD"]
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"20","emoji":{"name":"👍"}}`))
+	add.handleEvent(client, false, 0, []byte(`{"user_id":"5","guild_id":"1","channel_id":"10","message_id":"21","emoji":{"name":"🔴"}}`))
+
+	if len(sentRoles) != 1 || !slices.Equal(sentRoles[0], []Snowflake{7, 40, 30}) {
+		t.Errorf("swap dropped the toggled role: %v", sentRoles)
+	}
+}
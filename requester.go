@@ -2,9 +2,16 @@ package dwaz
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"slices"
 	"time"
 
 	"github.com/marouanesouiri/stdx/result"
@@ -20,6 +27,9 @@ const (
 	defaultBaseURL   = "https://discord.com/api/" + apiVersion
 	defaultUserAgent = "DiscordBot (dwaz, v0.0.1)"
 	headerReason     = "X-Audit-Log-Reason"
+
+	// maxRateLimitRetries is how many times a rate limited request is resent after waiting.
+	maxRateLimitRetries = 3
 )
 
 /***********************
@@ -38,21 +48,54 @@ type RequesterConfig struct {
 	// UserAgent is the User-Agent header value.
 	UserAgent string
 
-	// MaxRetries is the maximum number of retries for failed requests.
+	// MaxRetries is the maximum number of retries for requests failing with a network error
+	// or a 5xx status. Rate limited (429) requests are resent after waiting regardless.
 	MaxRetries int
 
+	// BackoffBase is the delay before the first retry, doubled on each following retry
+	// with random jitter. Defaults to 500 milliseconds.
+	BackoffBase time.Duration
+
+	// MaxBackoff caps the delay between retries. Defaults to 10 seconds.
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent allows retrying POST requests after a network error or a 5xx status.
+	//
+	// Note:
+	//   - Off by default: the failed POST may have been processed by Discord,
+	//     retrying it could for example send a message twice.
+	RetryNonIdempotent bool
+
 	// Token is the Bot token.
 	Token string
 
 	// HTTPClient is a custom HTTP client.
 	HTTPClient *http.Client
+
+	// DisableRateLimiter turns off the built-in rate limiter, for example when
+	// requests go through a proxy that already handles Discord's rate limits.
+	DisableRateLimiter bool
+
+	// Clock is the time source of the rate limiter, retry backoff, settings cache and message purges.
+	// Defaults to the system clock.
+	Clock Clock
+
+	// SettingsCacheTTL is how long the guild onboarding, welcome screen and widget settings
+	// are cached after being fetched. Modifying them through the library invalidates the cache.
+	// Defaults to 60 seconds, a negative value disables the cache.
+	SettingsCacheTTL time.Duration
 }
 
 func DefaultRequesterConfig() RequesterConfig {
 	return RequesterConfig{
-		BaseURL:    defaultBaseURL,
-		APIVersion: apiVersion,
-		UserAgent:  defaultUserAgent,
+		BaseURL:     defaultBaseURL,
+		APIVersion:  apiVersion,
+		UserAgent:   defaultUserAgent,
+		MaxRetries:  3,
+		BackoffBase: 500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		// short enough that changes made outside the bot show up quickly.
+		SettingsCacheTTL: 60 * time.Second,
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 			Transport: &http.Transport{
@@ -75,16 +118,60 @@ func DefaultRequesterConfig() RequesterConfig {
 
 // requester handles HTTP requests with basic retry logic, intended to be used with a proxy.
 type requester struct {
-	config RequesterConfig
-	logger xlog.Logger
+	config  RequesterConfig
+	logger  xlog.Logger
+	stats   routeStats
+	clock   Clock
+	limiter *rateLimiter // nil if disabled
+
+	// guild settings caches, nil if disabled.
+	onboardingCache     *ttlCache[Snowflake, GuildOnboarding]
+	welcomeScreenCache  *ttlCache[Snowflake, GuildWelcomeScreen]
+	widgetSettingsCache *ttlCache[Snowflake, GuildWidgetSettings]
 }
 
 // newRequester creates a new Requester with the given config.
+//
+// Zero fields of config are set to the values of DefaultRequesterConfig,
+// except MaxRetries where 0 disables retries.
 func newRequester(config RequesterConfig, logger xlog.Logger) *requester {
-	return &requester{
+	defaults := DefaultRequesterConfig()
+	if config.BaseURL == "" {
+		config.BaseURL = defaults.BaseURL
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = defaults.UserAgent
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = defaults.HTTPClient
+	}
+	if config.BackoffBase <= 0 {
+		config.BackoffBase = defaults.BackoffBase
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.Clock == nil {
+		config.Clock = systemClock{}
+	}
+	if config.SettingsCacheTTL == 0 {
+		config.SettingsCacheTTL = defaults.SettingsCacheTTL
+	}
+
+	r := &requester{
 		config: config,
 		logger: logger,
+		clock:  config.Clock,
 	}
+	if !config.DisableRateLimiter {
+		r.limiter = newRateLimiter(config.Clock)
+	}
+	if config.SettingsCacheTTL > 0 {
+		r.onboardingCache = newTTLCache[Snowflake, GuildOnboarding](config.Clock, config.SettingsCacheTTL)
+		r.welcomeScreenCache = newTTLCache[Snowflake, GuildWelcomeScreen](config.Clock, config.SettingsCacheTTL)
+		r.widgetSettingsCache = newTTLCache[Snowflake, GuildWidgetSettings](config.Clock, config.SettingsCacheTTL)
+	}
+	return r
 }
 
 // Shutdown gracefully closes the underlying HTTP client's idle connections.
@@ -98,13 +185,35 @@ func (r *requester) Shutdown() {
 
 func isRetryableStatus(code int) bool {
 	switch code {
-	case 429, 502, 503, 504:
+	case 500, 502, 503, 504:
 		return true
 	default:
 		return false
 	}
 }
 
+// canRetry reports whether a request that failed with a network error or a 5xx status
+// can be sent again, which is the case of every method but POST unless RetryNonIdempotent is set.
+func (r *requester) canRetry(req Request, attempt int) bool {
+	if attempt >= r.config.MaxRetries {
+		return false
+	}
+	return req.Method != http.MethodPost || r.config.RetryNonIdempotent
+}
+
+// backoff waits before retry number attempt (starting at 1): BackoffBase doubled for each
+// previous retry, capped to MaxBackoff, with a random jitter of up to half the delay.
+//
+// Returns ctx.Err() if ctx is done before the delay is over.
+func (r *requester) backoff(ctx context.Context, attempt int) error {
+	delay := r.config.BackoffBase << (attempt - 1)
+	if delay <= 0 || delay > r.config.MaxBackoff {
+		delay = r.config.MaxBackoff
+	}
+	delay = delay/2 + rand.N(delay/2+1)
+	return r.clock.Sleep(ctx, delay)
+}
+
 // Request represents an HTTP request to be executed by the requester.
 type Request struct {
 	// Body is the raw JSON request body or other data sent to the API.
@@ -115,69 +224,296 @@ type Request struct {
 	URL string
 	// Reason is the value for the "X-Audit-Log-Reason" header, useful for audit logs.
 	Reason string
+	// ContentType overrides the "Content-Type" header, defaults to "application/json" if empty.
+	ContentType string
 	// NoAuth indicates whether to skip token-based authentication for this request.
 	// If false (default), the "Authorization" header will be automatically set using the bot token.
 	NoAuth bool
+	// Context controls the lifetime of the request, defaults to context.Background() if nil.
+	// Canceling it aborts the in-flight HTTP call, any rate limit wait and any pending retry.
+	Context context.Context
+}
+
+// RestError is returned by DoRequest when Discord responds with a non-2xx status.
+//
+// Reference: https://discord.com/developers/docs/reference#error-messages
+type RestError struct {
+	// Method is the HTTP verb of the failed request.
+	Method string `json:"-"`
+	// URL is the endpoint path of the failed request, with webhook and interaction tokens
+	// replaced by "{token}".
+	URL string `json:"-"`
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"-"`
+	// Code is the Discord JSON error code, 0 if the response had no JSON body.
+	//
+	// Reference: https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+	Code int `json:"code"`
+	// Message is the human readable error message sent by Discord.
+	Message string `json:"message"`
+	// Errors is the raw per-field error tree sent with validation errors like "Invalid Form Body",
+	// nil if absent. Use FieldErrors to read it.
+	Errors json.RawMessage `json:"errors,omitempty"`
+}
+
+// RestFieldError is a validation error of a single request field.
+type RestFieldError struct {
+	// Code is the Discord error code of the field, like "BASE_TYPE_REQUIRED".
+	Code string `json:"code"`
+	// Message is the human readable error message of the field.
+	Message string `json:"message"`
+}
+
+// Discord JSON error codes checked by the library.
+//
+// Reference: https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+const (
+	// RestErrorCodeCannotSendMessagesToUser is returned when a DM can't be sent to a user,
+	// usually because they disabled DMs from server members or blocked the bot.
+	RestErrorCodeCannotSendMessagesToUser = 50007
+
+	// RestErrorCodeMissingPermissions is returned when the bot lacks a permission required by the request.
+	RestErrorCodeMissingPermissions = 50013
+)
+
+// Error implements the error interface.
+func (e *RestError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("request failed with status %d", e.StatusCode)
+	}
+	msg := fmt.Sprintf("request failed with status %d: %s (code %d)", e.StatusCode, e.Message, e.Code)
+
+	fields := e.FieldErrors()
+	paths := slices.Sorted(maps.Keys(fields))
+	for _, path := range paths {
+		for _, fieldErr := range fields[path] {
+			msg += "; " + path + ": " + fieldErr.Message
+		}
+	}
+	return msg
+}
+
+// Is reports whether target is a *RestError matching e. Zero StatusCode and Code fields
+// of target match any value, so errors.Is(err, &RestError{StatusCode: 404}) matches all 404s.
+func (e *RestError) Is(target error) bool {
+	t, ok := target.(*RestError)
+	if !ok {
+		return false
+	}
+	return (t.StatusCode == 0 || t.StatusCode == e.StatusCode) && (t.Code == 0 || t.Code == e.Code)
+}
+
+// FieldErrors flattens the per-field error tree into a map keyed by the dotted path
+// of each field, like "embeds.0.title". Returns nil if the error has no field errors.
+//
+// Reference: https://discord.com/developers/docs/reference#error-messages
+func (e *RestError) FieldErrors() map[string][]RestFieldError {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	var tree map[string]json.RawMessage
+	if err := json.Unmarshal(e.Errors, &tree); err != nil {
+		return nil
+	}
+	fields := make(map[string][]RestFieldError)
+	collectFieldErrors(fields, "", tree)
+	return fields
+}
+
+// collectFieldErrors walks a node of the error tree, adding the "_errors" lists it finds under their path.
+func collectFieldErrors(fields map[string][]RestFieldError, path string, node map[string]json.RawMessage) {
+	for key, raw := range node {
+		if key == "_errors" {
+			var errs []RestFieldError
+			if json.Unmarshal(raw, &errs) == nil && len(errs) > 0 {
+				fields[path] = append(fields[path], errs...)
+			}
+			continue
+		}
+		var child map[string]json.RawMessage
+		if json.Unmarshal(raw, &child) != nil {
+			continue
+		}
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		collectFieldErrors(fields, childPath, child)
+	}
+}
+
+// IsNotFound reports whether err is a RestError with the 404 Not Found status.
+func IsNotFound(err error) bool {
+	return errors.Is(err, &RestError{StatusCode: http.StatusNotFound})
+}
+
+// IsForbidden reports whether err is a RestError with the 403 Forbidden status,
+// returned when the bot lacks the permissions required by the request.
+func IsForbidden(err error) bool {
+	return errors.Is(err, &RestError{StatusCode: http.StatusForbidden})
+}
+
+// IsRateLimited reports whether err is a RestError with the 429 Too Many Requests status,
+// returned when a request stays rate limited after being resent.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, &RestError{StatusCode: http.StatusTooManyRequests})
+}
+
+// newRestError builds a RestError from a failed response, decoding the Discord error body if present.
+func newRestError(req Request, resp *http.Response) *RestError {
+	restErr := &RestError{Method: req.Method, URL: redactURL(req.URL), StatusCode: resp.StatusCode}
+	json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(restErr)
+	return restErr
+}
+
+// Response is a successful response returned by DoRequestFull.
+type Response struct {
+	// Body is the response body. The caller is responsible for closing it.
+	Body io.ReadCloser
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Header holds the response headers, like the "X-RateLimit-*" headers.
+	Header http.Header
 }
 
 // DoRequest executes a request with a raw body and returns the response body as a stream.
 // The caller is responsible for closing the body.
+//
+// Failed requests are retried following the retry policy of RequesterConfig, resending the same body.
+// If req.Context is canceled, the request is aborted and the context error is returned.
 func (r *requester) DoRequest(req Request) result.Result[io.ReadCloser] {
+	res := r.DoRequestFull(req)
+	if res.IsErr() {
+		return result.Err[io.ReadCloser](res.Err())
+	}
+	return result.Ok(res.Value().Body)
+}
+
+// DoRequestFull is like DoRequest but also returns the status code and headers of the response,
+// which is useful to inspect rate limits. The caller is responsible for closing the body.
+func (r *requester) DoRequestFull(req Request) result.Result[Response] {
 	fullURL := r.config.BaseURL + req.URL
+	// route is logged instead of the URL, which may contain a webhook or interaction token.
+	route := routeTemplate(req.Method, req.URL)
+	ctx := req.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
-	for i := 0; i <= r.config.MaxRetries; i++ {
-		if i > 0 {
-			r.logger.WithFields(map[string]any{
-				"method":  req.Method,
-				"url":     fullURL,
-				"attempt": i + 1,
-			}).Debug("retrying request")
-			time.Sleep(100 * time.Millisecond)
+	retries, rateLimited := 0, 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return result.Err[Response](err)
 		}
 
-		httpRequest, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+		httpRequest, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 		if err != nil {
-			return result.Err[io.ReadCloser](fmt.Errorf("failed creating request: %v", err))
+			return result.Err[Response](fmt.Errorf("failed creating request: %v", err))
 		}
 
 		if !req.NoAuth {
 			httpRequest.Header.Set("Authorization", r.config.Token)
 		}
 		httpRequest.Header.Set("User-Agent", r.config.UserAgent)
-		httpRequest.Header.Set("Content-Type", "application/json")
+		contentType := req.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		httpRequest.Header.Set("Content-Type", contentType)
 		httpRequest.Header.Set("Accept", "application/json")
 
 		if req.Reason != "" {
 			httpRequest.Header.Set(headerReason, req.Reason)
 		}
 
+		var bucket *rateLimitBucket
+		if r.limiter != nil {
+			if bucket, err = r.limiter.acquire(ctx, req.Method, req.URL); err != nil {
+				return result.Err[Response](err)
+			}
+		}
+
+		start := time.Now()
 		resp, err := r.config.HTTPClient.Do(httpRequest)
+		if bucket != nil {
+			r.limiter.release(req.Method, req.URL, bucket, resp)
+		}
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return result.Err[Response](ctxErr)
+			}
+			// *url.Error includes the full URL, keep only the underlying error.
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
 			r.logger.WithFields(map[string]any{
-				"method":   req.Method,
-				"endpoint": req.URL,
-				"error":    err,
+				"route": route,
+				"error": err,
 			}).Warn("request network error")
+			if !r.canRetry(req, retries) {
+				return result.Err[Response](fmt.Errorf("request %s failed: %w", route, err))
+			}
+			retries++
+			if err := r.retry(ctx, route, retries); err != nil {
+				return result.Err[Response](err)
+			}
 			continue
 		}
 
+		r.stats.record(route, time.Since(start), resp.StatusCode)
+
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return result.Ok(resp.Body)
+			return result.Ok(Response{Body: resp.Body, StatusCode: resp.StatusCode, Header: resp.Header})
+		}
+
+		// Discord didn't process a rate limited request, it's always safe to resend it.
+		if resp.StatusCode == http.StatusTooManyRequests && rateLimited < maxRateLimitRetries {
+			resp.Body.Close()
+			rateLimited++
+			r.logger.WithFields(map[string]any{
+				"route":  route,
+				"global": resp.Header.Get("X-RateLimit-Global") == "true",
+			}).Warn("request rate limited")
+			// the limiter waits for Retry-After on the next attempt.
+			if r.limiter == nil {
+				if err := r.clock.Sleep(ctx, parseRateLimitSeconds(resp.Header.Get("Retry-After"))); err != nil {
+					return result.Err[Response](err)
+				}
+			}
+			continue
 		}
 
-		if isRetryableStatus(resp.StatusCode) {
+		if isRetryableStatus(resp.StatusCode) && r.canRetry(req, retries) {
 			resp.Body.Close()
 			r.logger.WithFields(map[string]any{
-				"method":   req.Method,
-				"endpoint": req.URL,
-				"status":   resp.StatusCode,
+				"route":  route,
+				"status": resp.StatusCode,
 			}).Warn("request failed with retryable status")
+			retries++
+			if err := r.retry(ctx, route, retries); err != nil {
+				return result.Err[Response](err)
+			}
 			continue
 		}
 
+		restErr := newRestError(req, resp)
 		resp.Body.Close()
-		return result.Err[io.ReadCloser](fmt.Errorf("request failed with status %d", resp.StatusCode))
+		return result.Err[Response](restErr)
 	}
+}
+
+// DoRequestCtx is like DoRequest but runs the request under ctx, overriding req.Context.
+func (r *requester) DoRequestCtx(ctx context.Context, req Request) result.Result[io.ReadCloser] {
+	req.Context = ctx
+	return r.DoRequest(req)
+}
 
-	return result.Err[io.ReadCloser](fmt.Errorf("max retries reached, endpoint %s", req.URL))
+// retry logs and waits before retry number attempt, see backoff.
+func (r *requester) retry(ctx context.Context, route string, attempt int) error {
+	r.logger.WithFields(map[string]any{
+		"route":   route,
+		"attempt": attempt + 1,
+	}).Debug("retrying request")
+	return r.backoff(ctx, attempt)
 }
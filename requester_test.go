@@ -0,0 +1,458 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marouanesouiri/stdx/xlog"
+)
+
+// Helpers
+
+// newTestRequester returns a requester that sends all requests to an httptest server using handler.
+func newTestRequester(t *testing.T, handler http.HandlerFunc) *requester {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := DefaultRequesterConfig()
+	config.BaseURL = server.URL
+	config.HTTPClient = server.Client()
+
+	return newRequester(config, xlog.NewTextLogger(io.Discard, xlog.LogLevelErrorLevel))
+}
+
+// newTestClient returns a client with an in memory cache whose requester sends all
+// requests to an httptest server using handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	r := newTestRequester(t, handler)
+	client := &Client{
+		ctx:          context.Background(),
+		Logger:       r.logger,
+		requester:    r,
+		CacheManager: NewInMemoryCacheManager(CacheFlagsAll),
+	}
+	client.dispatcher = newDispatcher(client.Logger, client, HandlerExecutionSync)
+	return client
+}
+
+// roundTripFunc is an http.RoundTripper calling itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newMockTransportRequester returns a requester whose HTTP client sends requests to transport,
+// using clock for rate limits and retry backoff.
+func newMockTransportRequester(config RequesterConfig, clock Clock, transport roundTripFunc) *requester {
+	config.HTTPClient = &http.Client{Transport: transport}
+	config.Clock = clock
+	return newRequester(config, xlog.NewTextLogger(io.Discard, xlog.LogLevelErrorLevel))
+}
+
+// mockResponse returns a response with the given status and body.
+func mockResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// Tests
+
+func TestDoRequestHeaders(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if got := req.Header.Get(headerReason); got != "cleanup" {
+			t.Errorf("expected reason header 'cleanup', got %q", got)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bot token" {
+			t.Errorf("expected authorization header 'Bot token', got %q", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.config.Token = "Bot token"
+
+	res := r.DoRequest(Request{Method: "DELETE", URL: "/channels/1", Reason: "cleanup"})
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	res.Value().Close()
+}
+
+func TestDoRequestRestError(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"code":50013,"message":"Missing Permissions"}`)
+	})
+
+	res := r.DoRequest(Request{Method: "GET", URL: "/channels/1"})
+	if !res.IsErr() {
+		t.Fatal("expected an error")
+	}
+	var restErr *RestError
+	if !errors.As(res.Err(), &restErr) {
+		t.Fatalf("expected a *RestError, got %T", res.Err())
+	}
+	if restErr.StatusCode != http.StatusForbidden || restErr.Code != 50013 || restErr.Message != "Missing Permissions" {
+		t.Errorf("unexpected error %+v", restErr)
+	}
+	if restErr.Method != "GET" || restErr.URL != "/channels/1" {
+		t.Errorf("unexpected request info %+v", restErr)
+	}
+}
+
+func TestDoRequestRetriesWithBackoff(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	var attempts int
+	var bodies []string
+	r := newMockTransportRequester(DefaultRequesterConfig(), clock, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		buf, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(buf))
+		if attempts <= 2 {
+			return mockResponse(http.StatusServiceUnavailable, ""), nil
+		}
+		return mockResponse(http.StatusOK, `{}`), nil
+	})
+
+	res := r.DoRequest(Request{Method: "PATCH", URL: "/channels/1", Body: []byte(`{"name":"a"}`)})
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	res.Value().Close()
+
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+	for i, body := range bodies {
+		if body != `{"name":"a"}` {
+			t.Errorf("attempt %d sent body %q", i+1, body)
+		}
+	}
+
+	// jittered exponential backoff: [base/2, base] then [base, 2*base].
+	sleeps := clock.sleeps()
+	base := DefaultRequesterConfig().BackoffBase
+	if len(sleeps) != 2 || sleeps[0] < base/2 || sleeps[0] > base || sleeps[1] < base || sleeps[1] > 2*base {
+		t.Errorf("backoff sleeps = %v", sleeps)
+	}
+}
+
+func TestDoRequestRetryLimit(t *testing.T) {
+	config := DefaultRequesterConfig()
+	config.MaxRetries = 2
+	attempts := 0
+	r := newMockTransportRequester(config, &fakeClock{}, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return mockResponse(http.StatusBadGateway, ""), nil
+	})
+
+	res := r.DoRequest(Request{Method: "GET", URL: "/channels/1"})
+	var restErr *RestError
+	if !errors.As(res.Err(), &restErr) || restErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected the last 502 as a RestError, got %v", res.Err())
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestDoRequestDoesNotRetryPost(t *testing.T) {
+	attempts := 0
+	transport := func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return mockResponse(http.StatusServiceUnavailable, ""), nil
+	}
+
+	r := newMockTransportRequester(DefaultRequesterConfig(), &fakeClock{}, transport)
+	if res := r.DoRequest(Request{Method: "POST", URL: "/channels/1/messages"}); !res.IsErr() {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("POST attempts = %d, want 1", attempts)
+	}
+
+	config := DefaultRequesterConfig()
+	config.RetryNonIdempotent = true
+	attempts = 0
+	r = newMockTransportRequester(config, &fakeClock{}, transport)
+	r.DoRequest(Request{Method: "POST", URL: "/channels/1/messages"})
+	if attempts != config.MaxRetries+1 {
+		t.Errorf("POST attempts with RetryNonIdempotent = %d, want %d", attempts, config.MaxRetries+1)
+	}
+}
+
+func TestDoRequestRetriesRateLimitedPost(t *testing.T) {
+	clock := &fakeClock{}
+	attempts := 0
+	r := newMockTransportRequester(DefaultRequesterConfig(), clock, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			resp := mockResponse(http.StatusTooManyRequests, "")
+			resp.Header.Set("Retry-After", "2")
+			return resp, nil
+		}
+		return mockResponse(http.StatusOK, `{}`), nil
+	})
+
+	res := r.DoRequest(Request{Method: "POST", URL: "/channels/1/messages"})
+	if res.IsErr() {
+		t.Fatalf("rate limited POST should be resent: %v", res.Err())
+	}
+	res.Value().Close()
+	if attempts != 2 || !slices.Equal(clock.sleeps(), []time.Duration{2 * time.Second}) {
+		t.Errorf("attempts = %d, sleeps = %v", attempts, clock.sleeps())
+	}
+}
+
+func TestDoRequestContextCanceledMidRequest(t *testing.T) {
+	started := make(chan struct{})
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		select {
+		case <-req.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	res := r.FetchGuildCtx(ctx, 1, FetchGuildOptions{})
+	if !errors.Is(res.Err(), context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", res.Err())
+	}
+}
+
+func TestSendMessageCtxDeadline(t *testing.T) {
+	release := make(chan struct{})
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	})
+	// runs before the server is closed, which waits for the handler.
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	res := r.SendMessageCtx(ctx, 1, CreateMessageOptions{Content: "hi"})
+	if !errors.Is(res.Err(), context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", res.Err())
+	}
+}
+
+func TestDoRequestCanceledContextSendsNothing(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := r.ListMembersCtx(ctx, 1)
+	if !errors.Is(res.Err(), context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", res.Err())
+	}
+}
+
+func TestDoRequestContextCanceledStopsRetries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attempts int
+	r := newMockTransportRequester(DefaultRequesterConfig(), &fakeClock{}, func(req *http.Request) (*http.Response, error) {
+		attempts++
+		cancel()
+		return mockResponse(http.StatusServiceUnavailable, ""), nil
+	})
+
+	res := r.DoRequestCtx(ctx, Request{Method: "GET", URL: "/guilds/1"})
+	if !errors.Is(res.Err(), context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", res.Err())
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestRestErrorMissingPermissions(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message":"Missing Permissions","code":50013}`)
+	})
+
+	err := r.DoRequest(Request{Method: "POST", URL: "/channels/1/messages"}).Err()
+	if !IsForbidden(err) {
+		t.Errorf("IsForbidden(%v) = false", err)
+	}
+	if IsNotFound(err) || IsRateLimited(err) {
+		t.Errorf("%v matched the wrong status", err)
+	}
+	if !errors.Is(err, &RestError{Code: RestErrorCodeMissingPermissions}) {
+		t.Error("errors.Is should match the error code")
+	}
+	if errors.Is(err, &RestError{StatusCode: http.StatusForbidden, Code: RestErrorCodeCannotSendMessagesToUser}) {
+		t.Error("errors.Is matched a different error code")
+	}
+	if want := "request failed with status 403: Missing Permissions (code 50013)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	var restErr *RestError
+	if !errors.As(err, &restErr) || restErr.FieldErrors() != nil {
+		t.Errorf("unexpected field errors in %+v", restErr)
+	}
+}
+
+func TestRestErrorFieldErrors(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{
+			"message": "Invalid Form Body",
+			"code": 50035,
+			"errors": {
+				"content": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 2000 or fewer in length."}]},
+				"embeds": {"0": {"title": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}}
+			}
+		}`)
+	})
+
+	err := r.DoRequest(Request{Method: "POST", URL: "/channels/1/messages"}).Err()
+	var restErr *RestError
+	if !errors.As(err, &restErr) {
+		t.Fatalf("expected a *RestError, got %T", err)
+	}
+	fields := restErr.FieldErrors()
+	if len(fields) != 2 {
+		t.Fatalf("field errors = %v", fields)
+	}
+	if got := fields["embeds.0.title"]; len(got) != 1 || got[0].Code != "BASE_TYPE_REQUIRED" {
+		t.Errorf("embeds.0.title = %v", got)
+	}
+	if got := fields["content"]; len(got) != 1 || got[0].Message != "Must be 2000 or fewer in length." {
+		t.Errorf("content = %v", got)
+	}
+	want := "request failed with status 400: Invalid Form Body (code 50035); content: Must be 2000 or fewer in length.; embeds.0.title: This field is required"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestIsNotFoundWrapped(t *testing.T) {
+	err := fmt.Errorf("fetch guild: %w", &RestError{StatusCode: http.StatusNotFound, Code: 10004})
+	if !IsNotFound(err) {
+		t.Error("IsNotFound should see through wrapping")
+	}
+	if IsNotFound(errors.New("not found")) || IsNotFound(nil) {
+		t.Error("IsNotFound matched a non RestError")
+	}
+}
+
+func TestDoRequestFullHeaders(t *testing.T) {
+	r := newMockTransportRequester(DefaultRequesterConfig(), &fakeClock{now: time.Unix(1700000000, 0)}, func(req *http.Request) (*http.Response, error) {
+		resp := mockResponse(http.StatusOK, `{"id":"1"}`)
+		resp.Header.Set("X-RateLimit-Bucket", "abcd")
+		resp.Header.Set("X-RateLimit-Remaining", "4")
+		resp.Header.Set("X-RateLimit-Reset-After", "1.5")
+		resp.Header.Set(headerReason, req.Header.Get(headerReason))
+		return resp, nil
+	})
+
+	res := r.DoRequestFull(Request{Method: "DELETE", URL: "/channels/1", Reason: "cleanup"})
+	if res.IsErr() {
+		t.Fatalf("unexpected error: %v", res.Err())
+	}
+	resp := res.Value()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("X-RateLimit-Bucket"); got != "abcd" {
+		t.Errorf("X-RateLimit-Bucket = %q", got)
+	}
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "4" {
+		t.Errorf("X-RateLimit-Remaining = %q", got)
+	}
+	if got := resp.Header.Get(headerReason); got != "cleanup" {
+		t.Errorf("%s = %q", headerReason, got)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != `{"id":"1"}` {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestDoRequestFullRestError(t *testing.T) {
+	r := newMockTransportRequester(DefaultRequesterConfig(), &fakeClock{}, func(req *http.Request) (*http.Response, error) {
+		return mockResponse(http.StatusNotFound, `{"message":"Unknown Channel","code":10003}`), nil
+	})
+
+	res := r.DoRequestFull(Request{Method: "GET", URL: "/channels/1"})
+	if !IsNotFound(res.Err()) {
+		t.Errorf("error = %v, want a 404 RestError", res.Err())
+	}
+}
+
+func TestWebhookErrorsRedactToken(t *testing.T) {
+	const token = "super-secret-token"
+
+	tests := map[string]roundTripFunc{
+		"rest error": func(req *http.Request) (*http.Response, error) {
+			return mockResponse(http.StatusBadRequest, `{"message":"Invalid Form Body","code":50035}`), nil
+		},
+		"network error": func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection reset")
+		},
+	}
+	for name, transport := range tests {
+		t.Run(name, func(t *testing.T) {
+			var logs strings.Builder
+			r := newMockTransportRequester(DefaultRequesterConfig(), &fakeClock{}, transport)
+			r.logger = xlog.NewTextLogger(&logs, xlog.LogLevelDebugLevel)
+
+			res := r.DoRequest(Request{Method: "POST", URL: "/webhooks/10/" + token + "?wait=true", NoAuth: true})
+			if !res.IsErr() {
+				t.Fatal("expected an error")
+			}
+			if strings.Contains(res.Err().Error(), token) {
+				t.Errorf("error %q contains the webhook token", res.Err())
+			}
+			var restErr *RestError
+			if errors.As(res.Err(), &restErr) && restErr.URL != "/webhooks/10/{token}?wait=true" {
+				t.Errorf("RestError.URL = %q, want the token redacted", restErr.URL)
+			}
+			if strings.Contains(logs.String(), token) {
+				t.Errorf("logs contain the webhook token: %s", logs.String())
+			}
+		})
+	}
+}
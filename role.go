@@ -84,6 +84,55 @@ type RoleColors struct {
 	TertiaryColor optional.Option[Color] `json:"tertiary_color"`
 }
 
+// Holographic role colors, the only values Discord accepts when a tertiary color is set.
+const (
+	RoleColorHolographicPrimary   Color = 11127295
+	RoleColorHolographicSecondary Color = 16759788
+	RoleColorHolographicTertiary  Color = 16761760
+)
+
+// SolidRoleColor returns RoleColors for a role with a single solid color.
+//
+// Usage example:
+//
+//	opts := CreateRoleOptions{Colors: SolidRoleColor(0xFF0000)}
+func SolidRoleColor(primary Color) RoleColors {
+	return RoleColors{PrimaryColor: primary}
+}
+
+// GradientRoleColor returns RoleColors for a role with a gradient between two colors.
+//
+// Note:
+//   - The guild must have the GuildFeatureEnhancedRoleColors feature, otherwise Discord rejects the request.
+func GradientRoleColor(primary, secondary Color) RoleColors {
+	return RoleColors{
+		PrimaryColor:   primary,
+		SecondaryColor: optional.Some(secondary),
+	}
+}
+
+// HolographicRoleColor returns the RoleColors of Discord's holographic role style.
+//
+// Note:
+//   - The guild must have the GuildFeatureEnhancedRoleColors feature, otherwise Discord rejects the request.
+func HolographicRoleColor() RoleColors {
+	return RoleColors{
+		PrimaryColor:   RoleColorHolographicPrimary,
+		SecondaryColor: optional.Some(RoleColorHolographicSecondary),
+		TertiaryColor:  optional.Some(RoleColorHolographicTertiary),
+	}
+}
+
+// IsGradient returns true if the colors define a gradient (secondary color set).
+func (c RoleColors) IsGradient() bool {
+	return c.SecondaryColor.IsPresent()
+}
+
+// IsHolographic returns true if the colors define the holographic style (tertiary color set).
+func (c RoleColors) IsHolographic() bool {
+	return c.TertiaryColor.IsPresent()
+}
+
 // Role represents a Discord role.
 //
 // Reference: https://discord.com/developers/docs/resources/guild#role-object-role-structure
@@ -140,6 +189,12 @@ type Role struct {
 	Flags RoleFlags `json:"flags"`
 }
 
+// IsEveryone reports whether the role is the @everyone role of the given guild,
+// whose ID is always the guild ID.
+func (r *Role) IsEveryone(guildID Snowflake) bool {
+	return r.ID == guildID
+}
+
 // Mention returns a Discord mention string for the role.
 //
 // Example output: "<@&123456789012345678>"
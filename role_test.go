@@ -0,0 +1,194 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRoleColorsMarshal(t *testing.T) {
+	tests := []struct {
+		name   string
+		colors RoleColors
+		want   string
+	}{
+		{"solid", SolidRoleColor(0xFF0000), `{"primary_color":16711680,"secondary_color":null,"tertiary_color":null}`},
+		{"gradient", GradientRoleColor(0xFF0000, 0x0000FF), `{"primary_color":16711680,"secondary_color":255,"tertiary_color":null}`},
+		{"holographic", HolographicRoleColor(), `{"primary_color":11127295,"secondary_color":16759788,"tertiary_color":16761760}`},
+	}
+
+	for _, tt := range tests {
+		buf, err := json.Marshal(tt.colors)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if string(buf) != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, buf)
+		}
+	}
+}
+
+func TestRoleColorsPredicates(t *testing.T) {
+	if c := SolidRoleColor(1); c.IsGradient() || c.IsHolographic() {
+		t.Errorf("expected solid color to not be a gradient nor holographic")
+	}
+	if c := GradientRoleColor(1, 2); !c.IsGradient() || c.IsHolographic() {
+		t.Errorf("expected gradient color to be a gradient but not holographic")
+	}
+	if c := HolographicRoleColor(); !c.IsGradient() || !c.IsHolographic() {
+		t.Errorf("expected holographic color to be a gradient and holographic")
+	}
+}
+
+func TestRoleIconURL(t *testing.T) {
+	want := ImageBaseURL + "role-icons/123456789012345678/abcdef.png"
+	if url := RoleIconURL(123456789012345678, "abcdef", ImageFormatPNG, ImageSizeDefault); url != want {
+		t.Errorf("expected %q, got %q", want, url)
+	}
+
+	want = ImageBaseURL + "role-icons/123456789012345678/abcdef.webp?size=64"
+	if url := RoleIconURL(123456789012345678, "abcdef", ImageFormatWebP, ImageSize64); url != want {
+		t.Errorf("expected %q, got %q", want, url)
+	}
+}
+
+func TestRoleIconURLMethods(t *testing.T) {
+	role := Role{ID: 123456789012345678, UnicodeEmoji: "🔥"}
+
+	if url := role.IconURL(); url != "" {
+		t.Errorf("expected empty url without icon, got %q", url)
+	}
+	if url := role.IconURLWith(ImageFormatWebP, ImageSize64); url != "" {
+		t.Errorf("expected empty url without icon, got %q", url)
+	}
+
+	role.Icon = "abcdef"
+	want := ImageBaseURL + "role-icons/123456789012345678/abcdef.png"
+	if url := role.IconURL(); url != want {
+		t.Errorf("expected %q, got %q", want, url)
+	}
+	want = ImageBaseURL + "role-icons/123456789012345678/abcdef.jpeg?size=128"
+	if url := role.IconURLWith(ImageFormatJPEG, ImageSize128); url != want {
+		t.Errorf("expected %q, got %q", want, url)
+	}
+}
+
+func TestRoleIsEveryone(t *testing.T) {
+	everyone := Role{ID: 1, GuildID: 1}
+	if !everyone.IsEveryone(1) {
+		t.Error("role with the guild ID should be @everyone")
+	}
+	mods := Role{ID: 2, GuildID: 1}
+	if mods.IsEveryone(1) {
+		t.Error("role with another ID should not be @everyone")
+	}
+
+	guild := Guild{ID: 1}
+	if guild.EveryoneRoleID() != 1 {
+		t.Errorf("EveryoneRoleID() = %d, want 1", guild.EveryoneRoleID())
+	}
+}
+
+func TestClientEveryoneRole(t *testing.T) {
+	client := newTestClient(t, nil)
+	if client.EveryoneRole(1).IsPresent() {
+		t.Fatal("expected None before the role is cached")
+	}
+
+	client.PutRole(Role{ID: 1, GuildID: 1, Name: "@everyone", Permissions: PermissionViewChannel})
+	role, err := client.EveryoneRole(1).GetErr()
+	if err != nil || !role.Permissions.Has(PermissionViewChannel) {
+		t.Errorf("EveryoneRole(1) = %+v, %v", role, err)
+	}
+}
+
+func TestGuildRoleEventsUpdateCache(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	var created GuildRoleCreateEvent
+	client.OnGuildRoleCreate(func(evt GuildRoleCreateEvent) { created = evt })
+	client.handlersManagers["GUILD_ROLE_CREATE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","role":{"id":"10","name":"mods","position":2}}`))
+
+	if created.Role.ID != 10 || created.Role.GuildID != 1 {
+		t.Errorf("created role = %+v", created.Role)
+	}
+	role, ok := client.GetRoles(10)[10]
+	if !ok || role.Name != "mods" || role.GuildID != 1 {
+		t.Fatalf("cached role after create = %+v, %v", role, ok)
+	}
+	if rolesOpt := client.GetGuildRoles(1); !rolesOpt.IsPresent() || len(rolesOpt.Get()) != 1 {
+		t.Error("role is not indexed under its guild")
+	}
+
+	var updated GuildRoleUpdateEvent
+	client.OnGuildRoleUpdate(func(evt GuildRoleUpdateEvent) { updated = evt })
+	client.handlersManagers["GUILD_ROLE_UPDATE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","role":{"id":"10","name":"moderators","position":2}}`))
+
+	if updated.OldRole.Name != "mods" || updated.NewRole.Name != "moderators" || updated.NewRole.GuildID != 1 {
+		t.Errorf("update event = %+v", updated)
+	}
+	if role := client.GetRoles(10)[10]; role.Name != "moderators" {
+		t.Errorf("cached role after update = %+v", role)
+	}
+
+	var deleted GuildRoleDeleteEvent
+	client.OnGuildRoleDelete(func(evt GuildRoleDeleteEvent) { deleted = evt })
+	client.handlersManagers["GUILD_ROLE_DELETE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","role_id":"10"}`))
+
+	if deleted.Role.Name != "moderators" {
+		t.Errorf("delete event should carry the cached role, got %+v", deleted.Role)
+	}
+	if _, ok := client.GetRoles(10)[10]; ok {
+		t.Error("role is still cached after GUILD_ROLE_DELETE")
+	}
+}
+
+func TestGuildRoleUpdateUncached(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.SetFlags(CacheFlagChannels)
+
+	var updated GuildRoleUpdateEvent
+	client.OnGuildRoleUpdate(func(evt GuildRoleUpdateEvent) { updated = evt })
+	client.handlersManagers["GUILD_ROLE_UPDATE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","role":{"id":"10","name":"mods"}}`))
+
+	if updated.OldRole.ID != 10 || updated.OldRole.GuildID != 1 || updated.OldRole.Name != "" {
+		t.Errorf("old role = %+v", updated.OldRole)
+	}
+	if _, ok := client.GetRoles(10)[10]; ok {
+		t.Error("role was cached without CacheFlagRoles")
+	}
+}
+
+func TestGuildRoleDeleteRespectsCacheFlags(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.PutRole(Role{ID: 10, GuildID: 1, Name: "mods"})
+	client.SetFlags(CacheFlagChannels)
+
+	var deleted GuildRoleDeleteEvent
+	client.OnGuildRoleDelete(func(evt GuildRoleDeleteEvent) { deleted = evt })
+	client.handlersManagers["GUILD_ROLE_DELETE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id":"1","role_id":"10"}`))
+
+	if deleted.Role.ID != 10 {
+		t.Errorf("deleted role = %+v", deleted.Role)
+	}
+	if _, ok := client.GetRoles(10)[10]; !ok {
+		t.Error("role cache was changed with CacheFlagRoles disabled")
+	}
+}
@@ -0,0 +1,179 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*****************************
+ *       Route Stats
+ *****************************/
+
+// routeStatsReservoirSize is the maximum number of latency samples kept per route.
+const routeStatsReservoirSize = 1024
+
+// RouteStat holds the request statistics of a route template, see Client.RouteStats.
+type RouteStat struct {
+	// Count is the number of responses received on the route, retries included.
+	Count int
+
+	// RateLimited is the number of 429 responses received on the route.
+	RateLimited int
+
+	// P50 is the median latency of the route.
+	P50 time.Duration
+
+	// P95 is the 95th percentile latency of the route.
+	P95 time.Duration
+
+	// P99 is the 99th percentile latency of the route.
+	P99 time.Duration
+}
+
+// routeStatsEntry holds the counters and latency samples of a single route.
+//
+// Latencies are sampled with a fixed size reservoir, so percentiles are
+// approximations once more than routeStatsReservoirSize responses are recorded.
+type routeStatsEntry struct {
+	count       int
+	rateLimited int
+	samples     []time.Duration
+}
+
+// routeStats records the count, latency and 429s of requests per route template.
+type routeStats struct {
+	mu     sync.Mutex
+	routes map[string]*routeStatsEntry
+}
+
+// record adds the response of a request on route to the stats.
+func (s *routeStats) record(route string, latency time.Duration, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.routes == nil {
+		s.routes = make(map[string]*routeStatsEntry)
+	}
+	entry, ok := s.routes[route]
+	if !ok {
+		entry = &routeStatsEntry{}
+		s.routes[route] = entry
+	}
+
+	entry.count++
+	if status == 429 {
+		entry.rateLimited++
+	}
+	if len(entry.samples) < routeStatsReservoirSize {
+		entry.samples = append(entry.samples, latency)
+	} else if i := rand.IntN(entry.count); i < routeStatsReservoirSize {
+		entry.samples[i] = latency
+	}
+}
+
+// snapshot returns the stats of every recorded route.
+func (s *routeStats) snapshot() map[string]RouteStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make(map[string]RouteStat, len(s.routes))
+	for route, entry := range s.routes {
+		sorted := slices.Clone(entry.samples)
+		slices.Sort(sorted)
+		stats[route] = RouteStat{
+			Count:       entry.count,
+			RateLimited: entry.rateLimited,
+			P50:         percentile(sorted, 0.50),
+			P95:         percentile(sorted, 0.95),
+			P99:         percentile(sorted, 0.99),
+		}
+	}
+	return stats
+}
+
+// percentile returns the nearest-rank p percentile of the sorted samples.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+// routeTemplate returns the route template of a request, with IDs, tokens
+// and emojis replaced by placeholders, for example "GET /channels/{id}/messages/{id}".
+func routeTemplate(method, url string) string {
+	if i := strings.IndexByte(url, '?'); i != -1 {
+		url = url[:i]
+	}
+
+	segments := strings.Split(url, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		switch {
+		case isNumeric(segment):
+			segments[i] = "{id}"
+		case i >= 2 && (segments[i-2] == "webhooks" || segments[i-2] == "interactions"):
+			segments[i] = "{token}"
+		case i >= 1 && segments[i-1] == "reactions":
+			segments[i] = "{emoji}"
+		}
+	}
+	return method + " " + strings.Join(segments, "/")
+}
+
+// redactURL returns url with the webhook and interaction tokens replaced by "{token}",
+// so it can be logged or returned in errors.
+func redactURL(url string) string {
+	path, query, hasQuery := strings.Cut(url, "?")
+
+	segments := strings.Split(path, "/")
+	for i := 2; i < len(segments); i++ {
+		if segments[i] != "" && (segments[i-2] == "webhooks" || segments[i-2] == "interactions") {
+			segments[i] = "{token}"
+		}
+	}
+	path = strings.Join(segments, "/")
+
+	if hasQuery {
+		return path + "?" + query
+	}
+	return path
+}
+
+// isNumeric reports whether s only contains ASCII digits.
+func isNumeric(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// RouteStats returns the request statistics of each route template used by the client,
+// such as "GET /channels/{id}/messages/{id}".
+//
+// Use it to find which endpoints dominate the rate limit budget and latency of the bot.
+func (r *requester) RouteStats() map[string]RouteStat {
+	return r.stats.snapshot()
+}
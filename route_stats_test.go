@@ -0,0 +1,98 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRouteStatsPercentiles(t *testing.T) {
+	var stats routeStats
+	for i := 100; i >= 1; i-- {
+		status := http.StatusOK
+		if i%10 == 0 {
+			status = http.StatusTooManyRequests
+		}
+		stats.record("GET /channels/{id}", time.Duration(i)*time.Millisecond, status)
+	}
+
+	stat := stats.snapshot()["GET /channels/{id}"]
+	if stat.Count != 100 || stat.RateLimited != 10 {
+		t.Errorf("count = %d, rate limited = %d", stat.Count, stat.RateLimited)
+	}
+	if stat.P50 != 50*time.Millisecond || stat.P95 != 95*time.Millisecond || stat.P99 != 99*time.Millisecond {
+		t.Errorf("percentiles = %v / %v / %v", stat.P50, stat.P95, stat.P99)
+	}
+}
+
+func TestRouteStatsReservoirIsBounded(t *testing.T) {
+	var stats routeStats
+	for i := range routeStatsReservoirSize * 3 {
+		stats.record("GET /gateway/bot", time.Duration(i), http.StatusOK)
+	}
+	if n := len(stats.routes["GET /gateway/bot"].samples); n != routeStatsReservoirSize {
+		t.Errorf("kept %d samples, want %d", n, routeStatsReservoirSize)
+	}
+	if count := stats.snapshot()["GET /gateway/bot"].Count; count != routeStatsReservoirSize*3 {
+		t.Errorf("count = %d", count)
+	}
+}
+
+func TestRouteTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/channels/10/messages/20":                     "GET /channels/{id}/messages/{id}",
+		"/channels/10/messages/20/reactions/👍/@me":     "GET /channels/{id}/messages/{id}/reactions/{emoji}/@me",
+		"/webhooks/50/secret/messages/900?thread_id=7": "GET /webhooks/{id}/{token}/messages/{id}",
+		"/guilds/1/audit-logs?limit=10":                "GET /guilds/{id}/audit-logs",
+	}
+	for url, want := range tests {
+		if got := routeTemplate("GET", url); got != want {
+			t.Errorf("routeTemplate(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := map[string]string{
+		"/channels/10/messages/20":                     "/channels/10/messages/20",
+		"/webhooks/50/secret?wait=true":                "/webhooks/50/{token}?wait=true",
+		"/webhooks/50/secret/messages/900?thread_id=7": "/webhooks/50/{token}/messages/900?thread_id=7",
+		"/interactions/60/secret/callback":             "/interactions/60/{token}/callback",
+		"/webhooks/50":                                 "/webhooks/50",
+	}
+	for url, want := range tests {
+		if got := redactURL(url); got != want {
+			t.Errorf("redactURL(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestRequesterRecordsRouteStats(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, `{}`)
+	})
+	for range 3 {
+		res := r.DoRequest(Request{Method: "GET", URL: "/users/5"})
+		if res.IsErr() {
+			t.Fatalf("DoRequest: %v", res.Err())
+		}
+		res.Value().Close()
+	}
+	if stat := r.RouteStats()["GET /users/{id}"]; stat.Count != 3 {
+		t.Errorf("stats = %+v", r.RouteStats())
+	}
+}
@@ -18,10 +18,12 @@ import (
 	"compress/zlib"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"math/rand/v2"
 	"net"
 	"net/url"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -80,6 +82,71 @@ func (rl *DefaultShardsRateLimiter) Wait() {
 	<-rl.tokens
 }
 
+/*******************************
+ * Shards Session Store
+ *******************************/
+
+// ShardsSessionStore defines the interface used to persist the Gateway session state of shards.
+//
+// Persisting the session outside of the process (e.g. in Redis) lets shards RESUME after a
+// restart or a deploy, instead of identifying again and receiving every GUILD_CREATE.
+//
+// Note:
+//   - Since no GUILD_CREATE is received on RESUME, the cache of a restarted process stays empty
+//     until later events or REST fetches fill it.
+//
+// Implementations must be safe for concurrent use by multiple shards.
+type ShardsSessionStore interface {
+	// SaveSession stores the session state of a shard.
+	//
+	// An empty sessionID means the shard has no resumable session anymore.
+	SaveSession(shardID int, sessionID, resumeURL string, seq int)
+
+	// LoadSession returns the stored session state of a shard, ok is false if there is none.
+	LoadSession(shardID int) (sessionID, resumeURL string, seq int, ok bool)
+}
+
+// shardSession is the session state of a shard.
+type shardSession struct {
+	sessionID string
+	resumeURL string
+	seq       int
+}
+
+// InMemorySessionStore is the default ShardsSessionStore, keeping sessions in memory.
+//
+// Sessions don't survive a process restart.
+type InMemorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[int]shardSession
+}
+
+var _ ShardsSessionStore = (*InMemorySessionStore)(nil)
+
+// NewInMemorySessionStore creates a new empty InMemorySessionStore.
+func NewInMemorySessionStore() *InMemorySessionStore {
+	return &InMemorySessionStore{sessions: make(map[int]shardSession)}
+}
+
+// SaveSession stores the session state of a shard, removing it if sessionID is empty.
+func (st *InMemorySessionStore) SaveSession(shardID int, sessionID, resumeURL string, seq int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if sessionID == "" {
+		delete(st.sessions, shardID)
+		return
+	}
+	st.sessions[shardID] = shardSession{sessionID: sessionID, resumeURL: resumeURL, seq: seq}
+}
+
+// LoadSession returns the stored session state of a shard.
+func (st *InMemorySessionStore) LoadSession(shardID int) (sessionID, resumeURL string, seq int, ok bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	session, ok := st.sessions[shardID]
+	return session.sessionID, session.resumeURL, session.seq, ok
+}
+
 /*************************************
  * ShardManager: manages multiple shards
  *************************************/
@@ -108,6 +175,10 @@ type ShardManagerConfig struct {
 	TotalShards int
 	ShardIDs    []int
 	Identify    IdentifyProperties
+
+	// SessionStore persists the session state of shards to resume them after a restart.
+	// Defaults to an InMemorySessionStore if nil.
+	SessionStore ShardsSessionStore
 }
 
 // ShardManager manages the lifecycle of multiple Gateway shards.
@@ -169,12 +240,18 @@ func (sm *ShardManager) Start(ctx context.Context, totalShards int) error {
 		"managed_shards": shardIDs,
 	}).Info("starting shard manager")
 
+	if sm.config.SessionStore == nil {
+		sm.config.SessionStore = NewInMemorySessionStore()
+	}
+
 	for _, shardID := range shardIDs {
 		shard := newShard(
 			shardID, totalShards, sm.token, sm.intents,
 			sm.logger, sm.dispatcher, sm.identifyLimiter,
 			sm.useCompression, sm.config.Identify,
 		)
+		shard.sessionStore = sm.config.SessionStore
+		shard.loadSession()
 		if err := shard.connect(ctx); err != nil {
 			return err
 		}
@@ -193,6 +270,18 @@ func (sm *ShardManager) Shutdown() {
 	sm.shards = nil
 }
 
+// ShardForGuild returns the managed shard which receives the events of the given guild.
+//
+// ok is false if the guild belongs to a shard managed by another process.
+func (sm *ShardManager) ShardForGuild(guildID Snowflake) (shard *Shard, ok bool) {
+	for _, shard := range sm.shards {
+		if int((uint64(guildID)>>22)%uint64(shard.totalShards)) == shard.shardID {
+			return shard, true
+		}
+	}
+	return nil, false
+}
+
 // Shards returns the list of managed shards.
 func (sm *ShardManager) Shards() []*Shard {
 	return sm.shards
@@ -207,6 +296,14 @@ func (sm *ShardManager) ShardCount() int {
  * Shard: a single Gateway connection
  *************************************/
 
+// gatewaySendLimit is the number of payloads a shard sends on user request per gatewaySendInterval.
+//
+// Discord allows 120 payloads per minute, the rest is left for heartbeats, identifies and resumes.
+const (
+	gatewaySendLimit    = 110
+	gatewaySendInterval = 60 * time.Second
+)
+
 const (
 	gatewayVersion = "10"
 	gatewayURL     = "wss://gateway.discord.gg/?v=" + gatewayVersion + "&encoding=json"
@@ -225,11 +322,15 @@ type Shard struct {
 	dispatcher      *dispatcher               // event dispatcher for received Gateway events
 	identifyLimiter ShardsIdentifyRateLimiter // rate limiter controlling Identify payloads
 
-	conn net.Conn // websocket connection
+	conn        net.Conn                  // websocket connection
+	writeMu     sync.Mutex                // serializes writes to conn
+	sendLimiter ShardsIdentifyRateLimiter // rate limiter for payloads sent on user request
 
-	seq       int64  // last received sequence number from Gateway
-	sessionID string // current session id for resuming
-	resumeURL string // Gateway URL to resume session on
+	seq          int64              // last received sequence number from Gateway
+	sessionMu    sync.Mutex         // guards sessionID and resumeURL
+	sessionID    string             // current session id for resuming
+	resumeURL    string             // Gateway URL to resume session on
+	sessionStore ShardsSessionStore // persists the session state, may be nil
 
 	latency           int64         // heartbeat latency in milliseconds
 	lastHeartbeatSent int64         // timestamp (unix nano) of last heartbeat sent
@@ -265,7 +366,50 @@ func newShard(
 		identifyLimiter: limiter,
 		useCompression:  useCompression,
 		properties:      properties,
+		sendLimiter:     NewDefaultShardsRateLimiter(gatewaySendLimit, gatewaySendInterval/gatewaySendLimit),
+	}
+}
+
+// loadSession restores the session state of the shard from its session store, if any.
+func (s *Shard) loadSession() {
+	if s.sessionStore == nil {
+		return
+	}
+	sessionID, resumeURL, seq, ok := s.sessionStore.LoadSession(s.shardID)
+	if !ok || sessionID == "" {
+		return
+	}
+	s.setSession(sessionID, resumeURL)
+	atomic.StoreInt64(&s.seq, int64(seq))
+	s.logger.WithField("seq", seq).Debug("session loaded from store")
+}
+
+// saveSession persists the current session state of the shard to its session store.
+//
+// It's called from the read loop, the heartbeat goroutine and Shutdown. The session lock is held
+// while saving, so a save can't overwrite the store with an older session than a concurrent one.
+func (s *Shard) saveSession() {
+	if s.sessionStore == nil {
+		return
 	}
+	s.sessionMu.Lock()
+	defer s.sessionMu.Unlock()
+	s.sessionStore.SaveSession(s.shardID, s.sessionID, s.resumeURL, int(atomic.LoadInt64(&s.seq)))
+}
+
+// session returns the current session id and resume URL of the shard.
+func (s *Shard) session() (sessionID, resumeURL string) {
+	s.sessionMu.Lock()
+	defer s.sessionMu.Unlock()
+	return s.sessionID, s.resumeURL
+}
+
+// setSession sets the current session id and resume URL of the shard.
+func (s *Shard) setSession(sessionID, resumeURL string) {
+	s.sessionMu.Lock()
+	defer s.sessionMu.Unlock()
+	s.sessionID = sessionID
+	s.resumeURL = resumeURL
 }
 
 // Connect establishes or resumes a WebSocket connection to Discord Gateway
@@ -285,7 +429,7 @@ func (s *Shard) connect(ctx context.Context) error {
 		s.conn.Close()
 	}
 
-	connURL := s.resumeURL
+	_, connURL := s.session()
 	if connURL == "" {
 		if s.useCompression {
 			connURL = gatewayURLZlib
@@ -446,8 +590,8 @@ func (s *Shard) handleGatewayPayload(payload gatewayPayload) {
 				ResumeGatewayURL string `json:"resume_gateway_url"`
 			}
 			json.Unmarshal(payload.D, &ready)
-			s.sessionID = ready.SessionID
-			s.resumeURL = ready.ResumeGatewayURL
+			s.setSession(ready.SessionID, ready.ResumeGatewayURL)
+			s.saveSession()
 			s.logger.Info("READY received")
 		} else if payload.T == "RESUMED" {
 			s.logger.Info("RESUMED received")
@@ -467,8 +611,10 @@ func (s *Shard) handleGatewayPayload(payload gatewayPayload) {
 			s.sendResume()
 		} else {
 			s.logger.Info("session invalid (non-resumable), identifying")
-			s.sessionID = ""
-			s.seq = 0
+			_, resumeURL := s.session()
+			s.setSession("", resumeURL)
+			atomic.StoreInt64(&s.seq, 0)
+			s.saveSession()
 			s.sendIdentify()
 		}
 
@@ -481,7 +627,7 @@ func (s *Shard) handleGatewayPayload(payload gatewayPayload) {
 		s.logger.WithField("heartbeat_interval", interval.String()).Debug("HELLO received")
 		go s.startHeartbeat(interval)
 
-		if s.sessionID != "" && atomic.LoadInt64(&s.seq) > 0 {
+		if sessionID, _ := s.session(); sessionID != "" && atomic.LoadInt64(&s.seq) > 0 {
 			s.logger.Info("resuming session")
 			s.sendResume()
 		} else {
@@ -523,22 +669,23 @@ func (s *Shard) sendIdentify() error {
 		},
 	})
 	s.identifyLimiter.Wait()
-	return wsutil.WriteClientMessage(s.conn, ws.OpText, payload)
+	return s.send(payload)
 }
 
 // sendResume sends a Resume payload to Discord Gateway
 //
 // This attempts to resume a previous session using sessionID and sequence number.
 func (s *Shard) sendResume() error {
+	sessionID, _ := s.session()
 	payload, _ := json.Marshal(map[string]any{
 		"op": gatewayOpcodeResume,
 		"d": map[string]any{
 			"token":      s.token,
-			"session_id": s.sessionID,
+			"session_id": sessionID,
 			"seq":        atomic.LoadInt64(&s.seq),
 		},
 	})
-	return wsutil.WriteClientMessage(s.conn, ws.OpText, payload)
+	return s.send(payload)
 }
 
 // sendHeartbeat sends a Heartbeat payload to Discord Gateway
@@ -549,9 +696,89 @@ func (s *Shard) sendHeartbeat() error {
 		"op": gatewayOpcodeHeartbeat,
 		"d":  atomic.LoadInt64(&s.seq),
 	})
+	return s.send(payload)
+}
+
+// send writes a text payload to the Gateway connection.
+//
+// Writes are serialized since heartbeats are sent from their own goroutine.
+func (s *Shard) send(payload []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
 	return wsutil.WriteClientMessage(s.conn, ws.OpText, payload)
 }
 
+// RequestGuildMembersOptions contains the parameters of a Request Guild Members payload.
+//
+// Reference: https://discord.com/developers/docs/events/gateway-events#request-guild-members
+type RequestGuildMembersOptions struct {
+	// GuildID is the ID of the guild to get the members of.
+	GuildID Snowflake
+
+	// Query returns the members whose username starts with it, an empty string returns all members.
+	//
+	// Note:
+	//   - Ignored if UserIDs is set.
+	Query string
+
+	// Limit is the maximum number of members to return, 0 returns all members matching Query.
+	Limit int
+
+	// Presences requests the presences of the matched members.
+	//
+	// Note:
+	//   - Requires the GatewayIntentGuildPresences intent.
+	Presences bool
+
+	// UserIDs is the list of members to return (max 100).
+	UserIDs []Snowflake
+
+	// Nonce identifies the resulting GuildMembersChunkEvent (max 32 bytes).
+	Nonce string
+}
+
+// RequestGuildMembers asks Discord to send the members of a guild as GuildMembersChunkEvent events.
+//
+// The shard must be the one the guild belongs to. Requests are rate limited along
+// with the other payloads of the shard, so this call may block.
+//
+// Note:
+//   - Requesting all members (empty Query and Limit 0) requires the GatewayIntentGuildMembers intent.
+func (s *Shard) RequestGuildMembers(opts RequestGuildMembersOptions) error {
+	if s.conn == nil {
+		return errors.New("RequestGuildMembers: shard is not connected")
+	}
+	if len(opts.UserIDs) == 0 && opts.Query == "" && opts.Limit == 0 && s.intents&GatewayIntentGuildMembers == 0 {
+		return errors.New("RequestGuildMembers: requesting all members requires the GatewayIntentGuildMembers intent")
+	}
+	if opts.Presences && s.intents&GatewayIntentGuildPresences == 0 {
+		return errors.New("RequestGuildMembers: presences require the GatewayIntentGuildPresences intent")
+	}
+
+	d := map[string]any{
+		"guild_id": opts.GuildID,
+		"limit":    opts.Limit,
+	}
+	if len(opts.UserIDs) > 0 {
+		d["user_ids"] = opts.UserIDs
+	} else {
+		d["query"] = opts.Query
+	}
+	if opts.Presences {
+		d["presences"] = true
+	}
+	if opts.Nonce != "" {
+		d["nonce"] = opts.Nonce
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"op": gatewayOpcodeRequestGuildMembers,
+		"d":  d,
+	})
+
+	s.sendLimiter.Wait()
+	return s.send(payload)
+}
+
 // startHeartbeat begins sending heartbeats at the given interval.
 //
 // Per Discord spec, the first heartbeat has a jitter delay (interval * random 0-1).
@@ -597,6 +824,7 @@ func (s *Shard) startHeartbeat(interval time.Duration) {
 				s.conn.Close()
 				return
 			}
+			s.saveSession()
 		}
 	}
 }
@@ -642,6 +870,7 @@ func (s *Shard) Latency() int64 {
 //
 // Call this when you want to stop the shard gracefully.
 func (s *Shard) Shutdown() error {
+	s.saveSession()
 	if s.conn != nil {
 		s.logger.Info("shutting down")
 		return s.conn.Close()
@@ -0,0 +1,217 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gobwas/ws/wsutil"
+	"github.com/marouanesouiri/stdx/xlog"
+)
+
+func TestInMemorySessionStore(t *testing.T) {
+	store := NewInMemorySessionStore()
+	if _, _, _, ok := store.LoadSession(0); ok {
+		t.Fatal("expected no session in an empty store")
+	}
+
+	store.SaveSession(0, "abc", "wss://resume.discord.gg", 42)
+	sessionID, resumeURL, seq, ok := store.LoadSession(0)
+	if !ok || sessionID != "abc" || resumeURL != "wss://resume.discord.gg" || seq != 42 {
+		t.Errorf("LoadSession(0) = %q, %q, %d, %v", sessionID, resumeURL, seq, ok)
+	}
+
+	store.SaveSession(0, "", "", 0)
+	if _, _, _, ok := store.LoadSession(0); ok {
+		t.Error("expected the session to be removed when saved with an empty session ID")
+	}
+}
+
+func TestShardSessionRoundTrip(t *testing.T) {
+	client := newTestClient(t, nil)
+	logger := xlog.NewTextLogger(io.Discard, xlog.LogLevelWarnLevel)
+
+	store := NewInMemorySessionStore()
+	store.SaveSession(3, "old-session", "wss://old.discord.gg", 42)
+
+	shard := newShard(3, 4, "token", 0, logger, client.dispatcher, nil, false, IdentifyProperties{})
+	shard.sessionStore = store
+	shard.loadSession()
+
+	if shard.sessionID != "old-session" || shard.resumeURL != "wss://old.discord.gg" || shard.seq != 42 {
+		t.Fatalf("shard state not loaded: %q %q %d", shard.sessionID, shard.resumeURL, shard.seq)
+	}
+
+	shard.handleGatewayPayload(gatewayPayload{
+		Op: gatewayOpcodeDispatch,
+		T:  "READY",
+		S:  1,
+		D:  []byte(`{"session_id":"new-session","resume_gateway_url":"wss://new.discord.gg"}`),
+	})
+
+	sessionID, resumeURL, seq, ok := store.LoadSession(3)
+	if !ok || sessionID != "new-session" || resumeURL != "wss://new.discord.gg" || seq != 1 {
+		t.Errorf("after READY store has %q, %q, %d, %v", sessionID, resumeURL, seq, ok)
+	}
+
+	shard.seq = 7
+	shard.Shutdown()
+	if _, _, seq, _ := store.LoadSession(3); seq != 7 {
+		t.Errorf("after Shutdown seq = %d, want 7", seq)
+	}
+}
+
+func TestShardSaveSessionDuringReady(t *testing.T) {
+	client := newTestClient(t, nil)
+	logger := xlog.NewTextLogger(io.Discard, xlog.LogLevelWarnLevel)
+
+	// the store doesn't synchronize, so only the shard orders the accesses for the race detector.
+	store := &unsyncedSessionStore{}
+	shard := newShard(0, 1, "token", 0, logger, client.dispatcher, nil, false, IdentifyProperties{})
+	shard.sessionStore = store
+
+	// saves from the heartbeat goroutine run while the read loop handles READY and RESUMED.
+	started, stop := make(chan struct{}), make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		close(started)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				shard.saveSession()
+			}
+		}
+	}()
+	<-started
+
+	for i := 1; i <= 200; i++ {
+		shard.handleGatewayPayload(gatewayPayload{
+			Op: gatewayOpcodeDispatch,
+			T:  "READY",
+			S:  int64(i),
+			D:  []byte(`{"session_id":"session-` + strconv.Itoa(i) + `","resume_gateway_url":"wss://resume.discord.gg"}`),
+		})
+		shard.handleGatewayPayload(gatewayPayload{Op: gatewayOpcodeDispatch, T: "RESUMED", S: int64(i)})
+	}
+	close(stop)
+	wg.Wait()
+
+	sessionID, resumeURL, seq, ok := store.LoadSession(0)
+	if !ok || sessionID != "session-200" || resumeURL != "wss://resume.discord.gg" || seq != 200 {
+		t.Errorf("store has %q, %q, %d, %v", sessionID, resumeURL, seq, ok)
+	}
+}
+
+// unsyncedSessionStore is a ShardsSessionStore keeping the last saved session, without locking.
+type unsyncedSessionStore struct {
+	session shardSession
+}
+
+func (st *unsyncedSessionStore) SaveSession(shardID int, sessionID, resumeURL string, seq int) {
+	st.session = shardSession{sessionID: sessionID, resumeURL: resumeURL, seq: seq}
+}
+
+func (st *unsyncedSessionStore) LoadSession(shardID int) (sessionID, resumeURL string, seq int, ok bool) {
+	return st.session.sessionID, st.session.resumeURL, st.session.seq, st.session.sessionID != ""
+}
+
+func TestChunkGuildsOnStartup(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.intents = GatewayIntentGuilds | GatewayIntentGuildMembers
+	client.chunkGuildsOnStartup = true
+	client.chunkMaxMembers = 1000
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	shard := newShard(0, 1, "token", client.intents, client.Logger, client.dispatcher, nil, false, IdentifyProperties{})
+	shard.conn = clientConn
+	client.shardManager = &ShardManager{shards: []*Shard{shard}}
+
+	requested := make(chan Snowflake, 4)
+	go func() {
+		for {
+			data, err := wsutil.ReadClientText(serverConn)
+			if err != nil {
+				return
+			}
+			var payload struct {
+				Op gatewayOpcode `json:"op"`
+				D  struct {
+					GuildID Snowflake `json:"guild_id"`
+					Query   *string   `json:"query"`
+					Limit   int       `json:"limit"`
+				} `json:"d"`
+			}
+			if err := json.Unmarshal(data, &payload); err != nil {
+				t.Errorf("decode payload: %v", err)
+				return
+			}
+			if payload.Op != gatewayOpcodeRequestGuildMembers || payload.D.Query == nil || *payload.D.Query != "" || payload.D.Limit != 0 {
+				t.Errorf("unexpected payload %s", data)
+			}
+			requested <- payload.D.GuildID
+		}
+	}()
+
+	handler := client.handlersManagers["GUILD_CREATE"]
+	handler.handleEvent(client, false, 0, []byte(`{"id":"1","name":"a","member_count":10}`))
+	handler.handleEvent(client, false, 0, []byte(`{"id":"2","name":"b","member_count":5000}`))
+	handler.handleEvent(client, false, 0, []byte(`{"id":"3","name":"c","member_count":20}`))
+	handler.handleEvent(client, false, 0, []byte(`{"id":"4","unavailable":true}`))
+
+	var got []Snowflake
+	timeout := time.After(time.Second)
+	for len(got) < 2 {
+		select {
+		case id := <-requested:
+			got = append(got, id)
+		case <-timeout:
+			t.Fatalf("timed out, requested guilds %v", got)
+		}
+	}
+	select {
+	case id := <-requested:
+		t.Errorf("unexpected chunk request for guild %s", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if !slices.Equal(got, []Snowflake{1, 3}) {
+		t.Errorf("requested guilds %v, want [1 3]", got)
+	}
+}
+
+func TestGuildMembersChunkCachesMembers(t *testing.T) {
+	client := newTestClient(t, nil)
+	client.handlersManagers["GUILD_MEMBERS_CHUNK"].handleEvent(client, false, 0, []byte(`{
+		"guild_id": "1", "chunk_index": 0, "chunk_count": 1,
+		"members": [{"user": {"id": "5", "username": "a"}, "nick": "alpha", "roles": []}]
+	}`))
+
+	member, err := client.GetMember(1, 5).GetErr()
+	if err != nil || member.Nickname != "alpha" {
+		t.Errorf("cached member = %+v, %v", member, err)
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"strconv"
 	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
 )
 
 /***********************
@@ -32,6 +34,13 @@ const discordEpoch = 1420070400000
  ***********************/
 
 // Snowflake is a Discord unique identifier.
+//
+// Convention for optional IDs:
+//   - Fields of objects received from Discord use 0 when no ID is set (e.g. Guild.AfkChannelID),
+//     use IsSet or Option to check them.
+//   - Fields of options structs sent to Discord use optional.Option[Snowflake] when the ID can be
+//     removed, since None (leave unchanged) and Nil (remove) must be told apart. Otherwise they use
+//     Snowflake and 0 is omitted.
 type Snowflake uint64
 
 var (
@@ -67,6 +76,26 @@ func (s Snowflake) UnSet() bool {
 	return s == 0
 }
 
+// IsZero returns true if the Snowflake is zero (unset), same as UnSet.
+//
+// It also makes the `omitzero` JSON tag option omit unset IDs.
+func (s Snowflake) IsZero() bool {
+	return s == 0
+}
+
+// IsSet returns true if the Snowflake is not zero.
+func (s Snowflake) IsSet() bool {
+	return s != 0
+}
+
+// Option converts the Snowflake to an optional, None if it is zero.
+func (s Snowflake) Option() optional.Option[Snowflake] {
+	if s == 0 {
+		return optional.None[Snowflake]()
+	}
+	return optional.Some(s)
+}
+
 // String returns the Snowflake as string.
 func (s Snowflake) String() string {
 	return strconv.FormatUint(uint64(s), 10)
@@ -93,10 +122,33 @@ func (s Snowflake) Sequence() uint64 {
 	return uint64(s) & 0xFFF
 }
 
+// Increment extracts the increment part of the snowflake, same as Sequence
+// but named like in Discord's documentation.
+func (s Snowflake) Increment() uint64 {
+	return s.Sequence()
+}
+
 /***********************
  * Utilities           *
  ***********************/
 
+// NewSnowflakeFromTime returns the smallest snowflake created at t, with zero worker ID, process ID and increment.
+//
+// It's meant for the Before and After parameters of paginated endpoints, to page by time rather than by ID.
+// Times before the Discord epoch (2015-01-01) give 0.
+//
+// Example usage:
+//
+//	// messages of the last hour
+//	opts := dwaz.FetchMessagesOptions{After: dwaz.NewSnowflakeFromTime(time.Now().Add(-time.Hour))}
+func NewSnowflakeFromTime(t time.Time) Snowflake {
+	ms := t.UnixMilli() - discordEpoch
+	if ms <= 0 {
+		return 0
+	}
+	return Snowflake(uint64(ms) << 22)
+}
+
 // ParseSnowflake parses a string into a Snowflake.
 func ParseSnowflake(id string) (Snowflake, error) {
 	v, err := strconv.ParseUint(id, 10, 64)
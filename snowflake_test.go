@@ -0,0 +1,80 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnowflakeOption(t *testing.T) {
+	var unset Snowflake
+	if !unset.IsZero() || unset.IsSet() || unset.Option().IsPresent() {
+		t.Errorf("zero snowflake: IsZero=%v IsSet=%v Option=%v", unset.IsZero(), unset.IsSet(), unset.Option())
+	}
+
+	id := Snowflake(42)
+	if id.IsZero() || !id.IsSet() {
+		t.Errorf("set snowflake: IsZero=%v IsSet=%v", id.IsZero(), id.IsSet())
+	}
+	if got := id.Option(); !got.IsPresent() || got.Get() != 42 {
+		t.Errorf("Option() = %v, want Some(42)", got)
+	}
+}
+
+func TestGuildOptionalChannelAccessors(t *testing.T) {
+	guild := Guild{AfkChannelID: 10, RulesChannelID: 11}
+
+	if got := guild.AfkChannel(); got.OrEmpty() != 10 {
+		t.Errorf("AfkChannel() = %v, want Some(10)", got)
+	}
+	if got := guild.RulesChannel(); got.OrEmpty() != 11 {
+		t.Errorf("RulesChannel() = %v, want Some(11)", got)
+	}
+	if got := guild.SystemChannel(); got.IsPresent() {
+		t.Errorf("SystemChannel() = %v, want None", got)
+	}
+
+	var channel TextChannel
+	if channel.Parent().IsPresent() {
+		t.Error("Parent() of an uncategorized channel should be None")
+	}
+	channel.ParentID = 5
+	if got := channel.Parent(); got.OrEmpty() != 5 {
+		t.Errorf("Parent() = %v, want Some(5)", got)
+	}
+}
+
+func TestSnowflakeComponents(t *testing.T) {
+	// example snowflake of Discord's documentation
+	s := Snowflake(175928847299117063)
+
+	if want := time.Date(2016, 4, 30, 11, 18, 25, 796_000_000, time.UTC); !s.Timestamp().Equal(want) {
+		t.Errorf("Timestamp() = %v, want %v", s.Timestamp().UTC(), want)
+	}
+	if s.WorkerID() != 1 || s.ProcessID() != 0 || s.Increment() != 7 {
+		t.Errorf("components = worker %d, process %d, increment %d, want 1, 0, 7", s.WorkerID(), s.ProcessID(), s.Increment())
+	}
+
+	boundary := NewSnowflakeFromTime(s.Timestamp())
+	if !boundary.Timestamp().Equal(s.Timestamp()) || boundary.WorkerID() != 0 || boundary.ProcessID() != 0 || boundary.Increment() != 0 {
+		t.Errorf("NewSnowflakeFromTime() = %d, want a boundary snowflake at %v", boundary, s.Timestamp())
+	}
+	if boundary > s || boundary+1<<22 <= s {
+		t.Errorf("NewSnowflakeFromTime() = %d, want the smallest snowflake of the millisecond of %d", boundary, s)
+	}
+	if got := NewSnowflakeFromTime(time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)); got != 0 {
+		t.Errorf("NewSnowflakeFromTime() before the Discord epoch = %d, want 0", got)
+	}
+}
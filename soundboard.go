@@ -13,9 +13,21 @@
 
 package dwaz
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
+
 type SoundBoardSound struct {
 	// SoundID is the sound's unique Discord snowflake ID.
-	SoundID Snowflake `json:"id"`
+	SoundID Snowflake `json:"sound_id"`
 
 	// Name is the sound's name.
 	Name string `json:"name"`
@@ -79,3 +91,322 @@ func (s *SoundBoardSound) Save(fileName, dir string) (string, error) {
 	}
 	return DownloadFile(s.URL(), fileName, dir)
 }
+
+// Base64Sound represents a base64-encoded MP3 or OGG sound data URI string.
+type Base64Sound = string
+
+// NewSoundFile reads an MP3 or OGG sound file and returns its base64 data URI string.
+//
+// Example output: "data:audio/mpeg;base64,<base64-encoded-bytes>"
+func NewSoundFile(path string) (Base64Sound, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read file: %w", err)
+	}
+
+	mimeType := http.DetectContentType(data)
+	switch mimeType {
+	case "audio/mpeg":
+	case "application/ogg", "audio/ogg":
+		mimeType = "audio/ogg"
+	default:
+		return "", fmt.Errorf("not an mp3 or ogg file: detected MIME type %s", mimeType)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, encoded), nil
+}
+
+/*****************************
+ *     Soundboard REST
+ *****************************/
+
+// SendSoundboardSoundOptions contains parameters for playing a soundboard sound.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#send-soundboard-sound-json-params
+type SendSoundboardSoundOptions struct {
+	// SoundID is the ID of the soundboard sound to play.
+	SoundID Snowflake `json:"sound_id"`
+
+	// SourceGuildID is the ID of the guild the sound is from, required to play sounds from other guilds.
+	SourceGuildID Snowflake `json:"source_guild_id,omitempty"`
+}
+
+// SendSoundboardSound plays a soundboard sound in a voice channel the current user is connected to.
+//
+// Requires the PermissionSpeak and PermissionUseSoundboard permissions, and PermissionUseExternalSounds
+// for sounds from other guilds. The current user must not be deafened, muted or suppressed.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#send-soundboard-sound
+func (r *requester) SendSoundboardSound(channelID Snowflake, opts SendSoundboardSoundOptions) result.Void {
+	reqBody, err := json.Marshal(opts)
+	if err != nil {
+		return result.ErrVoid(err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/channels/" + channelID.String() + "/send-soundboard-sound",
+		Body:   reqBody,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// ErrVoiceNotConnected is returned when an action requires the bot to be connected to a voice channel it isn't connected to.
+var ErrVoiceNotConnected = errors.New("not connected to the voice channel")
+
+// SendSoundboardSoundChecked plays a soundboard sound in the voice channel the bot is connected to.
+//
+// sourceGuildID is the guild the sound is from. It's required for external sounds, from a guild
+// other than the one of the voice channel, and needs the PermissionUseExternalSounds permission.
+//
+// Returns ErrVoiceNotConnected, without sending the request, if the cached voice state of the bot
+// isn't in channelID. This relies on the CacheFlagChannels and CacheFlagVoiceStates cache flags
+// and the GatewayIntentGuildVoiceStates intent, use SendSoundboardSound to skip the check.
+func (c *Client) SendSoundboardSoundChecked(channelID, soundID Snowflake, sourceGuildID optional.Option[Snowflake]) result.Void {
+	if !c.connectedToVoice(channelID) {
+		return result.ErrVoid(fmt.Errorf("SendSoundboardSoundChecked: %w", ErrVoiceNotConnected))
+	}
+	return c.requester.SendSoundboardSound(channelID, SendSoundboardSoundOptions{
+		SoundID:       soundID,
+		SourceGuildID: sourceGuildID.OrEmpty(),
+	})
+}
+
+// connectedToVoice reports whether the cached voice state of the bot is in the given channel.
+func (c *Client) connectedToVoice(channelID Snowflake) bool {
+	channel, err := c.GetChannel(channelID).GetErr()
+	if err != nil {
+		return false
+	}
+	guildChannel, ok := channel.(GuildChannel)
+	if !ok {
+		return false
+	}
+	state, err := c.GetVoiceState(guildChannel.GetGuildID(), c.SelfID()).GetErr()
+	return err == nil && state.ChannelID == channelID
+}
+
+// ListDefaultSoundboardSounds returns the default soundboard sounds that can be used by all users.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#list-default-soundboard-sounds
+func (r *requester) ListDefaultSoundboardSounds() result.Result[[]SoundBoardSound] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/soundboard-default-sounds"})
+	if res.IsErr() {
+		return result.Err[[]SoundBoardSound](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var sounds []SoundBoardSound
+	if err := json.NewDecoder(body).Decode(&sounds); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/soundboard-default-sounds",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]SoundBoardSound](err)
+	}
+	return result.Ok(sounds)
+}
+
+// ListGuildSoundboardSounds returns the soundboard sounds of a guild.
+//
+// Requires the PermissionCreateGuildExpressions or PermissionManageGuildExpressions permission.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#list-guild-soundboard-sounds
+func (r *requester) ListGuildSoundboardSounds(guildID Snowflake) result.Result[[]SoundBoardSound] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/guilds/" + guildID.String() + "/soundboard-sounds"})
+	if res.IsErr() {
+		return result.Err[[]SoundBoardSound](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var list struct {
+		Items []SoundBoardSound `json:"items"`
+	}
+	if err := json.NewDecoder(body).Decode(&list); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/soundboard-sounds",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]SoundBoardSound](err)
+	}
+	for i := range list.Items {
+		list.Items[i].GuildID = guildID
+	}
+	return result.Ok(list.Items)
+}
+
+// FetchGuildSoundboardSound returns a soundboard sound of a guild.
+//
+// Requires the PermissionCreateGuildExpressions or PermissionManageGuildExpressions permission.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#get-guild-soundboard-sound
+func (r *requester) FetchGuildSoundboardSound(guildID, soundID Snowflake) result.Result[SoundBoardSound] {
+	res := r.DoRequest(Request{
+		Method: "GET",
+		URL:    "/guilds/" + guildID.String() + "/soundboard-sounds/" + soundID.String(),
+	})
+	if res.IsErr() {
+		return result.Err[SoundBoardSound](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var sound SoundBoardSound
+	if err := json.NewDecoder(body).Decode(&sound); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/soundboard-sounds/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[SoundBoardSound](err)
+	}
+	sound.GuildID = guildID
+	return result.Ok(sound)
+}
+
+// CreateGuildSoundboardSoundOptions contains parameters for creating a guild soundboard sound.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#create-guild-soundboard-sound-json-params
+type CreateGuildSoundboardSoundOptions struct {
+	// Name is the name of the sound (2-32 characters).
+	Name string `json:"name"`
+
+	// Sound is the MP3 or OGG sound data as a data URI, see NewSoundFile.
+	//
+	// Note:
+	//   - The sound is limited to 512kb and 5.2 seconds.
+	Sound Base64Sound `json:"sound"`
+
+	// Volume is the volume of the sound, from 0 to 1, defaults to 1.
+	Volume optional.Option[float64] `json:"volume,omitzero"`
+
+	// EmojiID is the ID of the custom emoji for the sound.
+	EmojiID Snowflake `json:"emoji_id,omitempty"`
+
+	// EmojiName is the unicode character of the standard emoji for the sound.
+	EmojiName string `json:"emoji_name,omitempty"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// CreateGuildSoundboardSound creates a soundboard sound in a guild.
+//
+// Requires the PermissionCreateGuildExpressions permission.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#create-guild-soundboard-sound
+func (r *requester) CreateGuildSoundboardSound(guildID Snowflake, opts CreateGuildSoundboardSoundOptions) result.Result[SoundBoardSound] {
+	reqBody, err := json.Marshal(opts)
+	if err != nil {
+		return result.Err[SoundBoardSound](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/guilds/" + guildID.String() + "/soundboard-sounds",
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[SoundBoardSound](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var sound SoundBoardSound
+	if err := json.NewDecoder(body).Decode(&sound); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/guilds/{id}/soundboard-sounds",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[SoundBoardSound](err)
+	}
+	sound.GuildID = guildID
+	return result.Ok(sound)
+}
+
+// ModifyGuildSoundboardSoundOptions contains parameters for modifying a guild soundboard sound.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#modify-guild-soundboard-sound-json-params
+type ModifyGuildSoundboardSoundOptions struct {
+	// Name is the name of the sound (2-32 characters).
+	Name optional.Option[string] `json:"name,omitzero"`
+
+	// Volume is the volume of the sound, from 0 to 1, optional.Nil resets it to 1.
+	Volume optional.Option[float64] `json:"volume,omitzero"`
+
+	// EmojiID is the ID of the custom emoji for the sound.
+	EmojiID optional.Option[Snowflake] `json:"emoji_id,omitzero"`
+
+	// EmojiName is the unicode character of the standard emoji for the sound.
+	EmojiName optional.Option[string] `json:"emoji_name,omitzero"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// ModifyGuildSoundboardSound modifies a soundboard sound of a guild.
+//
+// Requires the PermissionManageGuildExpressions permission, or PermissionCreateGuildExpressions
+// for sounds created by the current user.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#modify-guild-soundboard-sound
+func (r *requester) ModifyGuildSoundboardSound(guildID, soundID Snowflake, opts ModifyGuildSoundboardSoundOptions) result.Result[SoundBoardSound] {
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[SoundBoardSound](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "PATCH",
+		URL:    "/guilds/" + guildID.String() + "/soundboard-sounds/" + soundID.String(),
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[SoundBoardSound](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var sound SoundBoardSound
+	if err := json.NewDecoder(body).Decode(&sound); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/guilds/{id}/soundboard-sounds/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[SoundBoardSound](err)
+	}
+	sound.GuildID = guildID
+	return result.Ok(sound)
+}
+
+// DeleteGuildSoundboardSound deletes a soundboard sound of a guild.
+//
+// Requires the PermissionManageGuildExpressions permission, or PermissionCreateGuildExpressions
+// for sounds created by the current user.
+//
+// Reference: https://discord.com/developers/docs/resources/soundboard#delete-guild-soundboard-sound
+func (r *requester) DeleteGuildSoundboardSound(guildID, soundID Snowflake, reason string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/guilds/" + guildID.String() + "/soundboard-sounds/" + soundID.String(),
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
@@ -0,0 +1,144 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+const testSoundData = "data:audio/ogg;base64,T2dnUwACAAAAAAAAAAA="
+
+func TestCreateGuildSoundboardSound(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/guilds/1/soundboard-sounds" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["name"] != "airhorn" || body["sound"] != testSoundData || body["emoji_name"] != "📯" {
+			t.Errorf("body = %v", body)
+		}
+		if body["volume"] != 0.5 {
+			t.Errorf("volume = %v, want 0.5", body["volume"])
+		}
+		if _, ok := body["emoji_id"]; ok {
+			t.Error("unset emoji_id should be omitted")
+		}
+		io.WriteString(w, `{"sound_id":"70","name":"airhorn","volume":0.5,"emoji_name":"📯","available":true}`)
+	})
+
+	res := r.CreateGuildSoundboardSound(1, CreateGuildSoundboardSoundOptions{
+		Name:      "airhorn",
+		Sound:     testSoundData,
+		Volume:    optional.Some(0.5),
+		EmojiName: "📯",
+	})
+	if res.IsErr() {
+		t.Fatalf("CreateGuildSoundboardSound: %v", res.Err())
+	}
+	if sound := res.Value(); sound.SoundID != 70 || sound.Name != "airhorn" || sound.GuildID != 1 {
+		t.Errorf("sound = %+v", sound)
+	}
+}
+
+func TestSendSoundboardSound(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/channels/10/send-soundboard-sound" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"sound_id":"70","source_guild_id":"2"}` {
+			t.Errorf("body = %s", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if res := r.SendSoundboardSound(10, SendSoundboardSoundOptions{SoundID: 70, SourceGuildID: 2}); res.IsErr() {
+		t.Fatalf("SendSoundboardSound: %v", res.Err())
+	}
+}
+
+func TestClientSendSoundboardSoundNotConnected(t *testing.T) {
+	var sent bool
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		sent = true
+		if req.Method != http.MethodPost || req.URL.Path != "/channels/11/send-soundboard-sound" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client.selfID.Store(2)
+	client.PutChannel(&VoiceChannel{GuildChannelFields: GuildChannelFields{ChannelFields: ChannelFields{ID: 11}, GuildID: 1, Name: "voice"}})
+
+	res := client.SendSoundboardSoundChecked(11, 50, optional.None[Snowflake]())
+	if !errors.Is(res.Err(), ErrVoiceNotConnected) {
+		t.Fatalf("error = %v, want ErrVoiceNotConnected", res.Err())
+	}
+
+	client.PutVoiceState(VoiceState{GuildID: 1, ChannelID: 12, UserID: 2})
+	if res := client.SendSoundboardSoundChecked(11, 50, optional.None[Snowflake]()); !errors.Is(res.Err(), ErrVoiceNotConnected) {
+		t.Fatalf("connected to another channel: error = %v, want ErrVoiceNotConnected", res.Err())
+	}
+	if sent {
+		t.Fatal("request sent while not connected")
+	}
+
+	client.PutVoiceState(VoiceState{GuildID: 1, ChannelID: 11, UserID: 2})
+	if res := client.SendSoundboardSoundChecked(11, 50, optional.Some[Snowflake](3)); res.IsErr() {
+		t.Fatalf("SendSoundboardSoundChecked: %v", res.Err())
+	}
+	if !sent {
+		t.Error("request not sent while connected")
+	}
+
+	// the unchecked variant stays available on the client.
+	client.PutVoiceState(VoiceState{GuildID: 1, ChannelID: 12, UserID: 2})
+	if res := client.SendSoundboardSound(11, SendSoundboardSoundOptions{SoundID: 50}); res.IsErr() {
+		t.Fatalf("SendSoundboardSound: %v", res.Err())
+	}
+}
+
+func TestClientSendSoundboardSoundAfterVoiceStateUpdate(t *testing.T) {
+	var sent int
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client.selfID.Store(2)
+	client.PutChannel(&VoiceChannel{GuildChannelFields: GuildChannelFields{ChannelFields: ChannelFields{ID: 11}, GuildID: 1, Name: "voice"}})
+
+	// no OnVoiceStateUpdate handler is registered, the voice state is cached by default.
+	client.handlersManagers["VOICE_STATE_UPDATE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id": "1", "channel_id": "11", "user_id": "2", "session_id": "s"}`))
+	if res := client.SendSoundboardSoundChecked(11, 50, optional.None[Snowflake]()); res.IsErr() {
+		t.Fatalf("after joining: %v", res.Err())
+	}
+
+	client.handlersManagers["VOICE_STATE_UPDATE"].handleEvent(client, false, 0,
+		[]byte(`{"guild_id": "1", "channel_id": "12", "user_id": "2", "session_id": "s"}`))
+	if res := client.SendSoundboardSoundChecked(11, 50, optional.None[Snowflake]()); !errors.Is(res.Err(), ErrVoiceNotConnected) {
+		t.Fatalf("after moving: error = %v, want ErrVoiceNotConnected", res.Err())
+	}
+	if sent != 1 {
+		t.Errorf("sent %d requests, want 1", sent)
+	}
+}
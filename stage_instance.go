@@ -13,7 +13,16 @@
 
 package dwaz
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
 
 // StagePrivacyLevel represents the privacy level of a Discord stage instance.
 //
@@ -21,13 +30,20 @@ import "time"
 type StagePrivacyLevel int
 
 const (
-	// The Stage instance is visible publicly. (deprecated)
+	// The Stage instance is visible publicly.
+	//
+	// Deprecated: Discord no longer allows creating public Stage instances, use StagePrivacyLevelGuildOnly.
 	StagePrivacyLevelPublic StagePrivacyLevel = iota + 1
 
 	// The Stage instance is visible to only guild members.
 	StagePrivacyLevelGuildOnly
 )
 
+// Is returns true if the privacy level matches the provided one.
+func (l StagePrivacyLevel) Is(privacyLevel StagePrivacyLevel) bool {
+	return l == privacyLevel
+}
+
 // StageInstance represent a Discord stage instance.
 //
 // Reference: https://discord.com/developers/docs/resources/stage-instance#stage-instance-object
@@ -49,9 +65,207 @@ type StageInstance struct {
 
 	// DiscoverableDisabled is whether or not Stage Discovery is disabled (deprecated)
 	DiscoverableDisabled bool `json:"discoverable_disabled"`
+
+	// GuildScheduledEventID is the id of the scheduled event for this Stage instance
+	//
+	// Optional:
+	//   - Will be 0 if the Stage instance is not linked to a scheduled event.
+	GuildScheduledEventID Snowflake `json:"guild_scheduled_event_id"`
 }
 
 // CreatedAt returns the time when this stage instance is created.
 func (s *StageInstance) CreatedAt() time.Time {
 	return s.ID.Timestamp()
 }
+
+/*****************************
+ *   Stage Instances REST
+ *****************************/
+
+// CreateStageInstanceOptions contains parameters for creating a stage instance.
+//
+// Reference: https://discord.com/developers/docs/resources/stage-instance#create-stage-instance-json-params
+type CreateStageInstanceOptions struct {
+	// ChannelID is the id of the Stage channel.
+	ChannelID Snowflake `json:"channel_id"`
+
+	// Topic is the topic of the Stage instance (1-120 characters).
+	Topic string `json:"topic"`
+
+	// PrivacyLevel is the privacy level of the Stage instance.
+	//
+	// Note:
+	//   - Defaults to StagePrivacyLevelGuildOnly if not set.
+	PrivacyLevel StagePrivacyLevel `json:"privacy_level,omitempty"`
+
+	// SendStartNotification notifies @everyone that a Stage instance has started.
+	//
+	// Note:
+	//   - Requires the PermissionMentionEveryone permission.
+	SendStartNotification bool `json:"send_start_notification,omitempty"`
+
+	// GuildScheduledEventID is the scheduled event associated with this Stage instance.
+	GuildScheduledEventID Snowflake `json:"guild_scheduled_event_id,omitempty"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// Validate checks the topic length and the privacy level of the options.
+//
+// A zero PrivacyLevel is valid, Discord defaults it to StagePrivacyLevelGuildOnly.
+func (o *CreateStageInstanceOptions) Validate() error {
+	if o.ChannelID == 0 {
+		return errors.New("CreateStageInstanceOptions: channel id is required")
+	}
+	if n := utf8.RuneCountInString(o.Topic); n < 1 || n > 120 {
+		return fmt.Errorf("CreateStageInstanceOptions: topic must be 1-120 characters, got %d", n)
+	}
+	if o.PrivacyLevel != 0 && !o.PrivacyLevel.Is(StagePrivacyLevelGuildOnly) {
+		return fmt.Errorf("CreateStageInstanceOptions: privacy level must be StagePrivacyLevelGuildOnly, got %d", o.PrivacyLevel)
+	}
+	return nil
+}
+
+// CreateStageInstance creates a new Stage instance associated to a Stage channel.
+//
+// The options are checked with Validate before sending the request.
+//
+// Requires the PermissionManageChannels, PermissionMuteMembers and PermissionMoveMembers permissions.
+//
+// Reference: https://discord.com/developers/docs/resources/stage-instance#create-stage-instance
+func (r *requester) CreateStageInstance(opts CreateStageInstanceOptions) result.Result[StageInstance] {
+	if err := opts.Validate(); err != nil {
+		return result.Err[StageInstance](err)
+	}
+	reqBody, err := json.Marshal(opts)
+	if err != nil {
+		return result.Err[StageInstance](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/stage-instances",
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[StageInstance](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var stage StageInstance
+	if err := json.NewDecoder(body).Decode(&stage); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/stage-instances",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[StageInstance](err)
+	}
+	return result.Ok(stage)
+}
+
+// CreateStageInstance creates a new Stage instance associated to a Stage channel.
+//
+// Unlike the REST-only version, it also returns an error without sending the request
+// when the cached channel isn't a Stage channel. Uncached channels are left to Discord.
+//
+// Requires the PermissionManageChannels, PermissionMuteMembers and PermissionMoveMembers permissions.
+func (c *Client) CreateStageInstance(opts CreateStageInstanceOptions) result.Result[StageInstance] {
+	if channel, err := c.GetChannel(opts.ChannelID).GetErr(); err == nil && channel.GetType() != ChannelTypeGuildStageVoice {
+		return result.Err[StageInstance](fmt.Errorf("CreateStageInstance: channel %s of type %d is not a stage channel", opts.ChannelID, channel.GetType()))
+	}
+	return c.requester.CreateStageInstance(opts)
+}
+
+// FetchStageInstance returns the Stage instance of a Stage channel, if it exists.
+//
+// Reference: https://discord.com/developers/docs/resources/stage-instance#get-stage-instance
+func (r *requester) FetchStageInstance(channelID Snowflake) result.Result[StageInstance] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/stage-instances/" + channelID.String()})
+	if res.IsErr() {
+		return result.Err[StageInstance](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var stage StageInstance
+	if err := json.NewDecoder(body).Decode(&stage); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/stage-instances/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[StageInstance](err)
+	}
+	return result.Ok(stage)
+}
+
+// ModifyStageInstanceOptions contains parameters for modifying a stage instance.
+//
+// Reference: https://discord.com/developers/docs/resources/stage-instance#modify-stage-instance-json-params
+type ModifyStageInstanceOptions struct {
+	// Topic is the topic of the Stage instance (1-120 characters).
+	Topic optional.Option[string] `json:"topic,omitzero"`
+
+	// PrivacyLevel is the privacy level of the Stage instance.
+	PrivacyLevel optional.Option[StagePrivacyLevel] `json:"privacy_level,omitzero"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// ModifyStageInstance updates the fields of the Stage instance of a Stage channel.
+//
+// Requires the PermissionManageChannels, PermissionMuteMembers and PermissionMoveMembers permissions.
+//
+// Reference: https://discord.com/developers/docs/resources/stage-instance#modify-stage-instance
+func (r *requester) ModifyStageInstance(channelID Snowflake, opts ModifyStageInstanceOptions) result.Result[StageInstance] {
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[StageInstance](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "PATCH",
+		URL:    "/stage-instances/" + channelID.String(),
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[StageInstance](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var stage StageInstance
+	if err := json.NewDecoder(body).Decode(&stage); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/stage-instances/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[StageInstance](err)
+	}
+	return result.Ok(stage)
+}
+
+// DeleteStageInstance deletes the Stage instance of a Stage channel.
+//
+// Requires the PermissionManageChannels, PermissionMuteMembers and PermissionMoveMembers permissions.
+//
+// Reference: https://discord.com/developers/docs/resources/stage-instance#delete-stage-instance
+func (r *requester) DeleteStageInstance(channelID Snowflake, reason string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/stage-instances/" + channelID.String(),
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
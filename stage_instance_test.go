@@ -0,0 +1,102 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCreateStageInstance(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/stage-instances" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["channel_id"] != "10" || body["topic"] != "Town hall" || body["send_start_notification"] != true {
+			t.Errorf("body = %v", body)
+		}
+		if body["guild_scheduled_event_id"] != "30" {
+			t.Errorf("guild_scheduled_event_id = %v", body["guild_scheduled_event_id"])
+		}
+		if _, ok := body["privacy_level"]; ok {
+			t.Error("unset privacy_level should be omitted")
+		}
+		io.WriteString(w, `{
+			"id": "50", "guild_id": "1", "channel_id": "10", "topic": "Town hall",
+			"privacy_level": 2, "discoverable_disabled": false, "guild_scheduled_event_id": "30"
+		}`)
+	})
+
+	res := r.CreateStageInstance(CreateStageInstanceOptions{
+		ChannelID:             10,
+		Topic:                 "Town hall",
+		SendStartNotification: true,
+		GuildScheduledEventID: 30,
+	})
+	if res.IsErr() {
+		t.Fatalf("CreateStageInstance: %v", res.Err())
+	}
+	stage := res.Value()
+	if stage.ID != 50 || stage.ChannelID != 10 || stage.PrivacyLevel != StagePrivacyLevelGuildOnly || stage.GuildScheduledEventID != 30 {
+		t.Errorf("stage = %+v", stage)
+	}
+}
+
+func TestCreateStageInstanceRejectsNonStageChannel(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		io.WriteString(w, `{"id": "50", "channel_id": "11", "topic": "Town hall", "privacy_level": 2}`)
+	})
+	client.PutChannel(&TextChannel{GuildChannelFields: GuildChannelFields{ChannelFields: ChannelFields{ID: 10, Type: ChannelTypeGuildText}, GuildID: 1}})
+	client.PutChannel(&StageVoiceChannel{GuildChannelFields: GuildChannelFields{ChannelFields: ChannelFields{ID: 11, Type: ChannelTypeGuildStageVoice}, GuildID: 1}})
+
+	if res := client.CreateStageInstance(CreateStageInstanceOptions{ChannelID: 10, Topic: "Town hall"}); !res.IsErr() {
+		t.Error("expected an error for a text channel")
+	}
+	if requests != 0 {
+		t.Fatalf("invalid call sent %d requests, want 0", requests)
+	}
+
+	if res := client.CreateStageInstance(CreateStageInstanceOptions{ChannelID: 11, Topic: "Town hall"}); res.IsErr() {
+		t.Fatalf("CreateStageInstance: %v", res.Err())
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+}
+
+func TestCreateStageInstanceOptionsValidate(t *testing.T) {
+	invalid := []CreateStageInstanceOptions{
+		{Topic: "Town hall"},
+		{ChannelID: 10},
+		{ChannelID: 10, Topic: "Town hall", PrivacyLevel: StagePrivacyLevelPublic},
+	}
+	for _, opts := range invalid {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", opts)
+		}
+	}
+
+	valid := CreateStageInstanceOptions{ChannelID: 10, Topic: "Town hall", PrivacyLevel: StagePrivacyLevelGuildOnly}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
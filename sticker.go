@@ -13,7 +13,13 @@
 
 package dwaz
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
 
 // StickerType defines whether the sticker is a standard or guild sticker.
 //
@@ -150,3 +156,246 @@ func (p *StickerPack) BannerURLWith(format ImageFormat, size ImageSize) string {
 	}
 	return ""
 }
+
+// FetchSticker retrieves a sticker by its ID.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#get-sticker
+func (r *requester) FetchSticker(stickerID Snowflake) result.Result[Sticker] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/stickers/" + stickerID.String()})
+	if res.IsErr() {
+		return result.Err[Sticker](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var sticker Sticker
+	if err := json.NewDecoder(body).Decode(&sticker); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/stickers/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Sticker](err)
+	}
+	return result.Ok(sticker)
+}
+
+// stickerPacksResponse is the response of the list sticker packs endpoint,
+// which wraps the packs in a "sticker_packs" object.
+type stickerPacksResponse struct {
+	StickerPacks []StickerPack `json:"sticker_packs"`
+}
+
+// FetchStickerPacks retrieves the list of available standard sticker packs.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#list-sticker-packs
+func (r *requester) FetchStickerPacks() result.Result[[]StickerPack] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/sticker-packs"})
+	if res.IsErr() {
+		return result.Err[[]StickerPack](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var response stickerPacksResponse
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/sticker-packs",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]StickerPack](err)
+	}
+	return result.Ok(response.StickerPacks)
+}
+
+// FetchGuildStickers retrieves the stickers of a guild.
+//
+// Includes user fields if the bot has the PermissionCreateGuildExpressions or PermissionManageGuildExpressions permission.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#list-guild-stickers
+func (r *requester) FetchGuildStickers(guildID Snowflake) result.Result[[]Sticker] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/guilds/" + guildID.String() + "/stickers"})
+	if res.IsErr() {
+		return result.Err[[]Sticker](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var stickers []Sticker
+	if err := json.NewDecoder(body).Decode(&stickers); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/stickers",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]Sticker](err)
+	}
+	for i := range stickers {
+		stickers[i].GuildID = guildID
+	}
+	return result.Ok(stickers)
+}
+
+// FetchGuildSticker retrieves a sticker of a guild.
+//
+// Includes the user field if the bot has the PermissionCreateGuildExpressions or PermissionManageGuildExpressions permission.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#get-guild-sticker
+func (r *requester) FetchGuildSticker(guildID, stickerID Snowflake) result.Result[Sticker] {
+	res := r.DoRequest(Request{
+		Method: "GET",
+		URL:    "/guilds/" + guildID.String() + "/stickers/" + stickerID.String(),
+	})
+	if res.IsErr() {
+		return result.Err[Sticker](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var sticker Sticker
+	if err := json.NewDecoder(body).Decode(&sticker); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/stickers/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Sticker](err)
+	}
+	sticker.GuildID = guildID
+	return result.Ok(sticker)
+}
+
+// CreateGuildStickerOptions contains parameters for creating a new guild sticker.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#create-guild-sticker-form-params
+type CreateGuildStickerOptions struct {
+	// Name is the name of the sticker (2-30 characters).
+	Name string
+
+	// Description is the description of the sticker (empty or 2-100 characters).
+	Description string
+
+	// Tags is the autocomplete/suggestion tags for the sticker (max 200 characters).
+	Tags string
+
+	// File is the sticker image to upload, a PNG, APNG, GIF or Lottie JSON file of max 512 KiB.
+	File File
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string
+}
+
+// CreateGuildSticker creates a new sticker for the guild.
+//
+// Requires the PermissionCreateGuildExpressions permission.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#create-guild-sticker
+func (r *requester) CreateGuildSticker(guildID Snowflake, opts CreateGuildStickerOptions) result.Result[Sticker] {
+	reqBody, contentType, err := encodeMultipartForm([]multipartField{
+		{name: "name", value: opts.Name},
+		{name: "description", value: opts.Description},
+		{name: "tags", value: opts.Tags},
+	}, "file", opts.File)
+	if err != nil {
+		return result.Err[Sticker](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method:      "POST",
+		URL:         "/guilds/" + guildID.String() + "/stickers",
+		Body:        reqBody,
+		ContentType: contentType,
+		Reason:      opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[Sticker](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var sticker Sticker
+	if err := json.NewDecoder(body).Decode(&sticker); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/guilds/{id}/stickers",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Sticker](err)
+	}
+	sticker.GuildID = guildID
+	return result.Ok(sticker)
+}
+
+// ModifyGuildStickerOptions contains parameters for modifying an existing guild sticker.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#modify-guild-sticker-json-params
+type ModifyGuildStickerOptions struct {
+	// Name is the name of the sticker (2-30 characters).
+	Name optional.Option[string] `json:"name,omitzero"`
+
+	// Description is the description of the sticker (2-100 characters), optional.Nil removes it.
+	Description optional.Option[string] `json:"description,omitzero"`
+
+	// Tags is the autocomplete/suggestion tags for the sticker (max 200 characters).
+	Tags optional.Option[string] `json:"tags,omitzero"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// ModifyGuildSticker updates the given sticker.
+//
+// For stickers created by the current user, requires either the PermissionCreateGuildExpressions or PermissionManageGuildExpressions permission.
+// For other stickers, requires the PermissionManageGuildExpressions permission.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#modify-guild-sticker
+func (r *requester) ModifyGuildSticker(guildID, stickerID Snowflake, opts ModifyGuildStickerOptions) result.Result[Sticker] {
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[Sticker](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "PATCH",
+		URL:    "/guilds/" + guildID.String() + "/stickers/" + stickerID.String(),
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[Sticker](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var sticker Sticker
+	if err := json.NewDecoder(body).Decode(&sticker); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/guilds/{id}/stickers/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Sticker](err)
+	}
+	sticker.GuildID = guildID
+	return result.Ok(sticker)
+}
+
+// DeleteGuildSticker deletes the given sticker.
+//
+// For stickers created by the current user, requires either the PermissionCreateGuildExpressions or PermissionManageGuildExpressions permission.
+// For other stickers, requires the PermissionManageGuildExpressions permission.
+//
+// Reference: https://discord.com/developers/docs/resources/sticker#delete-guild-sticker
+func (r *requester) DeleteGuildSticker(guildID, stickerID Snowflake, reason string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/guilds/" + guildID.String() + "/stickers/" + stickerID.String(),
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
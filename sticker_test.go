@@ -0,0 +1,106 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+func TestCreateGuildStickerMultipart(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/guilds/1/stickers" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse multipart: %v", err)
+		}
+		if req.FormValue("name") != "wave" || req.FormValue("description") != "waving hand" || req.FormValue("tags") != "👋" {
+			t.Errorf("fields = %v", req.MultipartForm.Value)
+		}
+
+		file, header, err := req.FormFile("file")
+		if err != nil {
+			t.Fatalf("file: %v", err)
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if header.Filename != "wave.png" || string(data) != "png data" {
+			t.Errorf("file = %q %q", header.Filename, data)
+		}
+
+		io.WriteString(w, `{"id":"300","name":"wave","tags":"👋","type":2,"format_type":1}`)
+	})
+
+	res := r.CreateGuildSticker(1, CreateGuildStickerOptions{
+		Name:        "wave",
+		Description: "waving hand",
+		Tags:        "👋",
+		File:        File{Name: "wave.png", Reader: strings.NewReader("png data")},
+	})
+	if res.IsErr() {
+		t.Fatalf("CreateGuildSticker: %v", res.Err())
+	}
+	if sticker := res.Value(); sticker.ID != 300 || sticker.GuildID != 1 || sticker.Type != StickerTypeGuild {
+		t.Errorf("sticker = %+v", sticker)
+	}
+}
+
+func TestFetchStickerPacks(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/sticker-packs" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		io.WriteString(w, `{"sticker_packs":[{"id":"10","name":"Wumpus","sku_id":"11","description":"pack","stickers":[{"id":"12","name":"hi","tags":"wave","type":1,"format_type":3}]}]}`)
+	})
+
+	res := r.FetchStickerPacks()
+	if res.IsErr() {
+		t.Fatalf("FetchStickerPacks: %v", res.Err())
+	}
+	packs := res.Value()
+	if len(packs) != 1 || packs[0].Name != "Wumpus" || len(packs[0].Stickers) != 1 {
+		t.Fatalf("packs = %+v", packs)
+	}
+	if sticker := packs[0].Stickers[0]; sticker.ID != 12 || sticker.FormatType != StickerFormatTypeLottie {
+		t.Errorf("sticker = %+v", sticker)
+	}
+}
+
+func TestModifyGuildStickerClearsDescription(t *testing.T) {
+	var body string
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch || req.URL.Path != "/guilds/1/stickers/12" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		buf, _ := io.ReadAll(req.Body)
+		body = string(buf)
+		io.WriteString(w, `{"id":"12","name":"hi","tags":"wave","type":2,"format_type":1}`)
+	})
+
+	res := r.ModifyGuildSticker(1, 12, ModifyGuildStickerOptions{Description: optional.Nil[string]()})
+	if res.IsErr() {
+		t.Fatalf("ModifyGuildSticker: %v", res.Err())
+	}
+	if body != `{"description":null}` {
+		t.Errorf("body = %s", body)
+	}
+	if res.Value().GuildID != 1 {
+		t.Errorf("GuildID = %d, want 1", res.Value().GuildID)
+	}
+}
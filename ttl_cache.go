@@ -0,0 +1,112 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"sync"
+	"time"
+
+	"github.com/marouanesouiri/stdx/result"
+)
+
+// ttlEntry is a value of a ttlCache with its expiration time.
+type ttlEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// ttlCache is a map whose entries expire a fixed duration after being put.
+//
+// Expired entries are dropped lazily when read.
+type ttlCache[K comparable, V any] struct {
+	clock Clock
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[K]ttlEntry[V]
+	gen     uint64 // incremented on every deletion, see putIfGen
+}
+
+// newTTLCache creates a ttlCache whose entries live for ttl, measured with clock.
+func newTTLCache[K comparable, V any](clock Clock, ttl time.Duration) *ttlCache[K, V] {
+	return &ttlCache[K, V]{
+		clock:   clock,
+		ttl:     ttl,
+		entries: make(map[K]ttlEntry[V]),
+	}
+}
+
+// get returns the value of key if it is present and not expired.
+func (c *ttlCache[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !c.clock.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// generation returns the current deletion generation, to pass to putIfGen.
+func (c *ttlCache[K, V]) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// putIfGen stores value under key, unless an entry was deleted since gen was read.
+//
+// This keeps a fetch racing with a modification from caching the value it replaced.
+func (c *ttlCache[K, V]) putIfGen(key K, value V, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen != gen {
+		return
+	}
+	c.entries[key] = ttlEntry[V]{value: value, expiresAt: c.clock.Now().Add(c.ttl)}
+}
+
+// del removes the entry of key. It is a no-op on a nil cache.
+func (c *ttlCache[K, V]) del(key K) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	c.gen++
+}
+
+// cachedFetch returns the cached value of key, or calls fetch and caches its result on success.
+// fetch is always called when cache is nil.
+func cachedFetch[K comparable, V any](cache *ttlCache[K, V], key K, fetch func(K) result.Result[V]) result.Result[V] {
+	if cache == nil {
+		return fetch(key)
+	}
+	if value, ok := cache.get(key); ok {
+		return result.Ok(value)
+	}
+	gen := cache.generation()
+	res := fetch(key)
+	if res.IsOk() {
+		cache.putIfGen(key, res.Value(), gen)
+	}
+	return res
+}
@@ -304,7 +304,7 @@ func (u *User) AvatarURL() string {
 	if u.Avatar != "" {
 		return UserAvatarURL(u.ID, u.Avatar, ImageFormatDefault, ImageSizeDefault)
 	}
-	return DefaultUserAvatarURL(u.DefaultAvatarIndex())
+	return u.DefaultAvatarURL()
 }
 
 // AvatarURLWith returns the URL to the user's avatar image,
@@ -323,7 +323,7 @@ func (u *User) AvatarURLWith(format ImageFormat, size ImageSize) string {
 	if u.Avatar != "" {
 		return UserAvatarURL(u.ID, u.Avatar, format, size)
 	}
-	return DefaultUserAvatarURL(u.DefaultAvatarIndex())
+	return u.DefaultAvatarURL()
 }
 
 // BannerURL returns the URL to the user's banner image.
@@ -337,8 +337,8 @@ func (u *User) AvatarURLWith(format ImageFormat, size ImageSize) string {
 //
 //	url := user.BannerURL()
 func (u *User) BannerURL() string {
-	if u.Avatar != "" {
-		return UserBannerURL(u.ID, u.Avatar, ImageFormatDefault, ImageSizeDefault)
+	if u.Banner != "" {
+		return UserBannerURL(u.ID, u.Banner, ImageFormatDefault, ImageSizeDefault)
 	}
 	return ""
 }
@@ -352,8 +352,8 @@ func (u *User) BannerURL() string {
 //
 //	url := user.BannerURLWith(ImageFormatWebP, ImageSize1024)
 func (u *User) BannerURLWith(format ImageFormat, size ImageSize) string {
-	if u.Avatar != "" {
-		return UserBannerURL(u.ID, u.Avatar, format, size)
+	if u.Banner != "" {
+		return UserBannerURL(u.ID, u.Banner, format, size)
 	}
 	return ""
 }
@@ -377,8 +377,8 @@ func (u *User) DisplayName() string {
 // DefaultAvatarIndex returns the index (0-5) used to determine
 // which default avatar is assigned to the user.
 //
-// For users with discriminator "0" (new Discord usernames),
-// it uses the user's snowflake ID shifted right by 22 bits modulo 6.
+// For users with discriminator "0" (new Discord usernames), or without a discriminator
+// like in partial user objects, it uses the user's snowflake ID shifted right by 22 bits modulo 6.
 //
 // For legacy users with a numeric discriminator, it parses the discriminator
 // as an integer and returns modulo 5.
@@ -389,13 +389,22 @@ func (u *User) DisplayName() string {
 //
 //	index := user.DefaultAvatarIndex()
 func (u *User) DefaultAvatarIndex() int {
-	if u.Discriminator == "0" {
+	if u.Discriminator == "0" || u.Discriminator == "" {
 		return int((u.ID >> 22) % 6)
 	}
 	id, _ := strconv.Atoi(u.Discriminator)
 	return id % 5
 }
 
+// DefaultAvatarURL returns the URL to the user's default avatar, the one shown when they have no custom avatar.
+//
+// Example usage:
+//
+//	url := user.DefaultAvatarURL()
+func (u *User) DefaultAvatarURL() string {
+	return DefaultUserAvatarURL(u.DefaultAvatarIndex())
+}
+
 // AvatarDecorationURL returns the URL to the user's avatar decoration.
 //
 // If the user has no avatar decoration, it returns an empty string.
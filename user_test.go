@@ -0,0 +1,41 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import "testing"
+
+func TestUserDefaultAvatar(t *testing.T) {
+	tests := []struct {
+		name  string
+		user  User
+		index int
+	}{
+		// (80351110224678912 >> 22) % 6 == 5
+		{"pomelo", User{ID: 80351110224678912, Discriminator: "0"}, 5},
+		{"no discriminator", User{ID: 80351110224678912}, 5},
+		{"legacy", User{ID: 80351110224678912, Discriminator: "1337"}, 2},
+	}
+	for _, tt := range tests {
+		if got := tt.user.DefaultAvatarIndex(); got != tt.index {
+			t.Errorf("%s: DefaultAvatarIndex() = %d, want %d", tt.name, got, tt.index)
+		}
+		want := ImageBaseURL + "embed/avatars/" + string(rune('0'+tt.index)) + ".png"
+		if got := tt.user.DefaultAvatarURL(); got != want {
+			t.Errorf("%s: DefaultAvatarURL() = %q, want %q", tt.name, got, want)
+		}
+		if got := tt.user.AvatarURL(); got != want {
+			t.Errorf("%s: AvatarURL() without avatar = %q, want %q", tt.name, got, want)
+		}
+	}
+}
@@ -13,7 +13,13 @@
 
 package dwaz
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
 
 // VoiceState represents a user's voice connection status in a guild.
 //
@@ -90,3 +96,110 @@ type VoiceRegion struct {
 	// Used for special events or similar use cases.
 	Custom bool `json:"custom"`
 }
+
+// FetchCurrentUserVoiceState returns the current user's voice state in a guild.
+//
+// Reference: https://discord.com/developers/docs/resources/voice#get-current-user-voice-state
+func (r *requester) FetchCurrentUserVoiceState(guildID Snowflake) result.Result[VoiceState] {
+	return r.fetchVoiceState(guildID, "@me", "/guilds/{id}/voice-states/@me")
+}
+
+// FetchUserVoiceState returns the specified user's voice state in a guild.
+//
+// Reference: https://discord.com/developers/docs/resources/voice#get-user-voice-state
+func (r *requester) FetchUserVoiceState(guildID, userID Snowflake) result.Result[VoiceState] {
+	return r.fetchVoiceState(guildID, userID.String(), "/guilds/{id}/voice-states/{id}")
+}
+
+func (r *requester) fetchVoiceState(guildID Snowflake, user, route string) result.Result[VoiceState] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/guilds/" + guildID.String() + "/voice-states/" + user})
+	if res.IsErr() {
+		return result.Err[VoiceState](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var state VoiceState
+	if err := json.NewDecoder(body).Decode(&state); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    route,
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[VoiceState](err)
+	}
+	return result.Ok(state)
+}
+
+// ModifyCurrentUserVoiceStateOptions contains parameters for modifying the current user's voice state.
+//
+// Reference: https://discord.com/developers/docs/resources/voice#modify-current-user-voice-state-json-params
+type ModifyCurrentUserVoiceStateOptions struct {
+	// ChannelID is the ID of the channel the user is currently in.
+	ChannelID Snowflake `json:"channel_id,omitempty"`
+
+	// Suppress toggles the user's suppress state.
+	Suppress optional.Option[bool] `json:"suppress,omitzero"`
+
+	// RequestToSpeakTimestamp sets the user's request to speak.
+	//
+	// Use optional.Nil to clear the request.
+	RequestToSpeakTimestamp optional.Option[time.Time] `json:"request_to_speak_timestamp,omitzero"`
+}
+
+// ModifyCurrentUserVoiceState updates the current user's voice state in a stage channel.
+//
+// Notes:
+//   - ChannelID must currently point to a stage channel.
+//   - The current user must already have joined ChannelID.
+//   - Requires the PermissionMuteMembers permission to unsuppress yourself.
+//     Suppressing yourself needs no permission.
+//   - Requires the PermissionRequestToSpeak permission to request to speak.
+//     Any present or future time can be set.
+//
+// Reference: https://discord.com/developers/docs/resources/voice#modify-current-user-voice-state
+func (r *requester) ModifyCurrentUserVoiceState(guildID Snowflake, opts ModifyCurrentUserVoiceStateOptions) result.Void {
+	return r.modifyVoiceState(guildID, "@me", opts)
+}
+
+// ModifyUserVoiceStateOptions contains parameters for modifying another user's voice state.
+//
+// Reference: https://discord.com/developers/docs/resources/voice#modify-user-voice-state-json-params
+type ModifyUserVoiceStateOptions struct {
+	// ChannelID is the ID of the channel the user is currently in.
+	ChannelID Snowflake `json:"channel_id"`
+
+	// Suppress toggles the user's suppress state.
+	Suppress optional.Option[bool] `json:"suppress,omitzero"`
+}
+
+// ModifyUserVoiceState updates another user's voice state in a stage channel.
+//
+// Notes:
+//   - ChannelID must currently point to a stage channel.
+//   - The user must already have joined ChannelID.
+//   - Requires the PermissionMuteMembers permission.
+//   - When unsuppressed, non-bot users have their request to speak cleared.
+//
+// Reference: https://discord.com/developers/docs/resources/voice#modify-user-voice-state
+func (r *requester) ModifyUserVoiceState(guildID, userID Snowflake, opts ModifyUserVoiceStateOptions) result.Void {
+	return r.modifyVoiceState(guildID, userID.String(), opts)
+}
+
+func (r *requester) modifyVoiceState(guildID Snowflake, user string, opts any) result.Void {
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.ErrVoid(err)
+	}
+
+	res := r.DoRequest(Request{
+		Method: "PATCH",
+		URL:    "/guilds/" + guildID.String() + "/voice-states/" + user,
+		Body:   reqBody,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
@@ -0,0 +1,92 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+func TestFetchCurrentUserVoiceState(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet || req.URL.Path != "/guilds/1/voice-states/@me" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		io.WriteString(w, `{"guild_id": "1", "channel_id": "10", "user_id": "2", "session_id": "abc", "suppress": true}`)
+	})
+
+	res := r.FetchCurrentUserVoiceState(1)
+	if res.IsErr() {
+		t.Fatalf("FetchCurrentUserVoiceState: %v", res.Err())
+	}
+	state := res.Value()
+	if state.GuildID != 1 || state.ChannelID != 10 || state.UserID != 2 || !state.Suppress {
+		t.Errorf("state = %+v", state)
+	}
+}
+
+func TestModifyCurrentUserVoiceStateBody(t *testing.T) {
+	ts := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		opts ModifyCurrentUserVoiceStateOptions
+		want string
+	}{
+		{"suppress", ModifyCurrentUserVoiceStateOptions{ChannelID: 10, Suppress: Disabled()}, `{"channel_id":"10","suppress":false}`},
+		{"request to speak", ModifyCurrentUserVoiceStateOptions{RequestToSpeakTimestamp: optional.Some(ts)}, `{"request_to_speak_timestamp":"2025-03-01T12:00:00Z"}`},
+		{"clear request", ModifyCurrentUserVoiceStateOptions{RequestToSpeakTimestamp: optional.Nil[time.Time]()}, `{"request_to_speak_timestamp":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+				if req.Method != http.MethodPatch || req.URL.Path != "/guilds/1/voice-states/@me" {
+					t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+				}
+				body, _ := io.ReadAll(req.Body)
+				if string(body) != tt.want {
+					t.Errorf("body = %s, want %s", body, tt.want)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			})
+			if res := r.ModifyCurrentUserVoiceState(1, tt.opts); res.IsErr() {
+				t.Fatalf("ModifyCurrentUserVoiceState: %v", res.Err())
+			}
+		})
+	}
+}
+
+func TestModifyUserVoiceState(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch || req.URL.Path != "/guilds/1/voice-states/2" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["channel_id"] != "10" || body["suppress"] != true {
+			t.Errorf("body = %v", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if res := r.ModifyUserVoiceState(1, 2, ModifyUserVoiceStateOptions{ChannelID: 10, Suppress: Enabled()}); res.IsErr() {
+		t.Fatalf("ModifyUserVoiceState: %v", res.Err())
+	}
+}
@@ -0,0 +1,512 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/marouanesouiri/stdx/optional"
+	"github.com/marouanesouiri/stdx/result"
+)
+
+// WebhookType represents the type of a Discord webhook.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#webhook-object-webhook-types
+type WebhookType int
+
+const (
+	// WebhookTypeIncoming can post messages to channels with a generated token.
+	WebhookTypeIncoming WebhookType = iota + 1
+
+	// WebhookTypeChannelFollower is an internal webhook used with channel following to post new messages into channels.
+	WebhookTypeChannelFollower
+
+	// WebhookTypeApplication is used with interactions.
+	WebhookTypeApplication
+)
+
+// Is returns true if the webhook's Type matches the provided one.
+func (t WebhookType) Is(webhookType WebhookType) bool {
+	return t == webhookType
+}
+
+// Webhook represents a Discord webhook, a low-effort way to post messages to channels.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#webhook-object
+type Webhook struct {
+	// ID is the webhook's unique Discord snowflake ID.
+	ID Snowflake `json:"id"`
+
+	// Type is the type of the webhook.
+	Type WebhookType `json:"type"`
+
+	// GuildID is the guild id this webhook is for.
+	//
+	// Optional:
+	//   - May be 0 for application webhooks.
+	GuildID Snowflake `json:"guild_id"`
+
+	// ChannelID is the channel id this webhook is for.
+	//
+	// Optional:
+	//   - May be 0 for application webhooks.
+	ChannelID Snowflake `json:"channel_id"`
+
+	// User is the user this webhook was created by.
+	//
+	// Optional:
+	//   - Not returned when fetching a webhook with its token.
+	User *User `json:"user"`
+
+	// Name is the default name of the webhook.
+	Name string `json:"name"`
+
+	// Avatar is the default user avatar hash of the webhook.
+	//
+	// Optional:
+	//   - May be empty string if the webhook has no avatar.
+	Avatar string `json:"avatar"`
+
+	// Token is the secure token of the webhook.
+	//
+	// Optional:
+	//   - Only returned for WebhookTypeIncoming webhooks.
+	Token string `json:"token"`
+
+	// ApplicationID is the bot/OAuth2 application that created this webhook.
+	ApplicationID Snowflake `json:"application_id"`
+
+	// SourceGuild is the guild of the channel that this webhook is following.
+	//
+	// Optional:
+	//   - Only returned for WebhookTypeChannelFollower webhooks.
+	SourceGuild *PartialGuild `json:"source_guild"`
+
+	// SourceChannel is the channel that this webhook is following.
+	//
+	// Optional:
+	//   - Only returned for WebhookTypeChannelFollower webhooks.
+	SourceChannel *PartialChannel `json:"source_channel"`
+
+	// URL is the url used for executing the webhook.
+	//
+	// Optional:
+	//   - Only returned by the webhooks OAuth2 flow.
+	URL string `json:"url"`
+}
+
+// CreatedAt returns the time when this webhook was created.
+func (w *Webhook) CreatedAt() time.Time {
+	return w.ID.Timestamp()
+}
+
+// AvatarURL returns the URL to the webhook's default avatar image, or empty string if it has none.
+func (w *Webhook) AvatarURL() string {
+	if w.Avatar != "" {
+		return UserAvatarURL(w.ID, w.Avatar, ImageFormatDefault, ImageSizeDefault)
+	}
+	return ""
+}
+
+// ExecuteURL returns the URL used to execute the webhook, or empty string if its token is unknown.
+//
+// Example output: "https://discord.com/api/webhooks/123456789012345678/token"
+func (w *Webhook) ExecuteURL() string {
+	if w.Token == "" {
+		return ""
+	}
+	return "https://discord.com/api/webhooks/" + w.ID.String() + "/" + w.Token
+}
+
+// CreateWebhookOptions contains parameters for creating a webhook.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#create-webhook-json-params
+type CreateWebhookOptions struct {
+	// Name is the name of the webhook (1-80 characters).
+	//
+	// Note:
+	//   - Can't contain the substrings "clyde" or "discord" (case-insensitive).
+	Name string `json:"name"`
+
+	// Avatar is the image for the default webhook avatar.
+	Avatar Base64Image `json:"avatar,omitempty"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// CreateWebhook creates a new incoming webhook in a channel.
+//
+// Requires the PermissionManageWebhooks permission.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#create-webhook
+func (r *requester) CreateWebhook(channelID Snowflake, opts CreateWebhookOptions) result.Result[Webhook] {
+	reqBody, _ := json.Marshal(opts)
+	res := r.DoRequest(Request{
+		Method: "POST",
+		URL:    "/channels/" + channelID.String() + "/webhooks",
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[Webhook](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var webhook Webhook
+	if err := json.NewDecoder(body).Decode(&webhook); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/channels/{id}/webhooks",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Webhook](err)
+	}
+	return result.Ok(webhook)
+}
+
+// FetchChannelWebhooks retrieves the webhooks of a channel.
+//
+// Requires the PermissionManageWebhooks permission.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#get-channel-webhooks
+func (r *requester) FetchChannelWebhooks(channelID Snowflake) result.Result[[]Webhook] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/channels/" + channelID.String() + "/webhooks"})
+	if res.IsErr() {
+		return result.Err[[]Webhook](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var webhooks []Webhook
+	if err := json.NewDecoder(body).Decode(&webhooks); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/channels/{id}/webhooks",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]Webhook](err)
+	}
+	return result.Ok(webhooks)
+}
+
+// FetchGuildWebhooks retrieves the webhooks of a guild.
+//
+// Requires the PermissionManageWebhooks permission.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#get-guild-webhooks
+func (r *requester) FetchGuildWebhooks(guildID Snowflake) result.Result[[]Webhook] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/guilds/" + guildID.String() + "/webhooks"})
+	if res.IsErr() {
+		return result.Err[[]Webhook](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var webhooks []Webhook
+	if err := json.NewDecoder(body).Decode(&webhooks); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/guilds/{id}/webhooks",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[[]Webhook](err)
+	}
+	return result.Ok(webhooks)
+}
+
+// FetchWebhook retrieves a webhook by its ID.
+//
+// Requires the PermissionManageWebhooks permission unless the application making the request owns the webhook.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#get-webhook
+func (r *requester) FetchWebhook(webhookID Snowflake) result.Result[Webhook] {
+	res := r.DoRequest(Request{Method: "GET", URL: "/webhooks/" + webhookID.String()})
+	if res.IsErr() {
+		return result.Err[Webhook](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var webhook Webhook
+	if err := json.NewDecoder(body).Decode(&webhook); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "GET",
+			"url":    "/webhooks/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Webhook](err)
+	}
+	return result.Ok(webhook)
+}
+
+// ModifyWebhookOptions contains parameters for modifying a webhook.
+//
+// All fields are optional, only the fields that are set will be updated.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#modify-webhook-json-params
+type ModifyWebhookOptions struct {
+	// Name is the new default name of the webhook (1-80 characters).
+	Name optional.Option[string] `json:"name,omitzero"`
+
+	// Avatar is the new image for the default webhook avatar.
+	//
+	// Note:
+	//   - Set to optional.Nil to remove the avatar.
+	Avatar optional.Option[Base64Image] `json:"avatar,omitzero"`
+
+	// ChannelID is the new channel the webhook should be moved to.
+	ChannelID optional.Option[Snowflake] `json:"channel_id,omitzero"`
+
+	// Reason is the reason shown in the audit log for this action.
+	Reason string `json:"-"`
+}
+
+// ModifyWebhook updates a webhook.
+//
+// Requires the PermissionManageWebhooks permission.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#modify-webhook
+func (r *requester) ModifyWebhook(webhookID Snowflake, opts ModifyWebhookOptions) result.Result[Webhook] {
+	reqBody, err := MarshalPartial(opts)
+	if err != nil {
+		return result.Err[Webhook](err)
+	}
+	res := r.DoRequest(Request{
+		Method: "PATCH",
+		URL:    "/webhooks/" + webhookID.String(),
+		Body:   reqBody,
+		Reason: opts.Reason,
+	})
+	if res.IsErr() {
+		return result.Err[Webhook](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var webhook Webhook
+	if err := json.NewDecoder(body).Decode(&webhook); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/webhooks/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Webhook](err)
+	}
+	return result.Ok(webhook)
+}
+
+// DeleteWebhook permanently deletes a webhook.
+//
+// Requires the PermissionManageWebhooks permission.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#delete-webhook
+func (r *requester) DeleteWebhook(webhookID Snowflake, reason string) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    "/webhooks/" + webhookID.String(),
+		Reason: reason,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
+
+// ExecuteWebhookOptions contains parameters for executing a webhook.
+//
+// Note:
+//   - At least one of Content, Embeds, Components or Files is required.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#execute-webhook-jsonform-params
+type ExecuteWebhookOptions struct {
+	// Content is the message text (max 2000 characters).
+	Content string `json:"content,omitempty"`
+
+	// Username overrides the default username of the webhook.
+	Username string `json:"username,omitempty"`
+
+	// AvatarURL overrides the default avatar of the webhook.
+	AvatarURL string `json:"avatar_url,omitempty"`
+
+	// Embeds is the list of rich embeds to include (max 10).
+	Embeds []Embed `json:"embeds,omitempty"`
+
+	// Components is the list of components to include with the message.
+	//
+	// Note:
+	//   - Interactive components require an application-owned webhook.
+	Components []LayoutComponent `json:"components,omitempty"`
+
+	// AllowedMentions controls which mentions will notify their targets.
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// Flags is the message flags to set.
+	Flags MessageFlags `json:"flags,omitempty"`
+
+	// ThreadName is the name of the thread to create, only for forum and media channel webhooks.
+	ThreadName string `json:"thread_name,omitempty"`
+
+	// Files is the list of files to upload as attachments.
+	Files []File `json:"-"`
+
+	// Wait makes Discord return the created message, instead of confirming with no content.
+	Wait bool `json:"-"`
+
+	// ThreadID sends the message to this thread of the webhook's channel, the thread is unarchived automatically.
+	ThreadID Snowflake `json:"-"`
+}
+
+// webhookEndpoint returns the endpoint of a webhook with its token, with the given path suffix and query.
+func webhookEndpoint(webhookID Snowflake, token, suffix string, query url.Values) string {
+	endpoint := "/webhooks/" + webhookID.String() + "/" + token + suffix
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	return endpoint
+}
+
+// webhookThreadQuery returns the query parameters targeting a thread, empty if threadID is not set.
+func webhookThreadQuery(threadID Snowflake) url.Values {
+	query := url.Values{}
+	if !threadID.UnSet() {
+		query.Set("thread_id", threadID.String())
+	}
+	return query
+}
+
+// ExecuteWebhook sends a message through a webhook using its token.
+//
+// Returns Some with the created message if opts.Wait is true, None otherwise.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#execute-webhook
+func (r *requester) ExecuteWebhook(webhookID Snowflake, token string, opts ExecuteWebhookOptions) result.Result[optional.Option[Message]] {
+	payload, err := json.Marshal(struct {
+		ExecuteWebhookOptions
+		Attachments []attachmentPayload `json:"attachments,omitempty"`
+	}{opts, newAttachmentPayloads(opts.Files)})
+	if err != nil {
+		return result.Err[optional.Option[Message]](err)
+	}
+	reqBody, contentType, err := encodeMessageBody(payload, opts.Files)
+	if err != nil {
+		return result.Err[optional.Option[Message]](err)
+	}
+
+	query := webhookThreadQuery(opts.ThreadID)
+	if opts.Wait {
+		query.Set("wait", "true")
+	}
+
+	res := r.DoRequest(Request{
+		Method:      "POST",
+		URL:         webhookEndpoint(webhookID, token, "", query),
+		Body:        reqBody,
+		ContentType: contentType,
+		NoAuth:      true,
+	})
+	if res.IsErr() {
+		return result.Err[optional.Option[Message]](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	if !opts.Wait {
+		return result.Ok(optional.None[Message]())
+	}
+
+	var message Message
+	if err := json.NewDecoder(body).Decode(&message); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "POST",
+			"url":    "/webhooks/{id}/{token}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[optional.Option[Message]](err)
+	}
+	return result.Ok(optional.Some(message))
+}
+
+// EditWebhookMessageOptions contains parameters for editing a message sent by a webhook.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#edit-webhook-message-jsonform-params
+type EditWebhookMessageOptions struct {
+	EditMessageOptions
+
+	// ThreadID is the thread the message is in.
+	ThreadID Snowflake `json:"-"`
+}
+
+// EditWebhookMessage edits a message previously sent by the same webhook.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#edit-webhook-message
+func (r *requester) EditWebhookMessage(webhookID Snowflake, token string, messageID Snowflake, opts EditWebhookMessageOptions) result.Result[Message] {
+	payload, err := MarshalPartial(struct {
+		EditMessageOptions
+		Attachments []attachmentPayload `json:"attachments,omitzero"`
+	}{opts.EditMessageOptions, opts.attachmentPayloads()})
+	if err != nil {
+		return result.Err[Message](err)
+	}
+	reqBody, contentType, err := encodeMessageBody(payload, opts.Files)
+	if err != nil {
+		return result.Err[Message](err)
+	}
+
+	res := r.DoRequest(Request{
+		Method:      "PATCH",
+		URL:         webhookEndpoint(webhookID, token, "/messages/"+messageID.String(), webhookThreadQuery(opts.ThreadID)),
+		Body:        reqBody,
+		ContentType: contentType,
+		NoAuth:      true,
+	})
+	if res.IsErr() {
+		return result.Err[Message](res.Err())
+	}
+	body := res.Value()
+	defer body.Close()
+
+	var message Message
+	if err := json.NewDecoder(body).Decode(&message); err != nil {
+		r.logger.WithFields(map[string]any{
+			"method": "PATCH",
+			"url":    "/webhooks/{id}/{token}/messages/{id}",
+			"error":  err.Error(),
+		}).Error("failed parsing response")
+		return result.Err[Message](err)
+	}
+	return result.Ok(message)
+}
+
+// DeleteWebhookMessage deletes a message previously sent by the same webhook.
+//
+// threadID is the thread the message is in, 0 if it's not in a thread.
+//
+// Reference: https://discord.com/developers/docs/resources/webhook#delete-webhook-message
+func (r *requester) DeleteWebhookMessage(webhookID Snowflake, token string, messageID, threadID Snowflake) result.Void {
+	res := r.DoRequest(Request{
+		Method: "DELETE",
+		URL:    webhookEndpoint(webhookID, token, "/messages/"+messageID.String(), webhookThreadQuery(threadID)),
+		NoAuth: true,
+	})
+	if res.IsErr() {
+		return result.ErrVoid(res.Err())
+	}
+	res.Value().Close()
+	return result.OkVoid()
+}
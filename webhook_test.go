@@ -0,0 +1,203 @@
+/************************************************************************************
+ *
+ * dwaz (Discord Wrapper API for Zwafriya), A Lightweight Go library for Discord API
+ *
+ * SPDX-License-Identifier: BSD-3-Clause
+ *
+ * Copyright 2025 Marouane Souiri
+ *
+ * Licensed under the BSD 3-Clause License.
+ * See the LICENSE file for details.
+ *
+ ************************************************************************************/
+
+package dwaz
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/marouanesouiri/stdx/optional"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/channels/10/webhooks" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["name"] != "announcer" {
+			t.Errorf("name = %v, want announcer", body["name"])
+		}
+		if _, ok := body["avatar"]; ok {
+			t.Error("avatar should be omitted when empty")
+		}
+		io.WriteString(w, `{"id":"50","type":1,"channel_id":"10","guild_id":"1","name":"announcer","token":"secret"}`)
+	})
+
+	res := r.CreateWebhook(10, CreateWebhookOptions{Name: "announcer"})
+	if res.IsErr() {
+		t.Fatalf("CreateWebhook: %v", res.Err())
+	}
+	webhook := res.Value()
+	if webhook.ID != 50 || webhook.Token != "secret" {
+		t.Errorf("webhook = %+v", webhook)
+	}
+	if got := webhook.ExecuteURL(); got != "https://discord.com/api/webhooks/50/secret" {
+		t.Errorf("ExecuteURL() = %q", got)
+	}
+}
+
+func TestModifyWebhookBody(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch || req.URL.Path != "/webhooks/50" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"name":"renamed","avatar":null}` {
+			t.Errorf("body = %s", body)
+		}
+		io.WriteString(w, `{"id":"50","type":1,"name":"renamed"}`)
+	})
+
+	res := r.ModifyWebhook(50, ModifyWebhookOptions{
+		Name:   optional.Some("renamed"),
+		Avatar: optional.Nil[Base64Image](),
+	})
+	if res.IsErr() {
+		t.Fatalf("ModifyWebhook: %v", res.Err())
+	}
+	if res.Value().Name != "renamed" {
+		t.Errorf("name = %q, want renamed", res.Value().Name)
+	}
+}
+
+func TestDecodeWebhookTypes(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		io.WriteString(w, `[
+			{"id":"50","type":1,"channel_id":"10","name":"incoming","token":"secret","user":{"id":"7","username":"creator"}},
+			{"id":"51","type":2,"channel_id":"10","name":"follower",
+			 "source_guild":{"id":"2","name":"News Guild"},
+			 "source_channel":{"id":"20","name":"announcements"}}
+		]`)
+	})
+
+	res := r.FetchChannelWebhooks(10)
+	if res.IsErr() {
+		t.Fatalf("FetchChannelWebhooks: %v", res.Err())
+	}
+	webhooks := res.Value()
+	if len(webhooks) != 2 {
+		t.Fatalf("expected 2 webhooks, got %d", len(webhooks))
+	}
+
+	incoming := webhooks[0]
+	if !incoming.Type.Is(WebhookTypeIncoming) || incoming.Token != "secret" || incoming.User == nil || incoming.User.ID != 7 {
+		t.Errorf("unexpected incoming webhook %+v", incoming)
+	}
+
+	follower := webhooks[1]
+	if !follower.Type.Is(WebhookTypeChannelFollower) || follower.Token != "" {
+		t.Errorf("unexpected follower webhook %+v", follower)
+	}
+	if follower.SourceGuild == nil || follower.SourceGuild.Name != "News Guild" {
+		t.Errorf("unexpected source guild %+v", follower.SourceGuild)
+	}
+	if follower.SourceChannel == nil || follower.SourceChannel.ID != 20 || follower.SourceChannel.Name != "announcements" {
+		t.Errorf("unexpected source channel %+v", follower.SourceChannel)
+	}
+}
+
+func TestExecuteWebhookWait(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/webhooks/50/secret" {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		if got := req.URL.Query().Get("wait"); got != "true" {
+			t.Errorf("wait = %q, want true", got)
+		}
+		if got := req.URL.Query().Get("thread_id"); got != "77" {
+			t.Errorf("thread_id = %q, want 77", got)
+		}
+		if req.Header.Get("Authorization") != "" {
+			t.Error("webhook execution should not send the bot token")
+		}
+		var body map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["content"] != "hello" || body["username"] != "bot" {
+			t.Errorf("body = %v", body)
+		}
+		io.WriteString(w, `{"id":"900","channel_id":"77","content":"hello"}`)
+	})
+
+	res := r.ExecuteWebhook(50, "secret", ExecuteWebhookOptions{
+		Content:  "hello",
+		Username: "bot",
+		Wait:     true,
+		ThreadID: 77,
+	})
+	if res.IsErr() {
+		t.Fatalf("ExecuteWebhook: %v", res.Err())
+	}
+	if !res.Value().IsPresent() {
+		t.Fatal("expected a message when waiting")
+	}
+	message := res.Value().Get()
+	if message.ID != 900 || message.Content != "hello" {
+		t.Errorf("message = %+v", message)
+	}
+}
+
+func TestExecuteWebhookNoWait(t *testing.T) {
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.RawQuery != "" {
+			t.Errorf("query = %q, want empty", req.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := r.ExecuteWebhook(50, "secret", ExecuteWebhookOptions{Content: "hello"})
+	if res.IsErr() {
+		t.Fatalf("ExecuteWebhook: %v", res.Err())
+	}
+	if res.Value().IsPresent() {
+		t.Error("expected no message without wait")
+	}
+}
+
+func TestWebhookMessageThreadQuery(t *testing.T) {
+	var calls []string
+	r := newTestRequester(t, func(w http.ResponseWriter, req *http.Request) {
+		calls = append(calls, req.Method+" "+req.URL.Path+"?"+req.URL.RawQuery)
+		if req.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		io.WriteString(w, `{"id":"900","channel_id":"77","content":"edited"}`)
+	})
+
+	if res := r.EditWebhookMessage(50, "secret", 900, EditWebhookMessageOptions{
+		EditMessageOptions: EditMessageOptions{Content: optional.Some("edited")},
+		ThreadID:           77,
+	}); res.IsErr() {
+		t.Fatalf("EditWebhookMessage: %v", res.Err())
+	}
+	if res := r.DeleteWebhookMessage(50, "secret", 900, 0); res.IsErr() {
+		t.Fatalf("DeleteWebhookMessage: %v", res.Err())
+	}
+
+	want := []string{
+		"PATCH /webhooks/50/secret/messages/900?thread_id=77",
+		"DELETE /webhooks/50/secret/messages/900?",
+	}
+	if len(calls) != len(want) || calls[0] != want[0] || calls[1] != want[1] {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}